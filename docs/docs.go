@@ -22,7 +22,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Agrupa e analisa transações por categoria e descrição",
+                "description": "Agrupa e analisa transações por categoria e descrição. Com rollup=true, as subcategorias do catálogo do usuário entram no total da categoria de primeiro nível",
                 "consumes": [
                     "application/json"
                 ],
@@ -42,6 +42,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/analysis.AnalysisRequest"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Agrupar subcategorias na categoria de primeiro nível",
+                        "name": "rollup",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -68,6 +74,15 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
@@ -217,41 +232,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/expenses": {
+        "/bills": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retorna todas as despesas do usuário autenticado com filtros opcionais",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retorna os boletos e contas de consumo do usuário pelo vencimento, indicando as pendentes já vencidas",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "expenses"
+                    "bills"
                 ],
-                "summary": "Lista todas as despesas",
+                "summary": "Lista as contas a pagar",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Data inicial (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Data final (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filtrar por categoria",
-                        "name": "category",
+                        "description": "Filtrar pela situação (pending ou paid)",
+                        "name": "status",
                         "in": "query"
                     }
                 ],
@@ -298,7 +298,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Cria uma nova despesa para o usuário autenticado",
+                "description": "Cadastra um boleto ou conta de consumo pendente a partir do código colado. Valor e vencimento vêm do código; quando ele não traz o valor (comum em contas de consumo), amount é obrigatório. A despesa só é criada quando a conta é paga",
                 "consumes": [
                     "application/json"
                 ],
@@ -306,17 +306,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "expenses"
+                    "bills"
                 ],
-                "summary": "Cria uma nova despesa",
+                "summary": "Cadastra uma conta a pagar",
                 "parameters": [
                     {
-                        "description": "Dados da despesa",
+                        "description": "Código e dados da conta",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/expense.CreateExpenseRequest"
+                            "$ref": "#/definitions/bill.CreateBillRequest"
                         }
                     }
                 ],
@@ -324,7 +324,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/expense.Expense"
+                            "$ref": "#/definitions/bill.Bill"
                         }
                     },
                     "400": {
@@ -345,6 +345,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -357,14 +366,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/expenses/import": {
+        "/bills/parse": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Importa múltiplas transações de uma só vez para o usuário autenticado",
+                "description": "Valida os dígitos verificadores do código de barras (44 dígitos) ou da linha digitável (47 dígitos para boleto bancário, 48 para convênio) e retorna banco, segmento, valor e vencimento, sem salvar nada",
                 "consumes": [
                     "application/json"
                 ],
@@ -372,26 +381,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "expenses"
+                    "bills"
                 ],
-                "summary": "Importa transações em lote",
+                "summary": "Lê um boleto ou conta de consumo",
                 "parameters": [
                     {
-                        "description": "Lista de transações",
+                        "description": "Código de barras ou linha digitável",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/expense.ImportTransactionsRequest"
+                            "$ref": "#/definitions/bill.ParseCodeRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/bill.Code"
                         }
                     },
                     "400": {
@@ -411,60 +419,43 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 }
             }
         },
-        "/expenses/stats": {
+        "/bills/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retorna estatísticas agregadas das despesas do usuário autenticado",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retorna o boleto ou conta de consumo",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "expenses"
+                    "bills"
                 ],
-                "summary": "Obtém estatísticas das despesas",
+                "summary": "Busca uma conta a pagar por ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Data inicial (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Data final (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "ID da conta",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/expense.ExpenseStats"
+                            "$ref": "#/definitions/bill.Bill"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -472,8 +463,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -491,30 +482,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/expenses/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retorna uma despesa específica do usuário autenticado",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Remove o boleto ou conta de consumo; a despesa de uma conta já paga não é alterada",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "expenses"
+                    "bills"
                 ],
-                "summary": "Busca uma despesa por ID",
+                "summary": "Remove uma conta a pagar",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID da despesa",
+                        "description": "ID da conta",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -524,7 +510,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/expense.Expense"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "401": {
@@ -555,14 +544,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/bills/{id}/pay": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Atualiza uma despesa existente do usuário autenticado",
+                "description": "Marca a conta como paga e cria a despesa correspondente. O valor pago (com multa ou desconto) e a data do pagamento são opcionais; por padrão, o valor da conta e a data de hoje",
                 "consumes": [
                     "application/json"
                 ],
@@ -570,24 +561,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "expenses"
+                    "bills"
                 ],
-                "summary": "Atualiza uma despesa",
+                "summary": "Registra o pagamento de uma conta",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID da despesa",
+                        "description": "ID da conta",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Dados atualizados da despesa",
+                        "description": "Dados do pagamento",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/expense.UpdateExpenseRequest"
+                            "$ref": "#/definitions/bill.PayBillRequest"
                         }
                     }
                 ],
@@ -595,7 +585,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/expense.Expense"
+                            "$ref": "#/definitions/bill.Bill"
                         }
                     },
                     "400": {
@@ -625,6 +615,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -635,14 +634,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/categories": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove uma despesa do usuário autenticado",
+                "description": "Lista o catálogo de categorias do usuário em ordem alfabética. As arquivadas só aparecem com include_archived=true",
                 "consumes": [
                     "application/json"
                 ],
@@ -650,21 +651,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "expenses"
+                    "categories"
                 ],
-                "summary": "Deleta uma despesa",
+                "summary": "Lista as categorias",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID da despesa",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Incluir categorias arquivadas",
+                        "name": "include_archived",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -681,8 +688,72 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adiciona uma categoria ao catálogo do usuário. Nomes que só diferem em maiúsculas, acentos ou pontuação são a mesma categoria. Com parent_id, vira subcategoria (Alimentação \u003e Delivery)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Cria uma categoria",
+                "parameters": [
+                    {
+                        "description": "Dados da categoria",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/category.CreateCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/category.Category"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -702,30 +773,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/parser/upload/csv": {
-            "post": {
+        "/categories/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Faz upload de um arquivo CSV, categoriza e salva as transações automaticamente",
+                "description": "Retorna uma categoria do catálogo do usuário",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "parser"
+                    "categories"
                 ],
-                "summary": "Upload CSV e salvar automaticamente",
+                "summary": "Busca uma categoria",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "CSV file",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "ID da categoria",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -733,11 +804,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/parser.ImportAndSaveResponse"
+                            "$ref": "#/definitions/category.Category"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -745,8 +816,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -764,169 +835,6164 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "analysis.AnalysisRequest": {
-            "type": "object",
-            "properties": {
-                "transactions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/analysis.Transaction"
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
                     }
-                }
-            }
-        },
+                ],
+                "description": "Altera só os campos enviados. Ao renomear, as despesas, parcelamentos, contas e regras da categoria passam a usar o novo nome. parent_id vazio torna a categoria de primeiro nível",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Atualiza uma categoria",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da categoria",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos a alterar",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/category.UpdateCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/category.Category"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a categoria do catálogo. As despesas mantêm o nome e as subcategorias sobem para a categoria pai dela",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Remove uma categoria",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da categoria",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Junta outras categorias do catálogo e nomes livres usados nas despesas à categoria. As categorias juntadas são removidas, suas subcategorias passam para esta, e todas as despesas com esses nomes, ou com outra grafia do nome desta, são renomeadas em uma única transação",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Junta categorias",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da categoria que fica",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Categorias e nomes a juntar",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/category.MergeCategoriesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/category.MergeCategoriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna todas as despesas do usuário autenticado com filtros opcionais",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expenses"
+                ],
+                "summary": "Lista todas as despesas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Data inicial (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrar por categoria",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrar por lote de importação",
+                        "name": "batch_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrar por compra parcelada",
+                        "name": "installment_plan_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrar por conta (migrada de outro app)",
+                        "name": "account",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrar por tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cria uma nova despesa para o usuário autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expenses"
+                ],
+                "summary": "Cria uma nova despesa",
+                "parameters": [
+                    {
+                        "description": "Dados da despesa",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/expense.CreateExpenseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/expense.Expense"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/foreign": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna as compras em moeda estrangeira agrupadas por mês ou por viagem (compras com até 7 dias de intervalo), com o IOF de cada compra somado ao custo total e a cotação efetiva por moeda",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expenses"
+                ],
+                "summary": "Relatório de gastos em moeda estrangeira",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Data inicial (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Agrupamento (month ou trip, padrão month)",
+                        "name": "group_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/expense.ForeignSpendingReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Importa múltiplas transações de uma só vez para o usuário autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expenses"
+                ],
+                "summary": "Importa transações em lote",
+                "parameters": [
+                    {
+                        "description": "Lista de transações",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/expense.ImportTransactionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/imports": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna o histórico de importações do usuário autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expenses"
+                ],
+                "summary": "Lista os lotes de importação",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/imports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna um lote de importação e as despesas criadas por ele",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expenses"
+                ],
+                "summary": "Busca um lote de importação",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do lote de importação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/expense.ImportBatchDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/imports/{id}/rollback": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove, em uma única transação, todas as despesas criadas por um lote de importação. Lotes ainda em processamento precisam ser cancelados antes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expenses"
+                ],
+                "summary": "Desfaz um lote de importação",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do lote de importação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/installments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna as compras parceladas detectadas nas importações, com parcelas pagas, restantes e a data da próxima parcela",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expenses"
+                ],
+                "summary": "Lista as compras parceladas",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/installments/upcoming": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna as parcelas ainda não pagas agrupadas por mês, a partir do mês atual, com o total comprometido em cada mês",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expenses"
+                ],
+                "summary": "Projeta as parcelas futuras",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de meses projetados (1 a 60, padrão 12)",
+                        "name": "months",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/installments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna uma compra parcelada com todas as parcelas, pagas e pendentes, e as despesas importadas de cada uma",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expenses"
+                ],
+                "summary": "Busca uma compra parcelada",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da compra parcelada",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/expense.InstallmentPlanDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/recategorize": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Passa as despesas filtradas pela categorização atual (regras, classificador e palavras-chave) e lista o que mudaria: despesa, categoria antiga, categoria nova e motivo. Nada é salvo. Despesas com categoria escolhida pelo usuário (à mão ou vinda do app de origem) ficam de fora, a não ser com force=true",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expenses"
+                ],
+                "summary": "Prévia da recategorização",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Data inicial",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Categoria",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo (income ou expense)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trecho da descrição",
+                        "name": "description",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da importação",
+                        "name": "batch_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Recategorizar também as categorias escolhidas pelo usuário",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/categorizer.RecategorizePreview"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Recalcula a prévia com os mesmos filtros e salva as mudanças em uma única transação. Com expense_ids, só as despesas aceitas na prévia mudam",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expenses"
+                ],
+                "summary": "Aplica a recategorização",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Data inicial",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Categoria",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo (income ou expense)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trecho da descrição",
+                        "name": "description",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da importação",
+                        "name": "batch_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Recategorizar também as categorias escolhidas pelo usuário",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Despesas aceitas",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/categorizer.ApplyRecategorizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/categorizer.RecategorizeResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna estatísticas agregadas das despesas do usuário autenticado, com os totais por categoria. Com rollup=true, as subcategorias do catálogo entram no total da categoria de primeiro nível",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expenses"
+                ],
+                "summary": "Obtém estatísticas das despesas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Data inicial (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Agrupar subcategorias na categoria de primeiro nível",
+                        "name": "rollup",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/expense.ExpenseStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna uma despesa específica do usuário autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expenses"
+                ],
+                "summary": "Busca uma despesa por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da despesa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/expense.Expense"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Atualiza uma despesa existente do usuário autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expenses"
+                ],
+                "summary": "Atualiza uma despesa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da despesa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados atualizados da despesa",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/expense.UpdateExpenseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/expense.Expense"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove uma despesa do usuário autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "expenses"
+                ],
+                "summary": "Deleta uma despesa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da despesa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/inbox/address": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna o endereço para onde encaminhar os e-mails de compra do banco, criando-o no primeiro acesso. As compras das notificações reconhecidas são salvas assim que o e-mail chega. enabled indica se o servidor SMTP está ativo (SMTP_ADDR)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inbox"
+                ],
+                "summary": "Endereço de e-mail para notificações",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/inbox.Address"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/inbox/address/regenerate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Troca o endereço de e-mail do usuário; e-mails enviados ao endereço anterior passam a ser recusados",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inbox"
+                ],
+                "summary": "Gera um novo endereço de e-mail",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/inbox.Address"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista os jobs de importação do usuário, do mais recente ao mais antigo. Jobs finalizados são mantidos por 24 horas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Listar jobs de importação",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/jobs/csv": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Recebe um arquivo CSV e o importa em segundo plano, lendo e salvando as linhas em lotes. Indicado para arquivos grandes; acompanhe o progresso em /parser/jobs/{id}",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Importar CSV em segundo plano",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Perfil do banco (ex.: nubank_conta, inter_conta); detectado automaticamente se vazio",
+                        "name": "profile",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID de um mapeamento de colunas salvo (substitui a detecção automática)",
+                        "name": "mapping_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delimitador (auto, ',', ';', tab, '|')",
+                        "name": "delimiter",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Encoding (auto, utf-8, windows-1252, iso-8859-1)",
+                        "name": "encoding",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Formato dos valores (auto, pt-BR, en-US)",
+                        "name": "locale",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Falha o job e desfaz o lote se alguma linha for inválida",
+                        "name": "strict",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos",
+                        "name": "statement_kind",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convenção de sinal (auto, debit_positive, debit_negative)",
+                        "name": "sign_convention",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/parser.ImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna o status e o progresso do job: linhas lidas, salvas, duplicadas e rejeitadas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Consultar job de importação",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/parser.ImportJob"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/jobs/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Interrompe um job na fila ou em andamento. As transações já salvas ficam no lote de importação, que pode ser desfeito",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Cancelar job de importação",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/parser.ImportJob"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/mappings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna os mapeamentos de colunas salvos pelo usuário autenticado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Lista os mapeamentos de colunas",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Salva um mapeamento de colunas para importar CSVs de layouts não reconhecidos automaticamente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Cria um mapeamento de colunas",
+                "parameters": [
+                    {
+                        "description": "Mapeamento de colunas",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/parser.ColumnMappingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/parser.ColumnMapping"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/mappings/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna um mapeamento de colunas do usuário autenticado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Busca um mapeamento de colunas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do mapeamento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/parser.ColumnMapping"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Substitui as colunas e opções de um mapeamento salvo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Atualiza um mapeamento de colunas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do mapeamento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Mapeamento de colunas",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/parser.ColumnMappingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/parser.ColumnMapping"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove um mapeamento de colunas do usuário autenticado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Remove um mapeamento de colunas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do mapeamento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/migrate": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista os apps de finanças cujas exportações podem ser importadas e os formatos de arquivo aceitos de cada um",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Listar apps de origem para migração",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/migrate/{app}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Importa a exportação de outro app de finanças (QIF, Mobills, Organizze, GnuCash ou Money Lover) mantendo as categorias, contas e tags do app de origem. Só os lançamentos sem categoria são categorizados automaticamente",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Migrar lançamentos de outro app",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "App de origem (qif, mobills, organizze, gnucash, moneylover)",
+                        "name": "app",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Arquivo exportado (CSV, XLSX ou QIF, conforme o app)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Rejeita o arquivo inteiro se alguma linha for inválida",
+                        "name": "strict",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos",
+                        "name": "statement_kind",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convenção de sinal (auto, debit_positive, debit_negative)",
+                        "name": "sign_convention",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/parser.ImportAndSaveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/profiles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista os layouts de extrato reconhecidos na importação de CSV e a convenção de sinal de cada um",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Listar perfis de importação",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/staged/camt053": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Faz upload de um extrato ISO 20022 CAMT.053 (XML), categoriza os lançamentos e guarda o resultado como importação pendente, sem salvar",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Upload CAMT.053 para pré-visualização",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CAMT.053 XML file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Rejeita o arquivo inteiro se algum lançamento for inválido",
+                        "name": "strict",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos",
+                        "name": "statement_kind",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convenção de sinal (auto, debit_positive, debit_negative)",
+                        "name": "sign_convention",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/parser.StagedImport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/staged/csv": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Faz upload de um arquivo CSV, categoriza as transações e guarda o resultado como importação pendente, sem salvar",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Upload CSV para pré-visualização",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Perfil do banco (ex.: nubank_conta, inter_conta); detectado automaticamente se vazio",
+                        "name": "profile",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID de um mapeamento de colunas salvo (substitui a detecção automática)",
+                        "name": "mapping_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delimitador (auto, ',', ';', tab, '|')",
+                        "name": "delimiter",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Encoding (auto, utf-8, windows-1252, iso-8859-1)",
+                        "name": "encoding",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Formato dos valores (auto, pt-BR, en-US)",
+                        "name": "locale",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Rejeita o arquivo inteiro se alguma linha for inválida",
+                        "name": "strict",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos",
+                        "name": "statement_kind",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convenção de sinal (auto, debit_positive, debit_negative)",
+                        "name": "sign_convention",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/parser.StagedImport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/staged/ofx": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Faz upload de um extrato OFX, categoriza as transações e guarda o resultado como importação pendente, sem salvar",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Upload OFX para pré-visualização",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "OFX file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Rejeita o arquivo inteiro se alguma transação for inválida",
+                        "name": "strict",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos",
+                        "name": "statement_kind",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convenção de sinal (auto, debit_positive, debit_negative)",
+                        "name": "sign_convention",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/parser.StagedImport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/staged/pdf": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Faz upload da fatura do cartão em PDF (layout Nubank), categoriza as transações e guarda o resultado como importação pendente, sem salvar",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Upload de fatura PDF para pré-visualização",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "PDF file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Rejeita o arquivo inteiro se alguma linha for inválida",
+                        "name": "strict",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos",
+                        "name": "statement_kind",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convenção de sinal (auto, debit_positive, debit_negative)",
+                        "name": "sign_convention",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/parser.StagedImport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/staged/xlsx": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Faz upload de uma planilha Excel (.xlsx), categoriza as transações e guarda o resultado como importação pendente, sem salvar",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Upload de planilha XLSX para pré-visualização",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "XLSX file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nome ou número (a partir de 1) da planilha; padrão é a primeira",
+                        "name": "sheet",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número da linha do cabeçalho; detectado automaticamente se vazio",
+                        "name": "header_row",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Perfil do banco (ex.: nubank_conta, inter_conta); detectado automaticamente se vazio",
+                        "name": "profile",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID de um mapeamento de colunas salvo (substitui a detecção automática)",
+                        "name": "mapping_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Formato dos valores em células de texto (auto, pt-BR, en-US)",
+                        "name": "locale",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Rejeita o arquivo inteiro se alguma linha for inválida",
+                        "name": "strict",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos",
+                        "name": "statement_kind",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convenção de sinal (auto, debit_positive, debit_negative)",
+                        "name": "sign_convention",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/parser.StagedImport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/staged/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna as linhas parseadas e categorizadas de uma importação ainda não confirmada",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Busca uma importação pendente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da importação pendente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/parser.StagedImport"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a importação pendente sem salvar nenhuma transação",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Descarta uma importação pendente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da importação pendente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/staged/{id}/commit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Salva as linhas não excluídas da importação pendente como despesas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Confirma uma importação pendente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da importação pendente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/parser.ImportAndSaveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/staged/{id}/rows/{index}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Altera a categoria ou a descrição de uma linha, ou a exclui da importação",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Edita uma linha da importação pendente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da importação pendente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Índice da linha",
+                        "name": "index",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alterações da linha",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/parser.UpdateStagedRowRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/parser.StagedImport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/upload/camt053": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Faz upload de um extrato ISO 20022 CAMT.053 (XML), categoriza e salva os lançamentos contabilizados automaticamente. A referência de cada lançamento é usada para evitar duplicatas",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Upload CAMT.053 e salvar automaticamente",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CAMT.053 XML file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Rejeita o arquivo inteiro se algum lançamento for inválido",
+                        "name": "strict",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos",
+                        "name": "statement_kind",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convenção de sinal (auto, debit_positive, debit_negative)",
+                        "name": "sign_convention",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/parser.ImportAndSaveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/upload/csv": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Faz upload de um arquivo CSV, categoriza e salva as transações automaticamente",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Upload CSV e salvar automaticamente",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Perfil do banco (ex.: nubank_conta, inter_conta); detectado automaticamente se vazio",
+                        "name": "profile",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID de um mapeamento de colunas salvo (substitui a detecção automática)",
+                        "name": "mapping_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delimitador (auto, ',', ';', tab, '|')",
+                        "name": "delimiter",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Encoding (auto, utf-8, windows-1252, iso-8859-1)",
+                        "name": "encoding",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Formato dos valores (auto, pt-BR, en-US)",
+                        "name": "locale",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Rejeita o arquivo inteiro se alguma linha for inválida",
+                        "name": "strict",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos",
+                        "name": "statement_kind",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convenção de sinal (auto, debit_positive, debit_negative)",
+                        "name": "sign_convention",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/parser.ImportAndSaveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/upload/email": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Faz upload de um e-mail (.eml) ou de uma caixa exportada (.mbox) com as notificações de compra enviadas pelo banco (Nubank, Itaú, Inter e C6), inclusive encaminhadas. Cada notificação vira uma transação categorizada e salva; o Message-ID evita salvar o mesmo e-mail duas vezes. E-mails que não são notificações conhecidas voltam em rejected_rows",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Upload de e-mails de notificação de compra",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Arquivo .eml ou .mbox",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Rejeita o arquivo inteiro se algum e-mail não for reconhecido",
+                        "name": "strict",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/parser.ImportAndSaveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/upload/ofx": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Faz upload de um extrato OFX (1.x SGML ou 2.x XML), categoriza e salva as transações automaticamente",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Upload OFX e salvar automaticamente",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "OFX file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Rejeita o arquivo inteiro se alguma transação for inválida",
+                        "name": "strict",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos",
+                        "name": "statement_kind",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convenção de sinal (auto, debit_positive, debit_negative)",
+                        "name": "sign_convention",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/parser.ImportAndSaveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/upload/pdf": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Faz upload da fatura do cartão em PDF (layout Nubank), extrai compras, parcelas e IOF, categoriza e salva as transações automaticamente",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Upload de fatura PDF e salvar automaticamente",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "PDF file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Rejeita o arquivo inteiro se alguma linha for inválida",
+                        "name": "strict",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos",
+                        "name": "statement_kind",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convenção de sinal (auto, debit_positive, debit_negative)",
+                        "name": "sign_convention",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/parser.ImportAndSaveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/parser/upload/xlsx": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Faz upload de uma planilha Excel (.xlsx), detecta as colunas como no CSV, categoriza e salva as transações automaticamente",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parser"
+                ],
+                "summary": "Upload de planilha XLSX e salvar automaticamente",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "XLSX file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nome ou número (a partir de 1) da planilha; padrão é a primeira",
+                        "name": "sheet",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número da linha do cabeçalho; detectado automaticamente se vazio",
+                        "name": "header_row",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Perfil do banco (ex.: nubank_conta, inter_conta); detectado automaticamente se vazio",
+                        "name": "profile",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID de um mapeamento de colunas salvo (substitui a detecção automática)",
+                        "name": "mapping_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Formato dos valores em células de texto (auto, pt-BR, en-US)",
+                        "name": "locale",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Rejeita o arquivo inteiro se alguma linha for inválida",
+                        "name": "strict",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos",
+                        "name": "statement_kind",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convenção de sinal (auto, debit_positive, debit_negative)",
+                        "name": "sign_convention",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/parser.ImportAndSaveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pix/expenses": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lê o BR Code e salva o pagamento como despesa, com o nome do recebedor como descrição e a categoria sugerida pelas mesmas regras da importação de extratos. Os campos enviados substituem os do código; amount é obrigatório quando o código não traz o valor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pix"
+                ],
+                "summary": "Cria uma despesa a partir de um PIX copia e cola",
+                "parameters": [
+                    {
+                        "description": "Payload do PIX e dados da despesa",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pix.CreateExpenseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/pix.CreateExpenseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pix/parse": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Valida o CRC16 do BR Code (payload EMV do PIX copia e cola) e retorna chave, nome e cidade do recebedor, valor e txid, sem salvar nada. Códigos dinâmicos trazem a URL da cobrança no lugar da chave",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pix"
+                ],
+                "summary": "Lê um código PIX copia e cola",
+                "parameters": [
+                    {
+                        "description": "Payload do PIX copia e cola",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pix.ParseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pix.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna as notas fiscais importadas pelo usuário autenticado, com os itens",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Lista as notas fiscais",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Data inicial (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrar pela despesa vinculada",
+                        "name": "expense_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts/products": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soma os itens das notas fiscais por produto (código de barras ou descrição): quantidade, total gasto, preço médio e último preço pago",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Gastos por produto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Data inicial (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrar produtos pela descrição",
+                        "name": "description",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts/qrcode": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lê a URL do QR code da NFC-e (ou só os parâmetros dela) e salva a nota. O QR code online traz só a chave de acesso, então a data (issued_at) e o valor (total_amount ou itens) devem ser enviados junto. O XML da mesma nota, enviado depois, completa os itens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Importa uma NFC-e pelo QR code",
+                "parameters": [
+                    {
+                        "description": "URL do QR code e dados da nota",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/receipt.QRCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/receipt.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts/xml": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lê o XML da nota fiscal (NFC-e ou NF-e, com ou sem o protocolo de autorização), salva os itens (produto, quantidade, preço unitário e total) e tenta vincular a nota a uma despesa já salva pela data, valor e CNPJ ou nome do estabelecimento",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Importa o XML de uma NFC-e/NF-e",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "XML da nota fiscal",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/receipt.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna a nota fiscal com os itens",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Busca uma nota fiscal por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da nota fiscal",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/receipt.Receipt"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a nota fiscal e os itens; a despesa vinculada não é alterada",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Remove uma nota fiscal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da nota fiscal",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts/{id}/expense": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Vincula a nota a uma despesa escolhida pelo usuário, quando a busca automática não encontrou ou errou. Com expense_id vazio, desfaz o vínculo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Vincula a nota fiscal a uma despesa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da nota fiscal",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Despesa a vincular",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/receipt.LinkExpenseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/receipt.Receipt"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/rules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna as regras do usuário na ordem em que são aplicadas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rules"
+                ],
+                "summary": "Lista as regras de categorização",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cria uma regra que define a categoria das transações importadas sem categoria. match_type: contains (contém), prefix (começa com), merchant (descrição exata) ou regex. Condições opcionais: faixa de valor (min_amount/max_amount, em valor absoluto), dias da semana (weekdays, 0 = domingo) e tipo (expense ou income). As regras do usuário rodam antes das categorias padrão, da maior prioridade para a menor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rules"
+                ],
+                "summary": "Cria uma regra de categorização",
+                "parameters": [
+                    {
+                        "description": "Dados da regra",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rule.RuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rule.Rule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/rules/test": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Informa qual regra categorizaria a transação, sem salvar nada. Sem date, usa hoje; sem type, expense",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rules"
+                ],
+                "summary": "Testa as regras de categorização",
+                "parameters": [
+                    {
+                        "description": "Transação de exemplo",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rule.Candidate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rule.TestRuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/rules/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna a regra de categorização",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rules"
+                ],
+                "summary": "Busca uma regra de categorização por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da regra",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rule.Rule"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Substitui os dados da regra. Sem enabled, a regra mantém o estado atual",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rules"
+                ],
+                "summary": "Atualiza uma regra de categorização",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da regra",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados da regra",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rule.RuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rule.Rule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a regra; as categorias já aplicadas não são alteradas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rules"
+                ],
+                "summary": "Remove uma regra de categorização",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da regra",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "analysis.AnalysisRequest": {
+            "type": "object",
+            "properties": {
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/analysis.Transaction"
+                    }
+                }
+            }
+        },
         "analysis.AnalysisResponse": {
             "type": "object",
             "properties": {
-                "by_category": {
+                "by_category": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/analysis.CategorySummary"
+                    }
+                },
+                "by_description": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/analysis.DescriptionSummary"
+                    }
+                },
+                "net_balance": {
+                    "type": "number"
+                },
+                "total_income": {
+                    "type": "number"
+                },
+                "total_spent": {
+                    "type": "number"
+                },
+                "transaction_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "analysis.CategorySummary": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "analysis.DescriptionSummary": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "analysis.Transaction": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/auth.User"
+                }
+            }
+        },
+        "auth.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "auth.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "bill.Bill": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "bank_code": {
+                    "type": "string"
+                },
+                "bank_name": {
+                    "type": "string"
+                },
+                "barcode": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "digitable_line": {
+                    "type": "string"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "expense_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "overdue": {
+                    "type": "boolean"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "segment": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "bill.Code": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "bank_code": {
+                    "type": "string"
+                },
+                "bank_name": {
+                    "type": "string"
+                },
+                "barcode": {
+                    "type": "string"
+                },
+                "company_code": {
+                    "type": "string"
+                },
+                "digitable_line": {
+                    "type": "string"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "segment": {
+                    "type": "string"
+                }
+            }
+        },
+        "bill.CreateBillRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "category": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "due_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "bill.ParseCodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "bill.PayBillRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "paid_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "categorizer.ApplyRecategorizationRequest": {
+            "type": "object",
+            "properties": {
+                "expense_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "categorizer.CategoryChange": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "confidence": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "expense_id": {
+                    "type": "string"
+                },
+                "new_category": {
+                    "type": "string"
+                },
+                "old_category": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "categorizer.RecategorizePreview": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/categorizer.CategoryChange"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "unchanged": {
+                    "type": "integer"
+                }
+            }
+        },
+        "categorizer.RecategorizeResult": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/categorizer.CategoryChange"
+                    }
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "category.Category": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "type": "boolean"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "category.CreateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "archived": {
+                    "type": "boolean"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string",
+                    "maxLength": 40
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 60
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "category.MergeCategoriesRequest": {
+            "type": "object",
+            "properties": {
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "category.MergeCategoriesResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/category.Category"
+                },
+                "updated_expenses": {
+                    "type": "integer"
+                }
+            }
+        },
+        "category.UpdateCategoryRequest": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "type": "boolean"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string",
+                    "maxLength": 40
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 60,
+                    "minLength": 1
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "expense.CategoryStats": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "total_expense": {
+                    "type": "number"
+                },
+                "total_income": {
+                    "type": "number"
+                }
+            }
+        },
+        "expense.CreateExpenseRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "date",
+                "description",
+                "type"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "income",
+                        "expense"
+                    ]
+                }
+            }
+        },
+        "expense.Expense": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "description": "Account and Tags come from the app the expenses were migrated from.",
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "batch_id": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "category_source": {
+                    "description": "CategorySource tells who chose the category; see the CategorySource\nconstants. Empty for expenses without a category and for ones saved\nbefore it was recorded, which re-categorization may overwrite.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "type": "number"
+                },
+                "fingerprint": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "installment_number": {
+                    "type": "integer"
+                },
+                "installment_plan_id": {
+                    "type": "string"
+                },
+                "original_amount": {
+                    "type": "number"
+                },
+                "original_currency": {
+                    "description": "Purchases made in another currency keep the charged amount and the\nexchange rate; Amount is always in reais. TaxedExpenseID links an IOF\ncharge to the purchase it taxes.",
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "taxed_expense_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "expense.ExpenseStats": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "by_category": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/expense.CategoryStats"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "expense_count": {
+                    "type": "integer"
+                },
+                "income_count": {
+                    "type": "integer"
+                },
+                "total_expense": {
+                    "type": "number"
+                },
+                "total_income": {
+                    "type": "number"
+                }
+            }
+        },
+        "expense.ForeignSpendingGroup": {
+            "type": "object",
+            "properties": {
+                "currencies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "effective_rates": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "expenses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/expense.Expense"
+                    }
+                },
+                "iof_amount": {
+                    "type": "number"
+                },
+                "period": {
+                    "type": "string"
+                },
+                "purchase_amount": {
+                    "type": "number"
+                },
+                "purchases": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "total_cost": {
+                    "type": "number"
+                }
+            }
+        },
+        "expense.ForeignSpendingReport": {
+            "type": "object",
+            "properties": {
+                "group_by": {
+                    "type": "string"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/expense.ForeignSpendingGroup"
+                    }
+                },
+                "iof_amount": {
+                    "type": "number"
+                },
+                "purchase_amount": {
+                    "type": "number"
+                },
+                "total_cost": {
+                    "type": "number"
+                }
+            }
+        },
+        "expense.ImportBatchDetail": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expenses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/expense.Expense"
+                    }
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "rejected_rows": {
+                    "type": "integer"
+                },
+                "rolled_back_at": {
+                    "type": "string"
+                },
+                "saved_rows": {
+                    "type": "integer"
+                },
+                "skipped_duplicates": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "expense.ImportTransactionsRequest": {
+            "type": "object",
+            "required": [
+                "transactions"
+            ],
+            "properties": {
+                "filename": {
+                    "type": "string"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/expense.Transaction"
+                    }
+                }
+            }
+        },
+        "expense.Installment": {
+            "type": "object",
+            "properties": {
+                "number": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "minimum": 2
+                }
+            }
+        },
+        "expense.InstallmentEntry": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "expense_id": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "expense.InstallmentPlanDetail": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "first_installment_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imported_installments": {
+                    "type": "integer"
+                },
+                "installment_amount": {
+                    "type": "number"
+                },
+                "installments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/expense.InstallmentEntry"
+                    }
+                },
+                "next_installment_date": {
+                    "type": "string"
+                },
+                "paid_amount": {
+                    "type": "number"
+                },
+                "paid_installments": {
+                    "type": "integer"
+                },
+                "remaining_amount": {
+                    "type": "number"
+                },
+                "remaining_installments": {
+                    "type": "integer"
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "total_installments": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "expense.Transaction": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "type": "number"
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "installment": {
+                    "description": "Installment is read from the description (\"Parcela 2/10\") when the\nsource does not provide it.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/expense.Installment"
+                        }
+                    ]
+                },
+                "original_amount": {
+                    "type": "number"
+                },
+                "original_currency": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "income",
+                        "expense"
+                    ]
+                }
+            }
+        },
+        "expense.UpdateExpenseRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "income",
+                        "expense"
+                    ]
+                }
+            }
+        },
+        "inbox.Address": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "parser.ColumnMapping": {
+            "type": "object",
+            "properties": {
+                "amount_column": {
+                    "type": "string"
+                },
+                "category_column": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "credit_column": {
+                    "type": "string"
+                },
+                "date_column": {
+                    "type": "string"
+                },
+                "date_format": {
+                    "type": "string"
+                },
+                "debit_column": {
+                    "type": "string"
+                },
+                "description_column": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sign_convention": {
+                    "$ref": "#/definitions/parser.SignConvention"
+                },
+                "skip_rows": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "parser.ColumnMappingRequest": {
+            "type": "object",
+            "required": [
+                "date_column",
+                "name"
+            ],
+            "properties": {
+                "amount_column": {
+                    "type": "string"
+                },
+                "category_column": {
+                    "type": "string"
+                },
+                "credit_column": {
+                    "type": "string"
+                },
+                "date_column": {
+                    "type": "string"
+                },
+                "date_format": {
+                    "type": "string"
+                },
+                "debit_column": {
+                    "type": "string"
+                },
+                "description_column": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sign_convention": {
+                    "enum": [
+                        "debit_positive",
+                        "debit_negative"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/parser.SignConvention"
+                        }
+                    ]
+                },
+                "skip_rows": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "parser.ImportAndSaveResponse": {
+            "type": "object",
+            "properties": {
+                "batch_id": {
+                    "type": "string"
+                },
+                "excluded": {
+                    "type": "integer"
+                },
+                "invoice": {
+                    "$ref": "#/definitions/parser.InvoiceSummary"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "profile": {
+                    "$ref": "#/definitions/parser.ImportProfile"
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "rejected_rows": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/analysis.CategorySummary"
+                        "$ref": "#/definitions/parser.RejectedRow"
                     }
                 },
-                "by_description": {
+                "saved": {
+                    "type": "integer"
+                },
+                "skipped_duplicates": {
+                    "type": "integer"
+                },
+                "transactions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/analysis.DescriptionSummary"
+                        "$ref": "#/definitions/parser.Transaction"
+                    }
+                }
+            }
+        },
+        "parser.ImportJob": {
+            "type": "object",
+            "properties": {
+                "batch_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "parsed": {
+                    "type": "integer"
+                },
+                "profile": {
+                    "$ref": "#/definitions/parser.ImportProfile"
+                },
+                "rejected_rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/parser.RejectedRow"
+                    }
+                },
+                "saved": {
+                    "type": "integer"
+                },
+                "skipped_duplicates": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "parser.ImportProfile": {
+            "type": "object",
+            "properties": {
+                "bank": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "mapping_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sign_convention": {
+                    "$ref": "#/definitions/parser.SignConvention"
+                },
+                "sign_detected": {
+                    "type": "boolean"
+                },
+                "statement_kind": {
+                    "$ref": "#/definitions/parser.StatementKind"
+                }
+            }
+        },
+        "parser.InvoiceSummary": {
+            "type": "object",
+            "properties": {
+                "closing_date": {
+                    "type": "string"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "number"
+                },
+                "transactions_total": {
+                    "type": "number"
+                }
+            }
+        },
+        "parser.RejectedRow": {
+            "type": "object",
+            "properties": {
+                "line": {
+                    "type": "integer"
+                },
+                "raw": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "parser.SignConvention": {
+            "type": "string",
+            "enum": [
+                "debit_positive",
+                "debit_negative"
+            ],
+            "x-enum-varnames": [
+                "SignDebitPositive",
+                "SignDebitNegative"
+            ]
+        },
+        "parser.StagedImport": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invoice": {
+                    "$ref": "#/definitions/parser.InvoiceSummary"
+                },
+                "profile": {
+                    "$ref": "#/definitions/parser.ImportProfile"
+                },
+                "rejected_rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/parser.RejectedRow"
+                    }
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/parser.StagedRow"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "parser.StagedRow": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "category_confidence": {
+                    "type": "number"
+                },
+                "category_source": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "type": "number"
+                },
+                "excluded": {
+                    "type": "boolean"
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "original_amount": {
+                    "type": "number"
+                },
+                "original_currency": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
                 },
-                "net_balance": {
+                "value_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "parser.StatementKind": {
+            "type": "string",
+            "enum": [
+                "credit_card",
+                "checking",
+                "savings"
+            ],
+            "x-enum-varnames": [
+                "StatementCreditCard",
+                "StatementChecking",
+                "StatementSavings"
+            ]
+        },
+        "parser.Transaction": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "category_confidence": {
+                    "type": "number"
+                },
+                "category_source": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exchange_rate": {
                     "type": "number"
                 },
-                "total_income": {
-                    "type": "number"
+                "external_id": {
+                    "type": "string"
                 },
-                "total_spent": {
+                "original_amount": {
                     "type": "number"
                 },
-                "transaction_count": {
-                    "type": "integer"
+                "original_currency": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "value_date": {
+                    "type": "string"
                 }
             }
         },
-        "analysis.CategorySummary": {
+        "parser.UpdateStagedRowRequest": {
             "type": "object",
             "properties": {
-                "average": {
-                    "type": "number"
-                },
                 "category": {
                     "type": "string"
                 },
-                "count": {
-                    "type": "integer"
+                "description": {
+                    "type": "string"
                 },
-                "total": {
-                    "type": "number"
+                "excluded": {
+                    "type": "boolean"
                 }
             }
         },
-        "analysis.DescriptionSummary": {
+        "pix.CreateExpenseRequest": {
             "type": "object",
+            "required": [
+                "payload"
+            ],
             "properties": {
-                "count": {
-                    "type": "integer"
+                "amount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "category": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
                 },
                 "description": {
                     "type": "string"
                 },
-                "total": {
-                    "type": "number"
+                "payload": {
+                    "type": "string"
                 }
             }
         },
-        "analysis.Transaction": {
+        "pix.CreateExpenseResponse": {
+            "type": "object",
+            "properties": {
+                "expense": {
+                    "$ref": "#/definitions/expense.Expense"
+                },
+                "payment": {
+                    "$ref": "#/definitions/pix.Payment"
+                }
+            }
+        },
+        "pix.ParseRequest": {
+            "type": "object",
+            "required": [
+                "payload"
+            ],
+            "properties": {
+                "payload": {
+                    "type": "string"
+                }
+            }
+        },
+        "pix.Payment": {
             "type": "object",
             "properties": {
                 "amount": {
                     "type": "number"
                 },
-                "category": {
+                "dynamic": {
+                    "type": "boolean"
+                },
+                "key": {
                     "type": "string"
                 },
-                "date": {
+                "key_type": {
                     "type": "string"
                 },
-                "description": {
+                "merchant_city": {
+                    "type": "string"
+                },
+                "merchant_name": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "txid": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "auth.AuthResponse": {
+        "receipt.ImportResponse": {
             "type": "object",
             "properties": {
-                "token": {
-                    "type": "string"
+                "expense": {
+                    "$ref": "#/definitions/expense.Expense"
                 },
-                "user": {
-                    "$ref": "#/definitions/auth.User"
+                "receipt": {
+                    "$ref": "#/definitions/receipt.Receipt"
                 }
             }
         },
-        "auth.LoginRequest": {
+        "receipt.Item": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "description"
             ],
             "properties": {
-                "email": {
+                "code": {
                     "type": "string"
                 },
-                "password": {
+                "description": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "ean": {
+                    "type": "string"
+                },
+                "ncm": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "total_amount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "unit": {
                     "type": "string"
+                },
+                "unit_price": {
+                    "type": "number",
+                    "minimum": 0
                 }
             }
         },
-        "auth.RegisterRequest": {
+        "receipt.LinkExpenseRequest": {
+            "type": "object",
+            "properties": {
+                "expense_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "receipt.QRCodeRequest": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "url"
             ],
             "properties": {
-                "email": {
+                "issued_at": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "issuer_name": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.Item"
+                    }
+                },
+                "total_amount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "auth.User": {
+        "receipt.Receipt": {
             "type": "object",
             "properties": {
+                "access_key": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
-                "email": {
+                "discount_amount": {
+                    "type": "number"
+                },
+                "expense_id": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "issuer_cnpj": {
+                    "type": "string"
+                },
+                "issuer_name": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.Item"
+                    }
+                },
+                "model": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "expense.CreateExpenseRequest": {
+        "rule.Candidate": {
             "type": "object",
             "required": [
-                "amount",
-                "date",
-                "description",
-                "type"
+                "description"
             ],
             "properties": {
                 "amount": {
                     "type": "number"
                 },
-                "category": {
-                    "type": "string"
-                },
                 "date": {
                     "type": "string"
                 },
@@ -942,150 +7008,119 @@ const docTemplate = `{
                 }
             }
         },
-        "expense.Expense": {
+        "rule.Rule": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
                 "category": {
                     "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "date": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
+                "enabled": {
+                    "type": "boolean"
                 },
                 "id": {
                     "type": "string"
                 },
-                "type": {
+                "match_type": {
                     "type": "string"
                 },
-                "updated_at": {
+                "max_amount": {
+                    "type": "number"
+                },
+                "min_amount": {
+                    "type": "number"
+                },
+                "name": {
                     "type": "string"
                 },
-                "user_id": {
+                "pattern": {
                     "type": "string"
-                }
-            }
-        },
-        "expense.ExpenseStats": {
-            "type": "object",
-            "properties": {
-                "balance": {
-                    "type": "number"
                 },
-                "count": {
+                "priority": {
                     "type": "integer"
                 },
-                "expense_count": {
-                    "type": "integer"
+                "type": {
+                    "type": "string"
                 },
-                "income_count": {
-                    "type": "integer"
+                "updated_at": {
+                    "type": "string"
                 },
-                "total_expense": {
-                    "type": "number"
+                "user_id": {
+                    "type": "string"
                 },
-                "total_income": {
-                    "type": "number"
-                }
-            }
-        },
-        "expense.ImportTransactionsRequest": {
-            "type": "object",
-            "required": [
-                "transactions"
-            ],
-            "properties": {
-                "transactions": {
+                "weekdays": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/expense.Transaction"
+                        "type": "integer"
                     }
                 }
             }
         },
-        "expense.Transaction": {
+        "rule.RuleRequest": {
             "type": "object",
+            "required": [
+                "category",
+                "match_type",
+                "pattern"
+            ],
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
                 "category": {
                     "type": "string"
                 },
-                "date": {
-                    "type": "string"
+                "enabled": {
+                    "type": "boolean"
                 },
-                "description": {
-                    "type": "string"
-                }
-            }
-        },
-        "expense.UpdateExpenseRequest": {
-            "type": "object",
-            "properties": {
-                "amount": {
-                    "type": "number"
+                "match_type": {
+                    "type": "string",
+                    "enum": [
+                        "contains",
+                        "prefix",
+                        "regex",
+                        "merchant"
+                    ]
                 },
-                "category": {
-                    "type": "string"
+                "max_amount": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "date": {
+                "min_amount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
                     "type": "string"
                 },
-                "description": {
+                "pattern": {
                     "type": "string"
                 },
+                "priority": {
+                    "type": "integer"
+                },
                 "type": {
                     "type": "string",
                     "enum": [
                         "income",
                         "expense"
                     ]
-                }
-            }
-        },
-        "parser.ImportAndSaveResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
-                },
-                "processed": {
-                    "type": "integer"
-                },
-                "saved": {
-                    "type": "integer"
                 },
-                "transactions": {
+                "weekdays": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/parser.Transaction"
+                        "type": "integer"
                     }
                 }
             }
         },
-        "parser.Transaction": {
+        "rule.TestRuleResponse": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
                 "category": {
                     "type": "string"
                 },
-                "date": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
+                "rule": {
+                    "$ref": "#/definitions/rule.Rule"
                 }
             }
         }
@@ -3,8 +3,15 @@ package main
 import (
 	"gastei-quanto/src/internal/analysis"
 	"gastei-quanto/src/internal/auth"
+	"gastei-quanto/src/internal/bill"
+	"gastei-quanto/src/internal/categorizer"
+	"gastei-quanto/src/internal/category"
 	"gastei-quanto/src/internal/expense"
+	"gastei-quanto/src/internal/inbox"
 	"gastei-quanto/src/internal/parser"
+	"gastei-quanto/src/internal/pix"
+	"gastei-quanto/src/internal/receipt"
+	"gastei-quanto/src/internal/rule"
 	"gastei-quanto/src/pkg/database"
 	"log"
 	"os"
@@ -85,18 +92,76 @@ func main() {
 		protected.Use(authMiddleware)
 		{
 			expenseRepo := expense.NewSQLRepository(db.GetDB())
-			expenseService := expense.NewService(expenseRepo)
+			classifier := categorizer.NewClassifier(expenseRepo)
+
+			categoryRepo := category.NewSQLRepository(db.GetDB())
+			categoryService := category.NewService(categoryRepo, classifier)
+			categoryHandler := category.NewHandler(categoryService)
+			category.RegisterRoutes(protected, categoryHandler)
+
+			expenseService := expense.NewService(expenseRepo, categoryService, classifier)
 			expenseHandler := expense.NewHandler(expenseService)
 			expense.RegisterRoutes(protected, expenseHandler)
 
-			analysisService := analysis.NewService()
+			categorizerService := categorizer.NewKeywordCategorizer()
+
+			analysisService := analysis.NewService(categorizerService, categoryService)
 			analysisHandler := analysis.NewHandler(analysisService)
 			analysis.RegisterRoutes(protected, analysisHandler)
 
+			ruleRepo := rule.NewSQLRepository(db.GetDB())
+			ruleService := rule.NewService(ruleRepo)
+			ruleHandler := rule.NewHandler(ruleService)
+			rule.RegisterRoutes(protected, ruleHandler)
+
+			categorizerPipeline := categorizer.NewPipeline(ruleService, classifier, categorizerService)
+			recategorizer := categorizer.NewRecategorizer(expenseRepo, categorizerPipeline, classifier)
+			categorizerHandler := categorizer.NewHandler(recategorizer)
+			categorizer.RegisterRoutes(protected, categorizerHandler)
+
 			parserService := parser.NewService()
-			parserIntegrationService := parser.NewIntegrationService(parserService, analysisService, expenseService)
-			parserHandler := parser.NewIntegrationHandler(parserService, parserIntegrationService)
+			parserStagingRepo := parser.NewStagingRepository()
+			parserJobRepo := parser.NewJobRepository()
+			parserIntegrationService := parser.NewIntegrationService(parserService, analysisService, expenseService, categorizerPipeline, parserStagingRepo, parserJobRepo)
+			parserMappingRepo := parser.NewSQLMappingRepository(db.GetDB())
+			parserMappingService := parser.NewMappingService(parserMappingRepo)
+			parserHandler := parser.NewIntegrationHandler(parserService, parserIntegrationService, parserMappingService)
 			parser.RegisterRoutes(protected, parserHandler)
+
+			receiptRepo := receipt.NewSQLRepository(db.GetDB())
+			receiptService := receipt.NewService(receiptRepo, expenseService)
+			receiptHandler := receipt.NewHandler(receiptService)
+			receipt.RegisterRoutes(protected, receiptHandler)
+
+			billRepo := bill.NewSQLRepository(db.GetDB())
+			billService := bill.NewService(billRepo, expenseService)
+			billHandler := bill.NewHandler(billService)
+			bill.RegisterRoutes(protected, billHandler)
+
+			pixService := pix.NewService(expenseService, categorizerPipeline)
+			pixHandler := pix.NewHandler(pixService)
+			pix.RegisterRoutes(protected, pixHandler)
+
+			smtpAddr := os.Getenv("SMTP_ADDR")
+			inboxDomain := os.Getenv("INBOX_DOMAIN")
+			if inboxDomain == "" {
+				inboxDomain = "localhost"
+			}
+
+			inboxRepo := inbox.NewSQLRepository(db.GetDB())
+			inboxService := inbox.NewService(inboxRepo, parserIntegrationService, inboxDomain, smtpAddr != "")
+			inboxHandler := inbox.NewHandler(inboxService)
+			inbox.RegisterRoutes(protected, inboxHandler)
+
+			if smtpAddr != "" {
+				smtpServer := inbox.NewSMTPServer(smtpAddr, inboxDomain, inboxService)
+				go func() {
+					log.Println("Servidor SMTP rodando em", smtpAddr, "para o domínio", inboxDomain)
+					if err := smtpServer.ListenAndServe(); err != nil {
+						log.Fatal("Erro ao iniciar servidor SMTP:", err)
+					}
+				}()
+			}
 		}
 	}
 
@@ -2,6 +2,7 @@ package analysis
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,15 +17,17 @@ func NewHandler(service Service) *Handler {
 
 // AnalyzeTransactions godoc
 // @Summary Analisa transações
-// @Description Agrupa e analisa transações por categoria e descrição
+// @Description Agrupa e analisa transações por categoria e descrição. Com rollup=true, as subcategorias do catálogo do usuário entram no total da categoria de primeiro nível
 // @Tags analysis
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body AnalysisRequest true "Lista de transações"
+// @Param rollup query bool false "Agrupar subcategorias na categoria de primeiro nível"
 // @Success 200 {object} AnalysisResponse
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
 // @Router /analysis/transactions [post]
 func (h *Handler) AnalyzeTransactions(c *gin.Context) {
 	var req AnalysisRequest
@@ -43,5 +46,22 @@ func (h *Handler) AnalyzeTransactions(c *gin.Context) {
 	}
 
 	result := h.service.AnalyzeTransactions(req.Transactions)
+
+	if rollup := c.Query("rollup"); rollup != "" {
+		value, err := strconv.ParseBool(rollup)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Valor inválido para rollup: " + rollup,
+			})
+			return
+		}
+		if value {
+			if result, err = h.service.RollUp(c.GetString("user_id"), result); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+	}
+
 	c.JSON(http.StatusOK, result)
 }
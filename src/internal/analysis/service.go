@@ -3,16 +3,26 @@ package analysis
 import (
 	"sort"
 	"strings"
+
+	"gastei-quanto/src/internal/categorizer"
+	"gastei-quanto/src/internal/expense"
 )
 
 type Service interface {
 	AnalyzeTransactions(transactions []Transaction) *AnalysisResponse
+	RollUp(userID string, result *AnalysisResponse) (*AnalysisResponse, error)
 }
 
-type service struct{}
+type service struct {
+	categorizer categorizer.Categorizer
+	roots       expense.CategoryRoots
+}
 
-func NewService() Service {
-	return &service{}
+func NewService(categorizer categorizer.Categorizer, roots expense.CategoryRoots) Service {
+	return &service{
+		categorizer: categorizer,
+		roots:       roots,
+	}
 }
 
 func (s *service) AnalyzeTransactions(transactions []Transaction) *AnalysisResponse {
@@ -30,7 +40,7 @@ func (s *service) AnalyzeTransactions(transactions []Transaction) *AnalysisRespo
 
 		category := t.Category
 		if category == "" {
-			category = inferCategory(t.Description)
+			category = s.categorizer.Categorize(t.Description)
 		}
 
 		if cat, exists := categoryMap[category]; exists {
@@ -86,33 +96,44 @@ func (s *service) AnalyzeTransactions(transactions []Transaction) *AnalysisRespo
 	}
 }
 
-func inferCategory(description string) string {
-	desc := strings.ToLower(description)
-
-	if strings.Contains(desc, "99") || strings.Contains(desc, "ride") {
-		return "Transporte"
+// RollUp adds the categories of the analysis into their top-level category
+// of the user's catalog.
+func (s *service) RollUp(userID string, result *AnalysisResponse) (*AnalysisResponse, error) {
+	if s.roots == nil {
+		return result, nil
 	}
-	if strings.Contains(desc, "ifood") || strings.Contains(desc, "pizza") ||
-		strings.Contains(desc, "panif") || strings.Contains(desc, "tempero") {
-		return "Alimentação"
-	}
-	if strings.Contains(desc, "amazon") || strings.Contains(desc, "mercado") {
-		return "Compras"
-	}
-	if strings.Contains(desc, "spotify") || strings.Contains(desc, "prime") {
-		return "Assinaturas"
-	}
-	if strings.Contains(desc, "iof") {
-		return "Taxas"
+
+	root, err := s.roots.Roots(userID)
+	if err != nil {
+		return nil, err
 	}
-	if strings.Contains(desc, "pagamento recebido") || strings.Contains(desc, "estorno") || strings.Contains(desc, "crédito") {
-		return "Receitas"
+
+	byRoot := make(map[string]int)
+	byCategory := []CategorySummary{}
+	for _, cat := range result.ByCategory {
+		name := root(cat.Category)
+		index, ok := byRoot[name]
+		if !ok {
+			index = len(byCategory)
+			byRoot[name] = index
+			byCategory = append(byCategory, CategorySummary{Category: name})
+		}
+		byCategory[index].Total += cat.Total
+		byCategory[index].Count += cat.Count
 	}
-	if strings.Contains(desc, "cursor") {
-		return "Software"
+
+	for i := range byCategory {
+		if byCategory[i].Count > 0 {
+			byCategory[i].Average = byCategory[i].Total / float64(byCategory[i].Count)
+		}
 	}
+	sort.Slice(byCategory, func(i, j int) bool {
+		return byCategory[i].Total > byCategory[j].Total
+	})
 
-	return "Outros"
+	rolledUp := *result
+	rolledUp.ByCategory = byCategory
+	return &rolledUp, nil
 }
 
 func cleanDescription(desc string) string {
@@ -122,9 +143,8 @@ func cleanDescription(desc string) string {
 	desc = strings.ReplaceAll(desc, "Dl *", "")
 	desc = strings.ReplaceAll(desc, "Dm *", "")
 
-	if strings.Contains(desc, " - Parcela") {
-		idx := strings.Index(desc, " - Parcela")
-		desc = desc[:idx]
+	if base, _, ok := expense.ParseInstallment(desc); ok {
+		desc = base
 	}
 
 	return strings.TrimSpace(desc)
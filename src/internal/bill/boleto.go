@@ -0,0 +1,285 @@
+package bill
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCode = errors.New("código de boleto inválido")
+
+var nonDigits = regexp.MustCompile(`\D`)
+
+// Due date factors count days from 1997-10-07. Factor 9999 fell on
+// 2025-02-21, and the next day the count restarted at 1000.
+var (
+	factorBase       = time.Date(1997, 10, 7, 0, 0, 0, 0, time.UTC)
+	factorRolloverAt = time.Date(2025, 2, 22, 0, 0, 0, 0, time.UTC)
+)
+
+// bankNames are the banks most often seen on boletos, by FEBRABAN code.
+var bankNames = map[string]string{
+	"001": "Banco do Brasil",
+	"004": "Banco do Nordeste",
+	"033": "Santander",
+	"041": "Banrisul",
+	"070": "BRB",
+	"077": "Inter",
+	"104": "Caixa Econômica Federal",
+	"208": "BTG Pactual",
+	"212": "Banco Original",
+	"237": "Bradesco",
+	"260": "Nubank",
+	"290": "PagBank",
+	"323": "Mercado Pago",
+	"336": "C6 Bank",
+	"341": "Itaú",
+	"380": "PicPay",
+	"422": "Safra",
+	"748": "Sicredi",
+	"756": "Sicoob",
+}
+
+// segmentNames are the segments of convênio (arrecadação) barcodes.
+var segmentNames = map[byte]string{
+	'1': "Prefeitura",
+	'2': "Saneamento",
+	'3': "Energia elétrica e gás",
+	'4': "Telecomunicações",
+	'5': "Órgão governamental",
+	'6': "Carnê",
+	'7': "Multa de trânsito",
+	'9': "Uso exclusivo do banco",
+}
+
+// Code is what a boleto or convênio barcode tells. Amount is zero when the
+// barcode leaves it open, and DueDate is nil when it has none: convênio
+// barcodes never carry a standard due date.
+type Code struct {
+	Kind          string     `json:"kind"`
+	Barcode       string     `json:"barcode"`
+	DigitableLine string     `json:"digitable_line"`
+	BankCode      string     `json:"bank_code,omitempty"`
+	BankName      string     `json:"bank_name,omitempty"`
+	Segment       string     `json:"segment,omitempty"`
+	CompanyCode   string     `json:"company_code,omitempty"`
+	Amount        float64    `json:"amount"`
+	DueDate       *time.Time `json:"due_date,omitempty"`
+}
+
+// ParseCode reads a boleto bancário or convênio as a 44 digit barcode or as
+// its linha digitável (47 digits for boletos, 48 for convênios), with or
+// without the dots and spaces, and validates every check digit. today
+// decides the cycle of the due date factor.
+func ParseCode(value string, today time.Time) (*Code, error) {
+	digits := nonDigits.ReplaceAllString(value, "")
+
+	switch {
+	case len(digits) == 44 && digits[0] == '8':
+		return parseConvenioBarcode(digits)
+	case len(digits) == 44:
+		return parseBoletoBarcode(digits, today)
+	case len(digits) == 48 && digits[0] == '8':
+		return parseConvenioLine(digits)
+	case len(digits) == 47:
+		return parseBoletoLine(digits, today)
+	}
+
+	return nil, fmt.Errorf("%w: esperado código de barras (44 dígitos) ou linha digitável (47 ou 48 dígitos), recebido %d dígitos", ErrInvalidCode, len(digits))
+}
+
+// parseBoletoLine checks the three fields of the linha digitável that have
+// their own digit and rebuilds the barcode from it.
+func parseBoletoLine(line string, today time.Time) (*Code, error) {
+	fields := []string{line[0:10], line[10:21], line[21:32]}
+	for i, field := range fields {
+		if mod10(field[:len(field)-1]) != field[len(field)-1] {
+			return nil, fmt.Errorf("%w: dígito verificador do campo %d não confere", ErrInvalidCode, i+1)
+		}
+	}
+
+	barcode := line[0:4] + line[32:33] + line[33:47] + line[4:9] + line[10:20] + line[21:31]
+	return parseBoletoBarcode(barcode, today)
+}
+
+// parseBoletoBarcode reads bank, currency, general check digit, due date
+// factor, amount and the 25 digit free field.
+func parseBoletoBarcode(barcode string, today time.Time) (*Code, error) {
+	if boletoDigit(barcode[:4]+barcode[5:]) != barcode[4] {
+		return nil, fmt.Errorf("%w: dígito verificador geral não confere", ErrInvalidCode)
+	}
+
+	cents, _ := strconv.ParseInt(barcode[9:19], 10, 64)
+	code := &Code{
+		Kind:          KindBoleto,
+		Barcode:       barcode,
+		DigitableLine: boletoLine(barcode),
+		BankCode:      barcode[0:3],
+		BankName:      bankNames[barcode[0:3]],
+		Amount:        float64(cents) / 100,
+	}
+
+	factor, _ := strconv.Atoi(barcode[5:9])
+	if factor > 0 {
+		dueDate := dueDateFromFactor(factor, today)
+		code.DueDate = &dueDate
+	}
+
+	return code, nil
+}
+
+// dueDateFromFactor picks, of the two dates a factor can stand for since the
+// 2025 rollover, the one closer to today.
+func dueDateFromFactor(factor int, today time.Time) time.Time {
+	first := factorBase.AddDate(0, 0, factor)
+	if factor < 1000 {
+		return first
+	}
+
+	second := factorRolloverAt.AddDate(0, 0, factor-1000)
+	if absDuration(second.Sub(today)) < absDuration(first.Sub(today)) {
+		return second
+	}
+	return first
+}
+
+func boletoLine(barcode string) string {
+	field1 := barcode[0:4] + barcode[19:24]
+	field2 := barcode[24:34]
+	field3 := barcode[34:44]
+	return fmt.Sprintf("%s.%s%c %s.%s%c %s.%s%c %c %s",
+		field1[:5], field1[5:], mod10(field1),
+		field2[:5], field2[5:], mod10(field2),
+		field3[:5], field3[5:], mod10(field3),
+		barcode[4], barcode[5:19])
+}
+
+// parseConvenioLine checks the digit of each of the four blocks and joins
+// them back into the barcode.
+func parseConvenioLine(line string) (*Code, error) {
+	check, err := convenioCheck(line[2])
+	if err != nil {
+		return nil, err
+	}
+
+	var barcode strings.Builder
+	for i := 0; i < 4; i++ {
+		block := line[i*12 : i*12+11]
+		if check(block) != line[i*12+11] {
+			return nil, fmt.Errorf("%w: dígito verificador do bloco %d não confere", ErrInvalidCode, i+1)
+		}
+		barcode.WriteString(block)
+	}
+
+	return parseConvenioBarcode(barcode.String())
+}
+
+// parseConvenioBarcode reads product (8), segment, value type, general check
+// digit, amount and the company or agency code.
+func parseConvenioBarcode(barcode string) (*Code, error) {
+	check, err := convenioCheck(barcode[2])
+	if err != nil {
+		return nil, err
+	}
+
+	if check(barcode[:3]+barcode[4:]) != barcode[3] {
+		return nil, fmt.Errorf("%w: dígito verificador geral não confere", ErrInvalidCode)
+	}
+
+	code := &Code{
+		Kind:          KindConvenio,
+		Barcode:       barcode,
+		DigitableLine: convenioLine(barcode, check),
+		Segment:       segmentNames[barcode[1]],
+		CompanyCode:   barcode[15:19],
+	}
+	if barcode[1] == '6' {
+		// Carnês identify the company by the first 8 digits of its CNPJ.
+		code.CompanyCode = barcode[15:23]
+	}
+
+	// Value types 7 and 9 carry a reference quantity, not an amount in reais.
+	if barcode[2] == '6' || barcode[2] == '8' {
+		cents, _ := strconv.ParseInt(barcode[4:15], 10, 64)
+		code.Amount = float64(cents) / 100
+	}
+
+	return code, nil
+}
+
+func convenioLine(barcode string, check func(string) byte) string {
+	blocks := make([]string, 4)
+	for i := range blocks {
+		block := barcode[i*11 : i*11+11]
+		blocks[i] = fmt.Sprintf("%s-%c", block, check(block))
+	}
+	return strings.Join(blocks, " ")
+}
+
+// convenioCheck is the check digit of the value type: 6 and 7 use modulo 10,
+// 8 and 9 modulo 11.
+func convenioCheck(valueType byte) (func(string) byte, error) {
+	switch valueType {
+	case '6', '7':
+		return mod10, nil
+	case '8', '9':
+		return convenioMod11, nil
+	}
+	return nil, fmt.Errorf("%w: identificador de valor inválido: %c", ErrInvalidCode, valueType)
+}
+
+// mod10 weighs the digits 2 and 1 from the right, adding the digits of each
+// product.
+func mod10(digits string) byte {
+	sum, weight := 0, 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		product := int(digits[i]-'0') * weight
+		sum += product/10 + product%10
+		weight = 3 - weight
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// weightedMod11 is the sum of the digits weighed 2 to 9 from the right,
+// modulo 11.
+func weightedMod11(digits string) int {
+	sum, weight := 0, 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += int(digits[i]-'0') * weight
+		if weight++; weight > 9 {
+			weight = 2
+		}
+	}
+	return sum % 11
+}
+
+// boletoDigit is the general check digit of a boleto barcode, which is never
+// 0: results 0, 10 and 11 become 1.
+func boletoDigit(digits string) byte {
+	result := 11 - weightedMod11(digits)
+	if result == 0 || result >= 10 {
+		return '1'
+	}
+	return byte('0' + result)
+}
+
+func convenioMod11(digits string) byte {
+	remainder := weightedMod11(digits)
+	switch remainder {
+	case 0, 1:
+		return '0'
+	case 10:
+		return '1'
+	}
+	return byte('0' + 11 - remainder)
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
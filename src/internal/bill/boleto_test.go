@@ -0,0 +1,146 @@
+package bill
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var today = time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+
+func TestParseCode(t *testing.T) {
+	dueDate := time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		value   string
+		want    Code
+		dueDate *time.Time
+	}{
+		{
+			name:  "boleto barcode",
+			value: "34193162600000159901090000000000000000000001",
+			want: Code{
+				Kind:          KindBoleto,
+				Barcode:       "34193162600000159901090000000000000000000001",
+				DigitableLine: "34191.09008 00000.000000 00000.000018 3 16260000015990",
+				BankCode:      "341",
+				BankName:      "Itaú",
+				Amount:        159.90,
+			},
+			dueDate: &dueDate,
+		},
+		{
+			name:  "boleto line with punctuation",
+			value: "34191.09008 00000.000000 00000.000018 3 16260000015990",
+			want: Code{
+				Kind:          KindBoleto,
+				Barcode:       "34193162600000159901090000000000000000000001",
+				DigitableLine: "34191.09008 00000.000000 00000.000018 3 16260000015990",
+				BankCode:      "341",
+				BankName:      "Itaú",
+				Amount:        159.90,
+			},
+			dueDate: &dueDate,
+		},
+		{
+			name:  "convenio with modulo 10",
+			value: "836500000010234500480004000000000000000000000125",
+			want: Code{
+				Kind:          KindConvenio,
+				Barcode:       "83650000001234500480000000000000000000000012",
+				DigitableLine: "83650000001-0 23450048000-4 00000000000-0 00000000012-5",
+				Segment:       "Energia elétrica e gás",
+				CompanyCode:   "0048",
+				Amount:        123.45,
+			},
+		},
+		{
+			name:  "convenio with modulo 11",
+			value: "83840000001234500480000000000000000000000012",
+			want: Code{
+				Kind:          KindConvenio,
+				Barcode:       "83840000001234500480000000000000000000000012",
+				DigitableLine: "83840000001-4 23450048000-9 00000000000-0 00000000012-4",
+				Segment:       "Energia elétrica e gás",
+				CompanyCode:   "0048",
+				Amount:        123.45,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := ParseCode(tt.value, today)
+			if err != nil {
+				t.Fatalf("ParseCode: %v", err)
+			}
+
+			got := *code
+			got.DueDate = nil
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+
+			switch {
+			case tt.dueDate == nil && code.DueDate != nil:
+				t.Errorf("due date = %v, want none", code.DueDate)
+			case tt.dueDate != nil && (code.DueDate == nil || !code.DueDate.Equal(*tt.dueDate)):
+				t.Errorf("due date = %v, want %v", code.DueDate, tt.dueDate)
+			}
+		})
+	}
+}
+
+func TestParseCodeInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"wrong length", "3419316260000015990"},
+		{"boleto general digit", "34194162600000159901090000000000000000000001"},
+		{"boleto field digit", "34191090070000000000000000000018316260000015990"},
+		{"convenio general digit", "83660000001234500480000000000000000000000012"},
+		{"convenio block digit", "836500000011234500480004000000000000000000000125"},
+		{"convenio value type", "83150000001234500480000000000000000000000012"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCode(tt.value, today); !errors.Is(err, ErrInvalidCode) {
+				t.Errorf("error = %v, want ErrInvalidCode", err)
+			}
+		})
+	}
+}
+
+func TestCheckDigits(t *testing.T) {
+	if got := mod10("261533"); got != '4' {
+		t.Errorf("mod10(261533) = %c, want 4", got)
+	}
+	if got := boletoDigit("0000000000000000000000000000000000000000000"); got != '1' {
+		t.Errorf("boletoDigit of zeros = %c, want 1", got)
+	}
+	if got := convenioMod11("0000000000000000000000000000000000000000000"); got != '0' {
+		t.Errorf("convenioMod11 of zeros = %c, want 0", got)
+	}
+}
+
+func TestDueDateFromFactor(t *testing.T) {
+	tests := []struct {
+		factor int
+		today  time.Time
+		want   time.Time
+	}{
+		{9999, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 21, 0, 0, 0, 0, time.UTC)},
+		{1000, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 22, 0, 0, 0, 0, time.UTC)},
+		{1000, time.Date(2000, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2000, 7, 3, 0, 0, 0, 0, time.UTC)},
+		{1626, today, time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := dueDateFromFactor(tt.factor, tt.today); !got.Equal(tt.want) {
+			t.Errorf("dueDateFromFactor(%d, %s) = %s, want %s", tt.factor, tt.today.Format("2006-01-02"), got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package bill
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// Parse godoc
+// @Summary Lê um boleto ou conta de consumo
+// @Description Valida os dígitos verificadores do código de barras (44 dígitos) ou da linha digitável (47 dígitos para boleto bancário, 48 para convênio) e retorna banco, segmento, valor e vencimento, sem salvar nada
+// @Tags bills
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ParseCodeRequest true "Código de barras ou linha digitável"
+// @Success 200 {object} bill.Code
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /bills/parse [post]
+func (h *Handler) Parse(c *gin.Context) {
+	var req ParseCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	code, err := h.service.Parse(req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, code)
+}
+
+// Create godoc
+// @Summary Cadastra uma conta a pagar
+// @Description Cadastra um boleto ou conta de consumo pendente a partir do código colado. Valor e vencimento vêm do código; quando ele não traz o valor (comum em contas de consumo), amount é obrigatório. A despesa só é criada quando a conta é paga
+// @Tags bills
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateBillRequest true "Código e dados da conta"
+// @Success 201 {object} bill.Bill
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /bills [post]
+func (h *Handler) Create(c *gin.Context) {
+	var req CreateBillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bill, err := h.service.Create(c.GetString("user_id"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, bill)
+}
+
+// List godoc
+// @Summary Lista as contas a pagar
+// @Description Retorna os boletos e contas de consumo do usuário pelo vencimento, indicando as pendentes já vencidas
+// @Tags bills
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filtrar pela situação (pending ou paid)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /bills [get]
+func (h *Handler) List(c *gin.Context) {
+	var query ListBillsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bills, err := h.service.List(c.GetString("user_id"), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"bills": bills,
+		"count": len(bills),
+	})
+}
+
+// GetByID godoc
+// @Summary Busca uma conta a pagar por ID
+// @Description Retorna o boleto ou conta de consumo
+// @Tags bills
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da conta"
+// @Success 200 {object} bill.Bill
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /bills/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	bill, err := h.service.GetByID(c.Param("id"), c.GetString("user_id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bill)
+}
+
+// Pay godoc
+// @Summary Registra o pagamento de uma conta
+// @Description Marca a conta como paga e cria a despesa correspondente. O valor pago (com multa ou desconto) e a data do pagamento são opcionais; por padrão, o valor da conta e a data de hoje
+// @Tags bills
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da conta"
+// @Param request body PayBillRequest false "Dados do pagamento"
+// @Success 200 {object} bill.Bill
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /bills/{id}/pay [post]
+func (h *Handler) Pay(c *gin.Context) {
+	var req PayBillRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	bill, err := h.service.Pay(c.Param("id"), c.GetString("user_id"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bill)
+}
+
+// Delete godoc
+// @Summary Remove uma conta a pagar
+// @Description Remove o boleto ou conta de consumo; a despesa de uma conta já paga não é alterada
+// @Tags bills
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da conta"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /bills/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Param("id"), c.GetString("user_id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Conta removida",
+	})
+}
+
+func respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrBillNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrBillAlreadyRegistered), errors.Is(err, ErrBillAlreadyPaid):
+		status = http.StatusConflict
+	case errors.Is(err, ErrInvalidCode), errors.Is(err, ErrAmountRequired):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
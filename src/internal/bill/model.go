@@ -0,0 +1,61 @@
+package bill
+
+import "time"
+
+const (
+	KindBoleto   = "boleto"
+	KindConvenio = "convenio"
+
+	StatusPending = "pending"
+	StatusPaid    = "paid"
+)
+
+// Bill is a boleto or convênio registered before it is paid. Paying it
+// creates the expense, linked by ExpenseID. Overdue is worked out when the
+// bill is read.
+type Bill struct {
+	ID            string     `json:"id"`
+	UserID        string     `json:"user_id"`
+	Kind          string     `json:"kind"`
+	Barcode       string     `json:"barcode"`
+	DigitableLine string     `json:"digitable_line"`
+	BankCode      string     `json:"bank_code,omitempty"`
+	BankName      string     `json:"bank_name,omitempty"`
+	Segment       string     `json:"segment,omitempty"`
+	Description   string     `json:"description"`
+	Category      string     `json:"category"`
+	Amount        float64    `json:"amount"`
+	DueDate       *time.Time `json:"due_date,omitempty"`
+	Status        string     `json:"status"`
+	Overdue       bool       `json:"overdue"`
+	ExpenseID     string     `json:"expense_id,omitempty"`
+	PaidAt        *time.Time `json:"paid_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type ParseCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// CreateBillRequest registers a pasted code. Amount and DueDate are needed
+// only when the code does not carry them, as in most convênio bills, and
+// override it otherwise.
+type CreateBillRequest struct {
+	Code        string     `json:"code" binding:"required"`
+	Description string     `json:"description"`
+	Category    string     `json:"category"`
+	Amount      float64    `json:"amount" binding:"gte=0"`
+	DueDate     *time.Time `json:"due_date"`
+}
+
+// PayBillRequest records the payment. Amount defaults to the bill amount and
+// may differ when fines or discounts apply; PaidAt defaults to today.
+type PayBillRequest struct {
+	PaidAt *time.Time `json:"paid_at"`
+	Amount float64    `json:"amount" binding:"gte=0"`
+}
+
+type ListBillsQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending paid"`
+}
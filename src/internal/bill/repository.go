@@ -0,0 +1,119 @@
+package bill
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrBillNotFound          = errors.New("conta não encontrada")
+	ErrBillAlreadyRegistered = errors.New("boleto já cadastrado")
+	ErrBillAlreadyPaid       = errors.New("conta já paga")
+	ErrAmountRequired        = errors.New("o código não traz o valor da conta; informe amount")
+)
+
+type Repository interface {
+	Create(bill *Bill) error
+	Update(bill *Bill) error
+	FindByID(id, userID string) (*Bill, error)
+	FindByUserID(userID string, query ListBillsQuery) ([]*Bill, error)
+	Delete(id, userID string) error
+}
+
+type memoryRepository struct {
+	bills map[string]*Bill
+	mu    sync.RWMutex
+}
+
+func NewRepository() Repository {
+	return &memoryRepository{
+		bills: make(map[string]*Bill),
+	}
+}
+
+func (r *memoryRepository) Create(bill *Bill) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.bills {
+		if existing.UserID == bill.UserID && existing.Barcode == bill.Barcode {
+			return ErrBillAlreadyRegistered
+		}
+	}
+
+	stored := *bill
+	r.bills[bill.ID] = &stored
+	return nil
+}
+
+func (r *memoryRepository) Update(bill *Bill) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.bills[bill.ID]
+	if !ok || existing.UserID != bill.UserID {
+		return ErrBillNotFound
+	}
+
+	stored := *bill
+	r.bills[bill.ID] = &stored
+	return nil
+}
+
+func (r *memoryRepository) FindByID(id, userID string) (*Bill, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	bill, ok := r.bills[id]
+	if !ok || bill.UserID != userID {
+		return nil, ErrBillNotFound
+	}
+
+	copied := *bill
+	return &copied, nil
+}
+
+func (r *memoryRepository) FindByUserID(userID string, query ListBillsQuery) ([]*Bill, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	bills := []*Bill{}
+	for _, bill := range r.bills {
+		if bill.UserID != userID || (query.Status != "" && bill.Status != query.Status) {
+			continue
+		}
+		copied := *bill
+		bills = append(bills, &copied)
+	}
+
+	sort.Slice(bills, func(i, j int) bool {
+		return dueBefore(bills[i], bills[j])
+	})
+
+	return bills, nil
+}
+
+func (r *memoryRepository) Delete(id, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bill, ok := r.bills[id]
+	if !ok || bill.UserID != userID {
+		return ErrBillNotFound
+	}
+
+	delete(r.bills, id)
+	return nil
+}
+
+// dueBefore orders bills by due date, those without one last.
+func dueBefore(a, b *Bill) bool {
+	switch {
+	case a.DueDate == nil:
+		return false
+	case b.DueDate == nil:
+		return true
+	}
+	return a.DueDate.Before(*b.DueDate)
+}
@@ -0,0 +1,210 @@
+package bill
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+type sqlRepository struct {
+	db *sql.DB
+}
+
+func NewSQLRepository(db *sql.DB) Repository {
+	return &sqlRepository{
+		db: db,
+	}
+}
+
+const billColumns = `id, user_id, kind, barcode, digitable_line, bank_code, bank_name, segment, description, category, 
+	amount, due_date, status, expense_id, paid_at, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBill(row rowScanner) (*Bill, error) {
+	bill := &Bill{}
+	var bankCode, bankName, segment, expenseID sql.NullString
+	var dueDate, paidAt sql.NullTime
+
+	err := row.Scan(
+		&bill.ID,
+		&bill.UserID,
+		&bill.Kind,
+		&bill.Barcode,
+		&bill.DigitableLine,
+		&bankCode,
+		&bankName,
+		&segment,
+		&bill.Description,
+		&bill.Category,
+		&bill.Amount,
+		&dueDate,
+		&bill.Status,
+		&expenseID,
+		&paidAt,
+		&bill.CreatedAt,
+		&bill.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	bill.BankCode = bankCode.String
+	bill.BankName = bankName.String
+	bill.Segment = segment.String
+	bill.ExpenseID = expenseID.String
+	if dueDate.Valid {
+		bill.DueDate = &dueDate.Time
+	}
+	if paidAt.Valid {
+		bill.PaidAt = &paidAt.Time
+	}
+	return bill, nil
+}
+
+func (r *sqlRepository) Create(bill *Bill) error {
+	query := `INSERT INTO bills (` + billColumns + `) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.Exec(
+		query,
+		bill.ID,
+		bill.UserID,
+		bill.Kind,
+		bill.Barcode,
+		bill.DigitableLine,
+		nullString(bill.BankCode),
+		nullString(bill.BankName),
+		nullString(bill.Segment),
+		bill.Description,
+		bill.Category,
+		bill.Amount,
+		nullTime(bill.DueDate),
+		bill.Status,
+		nullString(bill.ExpenseID),
+		nullTime(bill.PaidAt),
+		bill.CreatedAt,
+		bill.UpdatedAt,
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return ErrBillAlreadyRegistered
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *sqlRepository) Update(bill *Bill) error {
+	query := `UPDATE bills SET description = ?, category = ?, amount = ?, due_date = ?, status = ?, expense_id = ?, 
+		paid_at = ?, updated_at = ? 
+		WHERE id = ? AND user_id = ?`
+
+	result, err := r.db.Exec(
+		query,
+		bill.Description,
+		bill.Category,
+		bill.Amount,
+		nullTime(bill.DueDate),
+		bill.Status,
+		nullString(bill.ExpenseID),
+		nullTime(bill.PaidAt),
+		bill.UpdatedAt,
+		bill.ID,
+		bill.UserID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrBillNotFound
+	}
+
+	return nil
+}
+
+func (r *sqlRepository) FindByID(id, userID string) (*Bill, error) {
+	query := `SELECT ` + billColumns + ` 
+		FROM bills WHERE id = ? AND user_id = ?`
+
+	bill, err := scanBill(r.db.QueryRow(query, id, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrBillNotFound
+		}
+		return nil, err
+	}
+
+	return bill, nil
+}
+
+func (r *sqlRepository) FindByUserID(userID string, query ListBillsQuery) ([]*Bill, error) {
+	queryStr := `SELECT ` + billColumns + ` 
+		FROM bills WHERE user_id = ?`
+	args := []interface{}{userID}
+
+	if query.Status != "" {
+		queryStr += " AND status = ?"
+		args = append(args, query.Status)
+	}
+
+	queryStr += " ORDER BY due_date IS NULL, due_date"
+
+	rows, err := r.db.Query(queryStr, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bills := []*Bill{}
+	for rows.Next() {
+		bill, err := scanBill(rows)
+		if err != nil {
+			return nil, err
+		}
+		bills = append(bills, bill)
+	}
+
+	return bills, rows.Err()
+}
+
+func (r *sqlRepository) Delete(id, userID string) error {
+	result, err := r.db.Exec(`DELETE FROM bills WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrBillNotFound
+	}
+
+	return nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func nullTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *value, Valid: true}
+}
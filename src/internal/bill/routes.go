@@ -0,0 +1,15 @@
+package bill
+
+import "github.com/gin-gonic/gin"
+
+func RegisterRoutes(rg *gin.RouterGroup, handler *Handler) {
+	bills := rg.Group("/bills")
+	{
+		bills.GET("", handler.List)
+		bills.POST("", handler.Create)
+		bills.POST("/parse", handler.Parse)
+		bills.GET("/:id", handler.GetByID)
+		bills.POST("/:id/pay", handler.Pay)
+		bills.DELETE("/:id", handler.Delete)
+	}
+}
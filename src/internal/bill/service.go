@@ -0,0 +1,196 @@
+package bill
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"gastei-quanto/src/internal/categorizer"
+	"gastei-quanto/src/internal/expense"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Parse(req ParseCodeRequest) (*Code, error)
+	Create(userID string, req CreateBillRequest) (*Bill, error)
+	GetByID(id, userID string) (*Bill, error)
+	List(userID string, query ListBillsQuery) ([]*Bill, error)
+	Pay(id, userID string, req PayBillRequest) (*Bill, error)
+	Delete(id, userID string) error
+}
+
+type service struct {
+	repo           Repository
+	expenseService expense.Service
+}
+
+func NewService(repo Repository, expenseService expense.Service) Service {
+	return &service{
+		repo:           repo,
+		expenseService: expenseService,
+	}
+}
+
+func (s *service) Parse(req ParseCodeRequest) (*Code, error) {
+	return ParseCode(req.Code, time.Now())
+}
+
+// Create registers a pending bill from a pasted code. The amount and due
+// date sent override the ones in the code, and are required when the code
+// leaves them out.
+func (s *service) Create(userID string, req CreateBillRequest) (*Bill, error) {
+	code, err := ParseCode(req.Code, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	amount := code.Amount
+	if req.Amount > 0 {
+		amount = round2(req.Amount)
+	}
+	if amount == 0 {
+		return nil, ErrAmountRequired
+	}
+
+	dueDate := code.DueDate
+	if req.DueDate != nil {
+		dueDate = req.DueDate
+	}
+
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		description = defaultDescription(code)
+	}
+
+	category := strings.TrimSpace(req.Category)
+	if category == "" {
+		category = categorizer.Other
+	}
+
+	now := time.Now()
+	bill := &Bill{
+		ID:            uuid.New().String(),
+		UserID:        userID,
+		Kind:          code.Kind,
+		Barcode:       code.Barcode,
+		DigitableLine: code.DigitableLine,
+		BankCode:      code.BankCode,
+		BankName:      code.BankName,
+		Segment:       code.Segment,
+		Description:   description,
+		Category:      category,
+		Amount:        amount,
+		DueDate:       dueDate,
+		Status:        StatusPending,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := s.repo.Create(bill); err != nil {
+		return nil, err
+	}
+
+	markOverdue(bill, now)
+	return bill, nil
+}
+
+func (s *service) GetByID(id, userID string) (*Bill, error) {
+	bill, err := s.repo.FindByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	markOverdue(bill, time.Now())
+	return bill, nil
+}
+
+// List returns the bills by due date, the ones without a due date last.
+func (s *service) List(userID string, query ListBillsQuery) ([]*Bill, error) {
+	bills, err := s.repo.FindByUserID(userID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, bill := range bills {
+		markOverdue(bill, now)
+	}
+
+	return bills, nil
+}
+
+// Pay marks the bill as paid and saves the expense for it.
+func (s *service) Pay(id, userID string, req PayBillRequest) (*Bill, error) {
+	bill, err := s.repo.FindByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if bill.Status == StatusPaid {
+		return nil, ErrBillAlreadyPaid
+	}
+
+	paidAt := time.Now()
+	if req.PaidAt != nil {
+		paidAt = *req.PaidAt
+	}
+
+	amount := bill.Amount
+	if req.Amount > 0 {
+		amount = round2(req.Amount)
+	}
+
+	created, err := s.expenseService.Create(userID, expense.CreateExpenseRequest{
+		Date:        paidAt,
+		Description: bill.Description,
+		Category:    bill.Category,
+		Amount:      amount,
+		Type:        "expense",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bill.Status = StatusPaid
+	bill.PaidAt = &paidAt
+	bill.ExpenseID = created.ID
+	bill.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(bill); err != nil {
+		return nil, err
+	}
+
+	return bill, nil
+}
+
+func (s *service) Delete(id, userID string) error {
+	return s.repo.Delete(id, userID)
+}
+
+func defaultDescription(code *Code) string {
+	switch {
+	case code.Kind == KindConvenio && code.Segment != "":
+		return "Conta de " + strings.ToLower(code.Segment)
+	case code.Kind == KindConvenio:
+		return "Conta de consumo"
+	case code.BankName != "":
+		return "Boleto " + code.BankName
+	}
+	return "Boleto banco " + code.BankCode
+}
+
+// markOverdue flags pending bills whose due date is before today.
+func markOverdue(bill *Bill, now time.Time) {
+	if bill.Status != StatusPending || bill.DueDate == nil {
+		return
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	due := time.Date(bill.DueDate.Year(), bill.DueDate.Month(), bill.DueDate.Day(), 0, 0, 0, 0, time.UTC)
+	bill.Overdue = due.Before(today)
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
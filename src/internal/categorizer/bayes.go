@@ -0,0 +1,145 @@
+package categorizer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"gastei-quanto/src/internal/expense"
+)
+
+// amountBuckets are the upper bounds, in reais, of the amount ranges the
+// model tells apart. A coffee and a flight from the same merchant name are
+// usually in different categories.
+var amountBuckets = []float64{10, 25, 50, 100, 250, 500, 1000, 5000}
+
+// bayesModel is a multinomial naive Bayes model over the words of the
+// description and the amount range. Counts are kept per category, so an
+// example can be forgotten as easily as it is learned.
+type bayesModel struct {
+	examples    map[string]int
+	tokens      map[string]map[string]int
+	tokenTotals map[string]int
+	vocabulary  map[string]int
+}
+
+func newBayesModel() *bayesModel {
+	return &bayesModel{
+		examples:    make(map[string]int),
+		tokens:      make(map[string]map[string]int),
+		tokenTotals: make(map[string]int),
+		vocabulary:  make(map[string]int),
+	}
+}
+
+func (m *bayesModel) learn(category string, features []string) {
+	if m.tokens[category] == nil {
+		m.tokens[category] = make(map[string]int)
+	}
+	m.examples[category]++
+	for _, token := range features {
+		m.tokens[category][token]++
+		m.tokenTotals[category]++
+		m.vocabulary[token]++
+	}
+}
+
+func (m *bayesModel) forget(category string, features []string) {
+	if m.examples[category] == 0 {
+		return
+	}
+	m.examples[category]--
+	for _, token := range features {
+		if m.tokens[category][token] == 0 {
+			continue
+		}
+		m.tokens[category][token]--
+		m.tokenTotals[category]--
+		if m.tokens[category][token] == 0 {
+			delete(m.tokens[category], token)
+		}
+		if m.vocabulary[token]--; m.vocabulary[token] == 0 {
+			delete(m.vocabulary, token)
+		}
+	}
+	if m.examples[category] == 0 {
+		delete(m.examples, category)
+		delete(m.tokens, category)
+		delete(m.tokenTotals, category)
+	}
+}
+
+// predict returns the most likely category and its posterior probability.
+// Tokens the model has never seen are ignored; with no known word in the
+// description there is nothing to go on and the category is empty.
+func (m *bayesModel) predict(features []string) (string, float64) {
+	if len(m.examples) < 2 {
+		return "", 0
+	}
+
+	var known []string
+	knownWord := false
+	for _, token := range features {
+		if m.vocabulary[token] > 0 {
+			known = append(known, token)
+			if !strings.HasPrefix(token, "$") {
+				knownWord = true
+			}
+		}
+	}
+	if !knownWord {
+		return "", 0
+	}
+
+	totalExamples := 0
+	for _, count := range m.examples {
+		totalExamples += count
+	}
+	vocabularySize := float64(len(m.vocabulary))
+
+	scores := make(map[string]float64, len(m.examples))
+	best, bestScore := "", math.Inf(-1)
+	for category, count := range m.examples {
+		score := math.Log(float64(count) / float64(totalExamples))
+		denominator := float64(m.tokenTotals[category]) + vocabularySize
+		for _, token := range known {
+			score += math.Log((float64(m.tokens[category][token]) + 1) / denominator)
+		}
+		scores[category] = score
+		if score > bestScore || (score == bestScore && category < best) {
+			best, bestScore = category, score
+		}
+	}
+
+	// Posterior of the best category: 1 / sum(exp(score - bestScore)).
+	sum := 0.0
+	for _, score := range scores {
+		sum += math.Exp(score - bestScore)
+	}
+
+	return best, 1 / sum
+}
+
+// features are the normalized words of the description, without numbers
+// and one-letter leftovers, plus a token for the amount range.
+func features(description string, amount float64) []string {
+	var result []string
+	for _, word := range strings.Fields(expense.NormalizeDescription(description)) {
+		if len([]rune(word)) < 2 || strings.IndexFunc(word, unicode.IsLetter) < 0 {
+			continue
+		}
+		result = append(result, word)
+	}
+	return append(result, amountBucket(amount))
+}
+
+func amountBucket(amount float64) string {
+	amount = math.Abs(amount)
+	for i, limit := range amountBuckets {
+		if amount < limit {
+			return fmt.Sprintf("$%d", i)
+		}
+	}
+	return fmt.Sprintf("$%d", len(amountBuckets))
+}
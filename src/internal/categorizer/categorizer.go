@@ -0,0 +1,65 @@
+package categorizer
+
+import "strings"
+
+// The canonical categories. Every built-in categorizer returns one of them,
+// so imports and analysis agree on the labels.
+const (
+	Food          = "Alimentação"
+	Transport     = "Transporte"
+	Shopping      = "Compras"
+	Subscriptions = "Assinaturas"
+	Software      = "Software"
+	Fees          = "Taxas"
+	Credits       = "Créditos"
+	Other         = "Outros"
+)
+
+// Categories lists the canonical categories, Other last.
+func Categories() []string {
+	return []string{Food, Transport, Shopping, Subscriptions, Software, Fees, Credits, Other}
+}
+
+// Categorizer suggests the category of a transaction from its description.
+type Categorizer interface {
+	Categorize(description string) string
+}
+
+type keywordGroup struct {
+	category string
+	keywords []string
+}
+
+// keywordGroups are checked in order, so more specific groups come first:
+// "IOF de compra internacional" is a fee, not a purchase.
+var keywordGroups = []keywordGroup{
+	{Credits, []string{"estorno", "crédito de", "credito de", "pagamento recebido"}},
+	{Fees, []string{"iof"}},
+	{Software, []string{"cursor"}},
+	{Transport, []string{"uber", "99", "taxi", "ride", "dl*", "pg *", "dl *", "transporte", "estacionamento"}},
+	{Food, []string{"ifood", "restaurante", "padaria", "panif", "pizza", "lanche", "acai", "açai", "food", "bar", "cafe", "café", "tempero"}},
+	{Shopping, []string{"amazon", "mercado", "compra", "loja", "mercadolivre"}},
+	{Subscriptions, []string{"spotify", "netflix", "prime", "assinatura", "dm *"}},
+}
+
+type keywordCategorizer struct{}
+
+// NewKeywordCategorizer returns the built-in categorizer, which matches
+// keywords in the description and falls back to Other.
+func NewKeywordCategorizer() Categorizer {
+	return &keywordCategorizer{}
+}
+
+func (c *keywordCategorizer) Categorize(description string) string {
+	descLower := strings.ToLower(description)
+
+	for _, group := range keywordGroups {
+		for _, keyword := range group.keywords {
+			if strings.Contains(descLower, keyword) {
+				return group.category
+			}
+		}
+	}
+
+	return Other
+}
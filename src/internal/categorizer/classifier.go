@@ -0,0 +1,132 @@
+package categorizer
+
+import (
+	"math"
+	"sync"
+
+	"gastei-quanto/src/internal/category"
+	"gastei-quanto/src/internal/expense"
+)
+
+// MinConfidence is the confidence below which a suggestion from the
+// classifier is not used and the keywords decide instead.
+const MinConfidence = 0.6
+
+// Suggestion is a category the classifier learned from the user, with the
+// posterior probability of that category.
+type Suggestion struct {
+	Category   string  `json:"category"`
+	Confidence float64 `json:"confidence"`
+}
+
+// Classifier suggests categories from the ones the user chose before, by
+// hand or in the app the expenses were migrated from; the categories the
+// pipeline guessed are left out, so it does not learn its own mistakes.
+type Classifier interface {
+	Suggest(userID, description string, amount float64) (*Suggestion, error)
+	expense.ChangeListener
+	category.ChangeListener
+}
+
+type classifier struct {
+	repo expense.Repository
+
+	models map[string]*bayesModel
+	mu     sync.Mutex
+}
+
+// NewClassifier returns a classifier that keeps one model per user in
+// memory, trained on the user's expenses the first time it is needed.
+func NewClassifier(repo expense.Repository) Classifier {
+	return &classifier{
+		repo:   repo,
+		models: make(map[string]*bayesModel),
+	}
+}
+
+// Suggest returns nil when the model has nothing to say about the
+// description.
+func (c *classifier) Suggest(userID, description string, amount float64) (*Suggestion, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	model, err := c.model(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	category, confidence := model.predict(features(description, amount))
+	if category == "" {
+		return nil, nil
+	}
+
+	return &Suggestion{
+		Category:   category,
+		Confidence: math.Round(confidence*1000) / 1000,
+	}, nil
+}
+
+// ExpenseChanged keeps a trained model up to date. Models not trained yet
+// read the change from the database when they are.
+func (c *classifier) ExpenseChanged(before, after *expense.Expense) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	userID := ""
+	if before != nil {
+		userID = before.UserID
+	} else if after != nil {
+		userID = after.UserID
+	}
+
+	model, ok := c.models[userID]
+	if !ok {
+		return
+	}
+
+	if before != nil && trainable(before) {
+		model.forget(before.Category, features(before.Description, before.Amount))
+	}
+	if after != nil && trainable(after) {
+		model.learn(after.Category, features(after.Description, after.Amount))
+	}
+}
+
+// CategoriesRenamed drops the user's model; it is trained again, with the
+// new names, the next time it is needed.
+func (c *classifier) CategoriesRenamed(userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.models, userID)
+}
+
+func (c *classifier) model(userID string) (*bayesModel, error) {
+	if model, ok := c.models[userID]; ok {
+		return model, nil
+	}
+
+	expenses, err := c.repo.FindByUserID(userID, expense.ListExpensesQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	model := newBayesModel()
+	for _, e := range expenses {
+		if trainable(e) {
+			model.learn(e.Category, features(e.Description, e.Amount))
+		}
+	}
+	c.models[userID] = model
+
+	return model, nil
+}
+
+// trainable reports whether the category of the expense was chosen by the
+// user. Uncategorized expenses teach nothing.
+func trainable(e *expense.Expense) bool {
+	if e.Category == "" || e.Category == Other {
+		return false
+	}
+	return expense.CategoryChosenByUser(e.CategorySource)
+}
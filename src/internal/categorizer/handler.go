@@ -0,0 +1,96 @@
+package categorizer
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	recategorizer Recategorizer
+}
+
+func NewHandler(recategorizer Recategorizer) *Handler {
+	return &Handler{
+		recategorizer: recategorizer,
+	}
+}
+
+// PreviewRecategorization godoc
+// @Summary Prévia da recategorização
+// @Description Passa as despesas filtradas pela categorização atual (regras, classificador e palavras-chave) e lista o que mudaria: despesa, categoria antiga, categoria nova e motivo. Nada é salvo. Despesas com categoria escolhida pelo usuário (à mão ou vinda do app de origem) ficam de fora, a não ser com force=true
+// @Tags expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Data inicial"
+// @Param end_date query string false "Data final"
+// @Param category query string false "Categoria"
+// @Param type query string false "Tipo (income ou expense)"
+// @Param description query string false "Trecho da descrição"
+// @Param batch_id query string false "ID da importação"
+// @Param force query bool false "Recategorizar também as categorias escolhidas pelo usuário"
+// @Success 200 {object} RecategorizePreview
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /expenses/recategorize [get]
+func (h *Handler) PreviewRecategorization(c *gin.Context) {
+	var query RecategorizeQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	preview, err := h.recategorizer.Preview(c.GetString("user_id"), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// ApplyRecategorization godoc
+// @Summary Aplica a recategorização
+// @Description Recalcula a prévia com os mesmos filtros e salva as mudanças em uma única transação. Com expense_ids, só as despesas aceitas na prévia mudam
+// @Tags expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Data inicial"
+// @Param end_date query string false "Data final"
+// @Param category query string false "Categoria"
+// @Param type query string false "Tipo (income ou expense)"
+// @Param description query string false "Trecho da descrição"
+// @Param batch_id query string false "ID da importação"
+// @Param force query bool false "Recategorizar também as categorias escolhidas pelo usuário"
+// @Param request body ApplyRecategorizationRequest false "Despesas aceitas"
+// @Success 200 {object} RecategorizeResult
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /expenses/recategorize [post]
+func (h *Handler) ApplyRecategorization(c *gin.Context) {
+	var query RecategorizeQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req ApplyRecategorizationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	result, err := h.recategorizer.Apply(c.GetString("user_id"), query, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
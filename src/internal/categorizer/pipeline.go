@@ -0,0 +1,104 @@
+package categorizer
+
+import (
+	"fmt"
+
+	"gastei-quanto/src/internal/expense"
+	"gastei-quanto/src/internal/rule"
+)
+
+// Result is the category the pipeline chose, which step chose it (one of
+// the expense.CategorySource constants) and why, in words for the user.
+type Result struct {
+	Category   string
+	Source     string
+	Confidence float64
+	Reason     string
+}
+
+// Pipeline categorizes what comes without a category: the user's rules
+// first, then the classifier when it is confident enough, then the
+// keywords. Imports, PIX codes and re-categorization all go through it.
+type Pipeline interface {
+	ForUser(userID string) (UserPipeline, error)
+}
+
+// UserPipeline categorizes for one user, with the user's rules loaded once.
+type UserPipeline interface {
+	Categorize(candidate rule.Candidate) (*Result, error)
+}
+
+type pipeline struct {
+	ruleService rule.Service
+	classifier  Classifier
+	keywords    Categorizer
+}
+
+// NewPipeline takes the optional rule service and classifier; without
+// them, those steps are skipped.
+func NewPipeline(ruleService rule.Service, classifier Classifier, keywords Categorizer) Pipeline {
+	return &pipeline{
+		ruleService: ruleService,
+		classifier:  classifier,
+		keywords:    keywords,
+	}
+}
+
+func (p *pipeline) ForUser(userID string) (UserPipeline, error) {
+	var rules *rule.Matcher
+	if p.ruleService != nil {
+		var err error
+		if rules, err = p.ruleService.Matcher(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &userPipeline{
+		pipeline: p,
+		userID:   userID,
+		rules:    rules,
+	}, nil
+}
+
+type userPipeline struct {
+	*pipeline
+	userID string
+	rules  *rule.Matcher
+}
+
+func (p *userPipeline) Categorize(candidate rule.Candidate) (*Result, error) {
+	if matched := p.rules.Match(candidate); matched != nil {
+		return &Result{
+			Category: matched.Category,
+			Source:   expense.CategorySourceRule,
+			Reason:   fmt.Sprintf("regra %q", matched.Name),
+		}, nil
+	}
+
+	if p.classifier != nil {
+		suggestion, err := p.classifier.Suggest(p.userID, candidate.Description, candidate.Amount)
+		if err != nil {
+			return nil, err
+		}
+		if suggestion != nil && suggestion.Confidence >= MinConfidence {
+			return &Result{
+				Category:   suggestion.Category,
+				Source:     expense.CategorySourceClassifier,
+				Confidence: suggestion.Confidence,
+				Reason:     fmt.Sprintf("aprendida com as suas despesas (confiança de %.0f%%)", suggestion.Confidence*100),
+			}, nil
+		}
+	}
+
+	category := p.keywords.Categorize(candidate.Description)
+	reason := "palavras-chave da descrição"
+	if category == Other {
+		reason = "nenhuma regra ou palavra-chave reconhecida"
+	}
+
+	return &Result{
+		Category: category,
+		Source:   expense.CategorySourceKeywords,
+		Reason:   reason,
+	}, nil
+}
@@ -0,0 +1,176 @@
+package categorizer
+
+import (
+	"time"
+
+	"gastei-quanto/src/internal/expense"
+	"gastei-quanto/src/internal/rule"
+)
+
+// RecategorizeQuery selects the expenses like the expense listing does.
+// Force also re-categorizes the expenses whose category the user chose.
+type RecategorizeQuery struct {
+	expense.ListExpensesQuery
+	Force bool `form:"force"`
+}
+
+// ApplyRecategorizationRequest optionally limits the changes applied to the
+// expenses accepted from the preview.
+type ApplyRecategorizationRequest struct {
+	ExpenseIDs []string `json:"expense_ids"`
+}
+
+type CategoryChange struct {
+	ExpenseID   string    `json:"expense_id"`
+	Date        time.Time `json:"date"`
+	Description string    `json:"description"`
+	Amount      float64   `json:"amount"`
+	OldCategory string    `json:"old_category"`
+	NewCategory string    `json:"new_category"`
+	Source      string    `json:"source"`
+	Confidence  float64   `json:"confidence,omitempty"`
+	Reason      string    `json:"reason"`
+}
+
+// RecategorizePreview lists the changes the pipeline would make. Unchanged
+// expenses already have the category it picks; skipped ones have a
+// category chosen by the user and the request was not forced.
+type RecategorizePreview struct {
+	Changes   []CategoryChange `json:"changes"`
+	Count     int              `json:"count"`
+	Unchanged int              `json:"unchanged"`
+	Skipped   int              `json:"skipped"`
+}
+
+type RecategorizeResult struct {
+	Changes []CategoryChange `json:"changes"`
+	Updated int              `json:"updated"`
+}
+
+// Recategorizer runs the current categorization pipeline over stored
+// expenses, so better rules and corrections reach the old ones too.
+type Recategorizer interface {
+	Preview(userID string, query RecategorizeQuery) (*RecategorizePreview, error)
+	Apply(userID string, query RecategorizeQuery, req ApplyRecategorizationRequest) (*RecategorizeResult, error)
+}
+
+type recategorizer struct {
+	repo      expense.Repository
+	pipeline  Pipeline
+	listeners []expense.ChangeListener
+}
+
+func NewRecategorizer(repo expense.Repository, pipeline Pipeline, listeners ...expense.ChangeListener) Recategorizer {
+	return &recategorizer{
+		repo:      repo,
+		pipeline:  pipeline,
+		listeners: listeners,
+	}
+}
+
+func (r *recategorizer) Preview(userID string, query RecategorizeQuery) (*RecategorizePreview, error) {
+	preview, _, err := r.preview(userID, query)
+	return preview, err
+}
+
+// Apply computes the changes again, so it never writes a stale preview,
+// and saves them in a single transaction.
+func (r *recategorizer) Apply(userID string, query RecategorizeQuery, req ApplyRecategorizationRequest) (*RecategorizeResult, error) {
+	preview, expenses, err := r.preview(userID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	accepted := make(map[string]bool, len(req.ExpenseIDs))
+	for _, id := range req.ExpenseIDs {
+		accepted[id] = true
+	}
+
+	changes := []CategoryChange{}
+	updates := []expense.CategoryUpdate{}
+	for _, change := range preview.Changes {
+		if len(accepted) > 0 && !accepted[change.ExpenseID] {
+			continue
+		}
+		changes = append(changes, change)
+		updates = append(updates, expense.CategoryUpdate{
+			ID:       change.ExpenseID,
+			Category: change.NewCategory,
+			Source:   change.Source,
+		})
+	}
+
+	updated := 0
+	if len(updates) > 0 {
+		if updated, err = r.repo.UpdateCategories(userID, updates); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, change := range changes {
+		before := expenses[change.ExpenseID]
+		after := *before
+		after.Category = change.NewCategory
+		after.CategorySource = change.Source
+		for _, listener := range r.listeners {
+			listener.ExpenseChanged(before, &after)
+		}
+	}
+
+	return &RecategorizeResult{
+		Changes: changes,
+		Updated: updated,
+	}, nil
+}
+
+func (r *recategorizer) preview(userID string, query RecategorizeQuery) (*RecategorizePreview, map[string]*expense.Expense, error) {
+	expenses, err := r.repo.FindByUserID(userID, query.ListExpensesQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pipeline, err := r.pipeline.ForUser(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	preview := &RecategorizePreview{Changes: []CategoryChange{}}
+	byID := make(map[string]*expense.Expense)
+	for _, e := range expenses {
+		if !query.Force && expense.CategoryChosenByUser(e.CategorySource) {
+			preview.Skipped++
+			continue
+		}
+
+		result, err := pipeline.Categorize(rule.Candidate{
+			Description: e.Description,
+			Amount:      e.Amount,
+			Date:        e.Date,
+			Type:        e.Type,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if result.Category == e.Category {
+			preview.Unchanged++
+			continue
+		}
+
+		byID[e.ID] = e
+		preview.Changes = append(preview.Changes, CategoryChange{
+			ExpenseID:   e.ID,
+			Date:        e.Date,
+			Description: e.Description,
+			Amount:      e.Amount,
+			OldCategory: e.Category,
+			NewCategory: result.Category,
+			Source:      result.Source,
+			Confidence:  result.Confidence,
+			Reason:      result.Reason,
+		})
+	}
+	preview.Count = len(preview.Changes)
+
+	return preview, byID, nil
+}
@@ -0,0 +1,11 @@
+package categorizer
+
+import "github.com/gin-gonic/gin"
+
+func RegisterRoutes(rg *gin.RouterGroup, handler *Handler) {
+	expenses := rg.Group("/expenses")
+	{
+		expenses.GET("/recategorize", handler.PreviewRecategorization)
+		expenses.POST("/recategorize", handler.ApplyRecategorization)
+	}
+}
@@ -0,0 +1,204 @@
+package category
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// Create godoc
+// @Summary Cria uma categoria
+// @Description Adiciona uma categoria ao catálogo do usuário. Nomes que só diferem em maiúsculas, acentos ou pontuação são a mesma categoria. Com parent_id, vira subcategoria (Alimentação > Delivery)
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateCategoryRequest true "Dados da categoria"
+// @Success 201 {object} category.Category
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /categories [post]
+func (h *Handler) Create(c *gin.Context) {
+	var req CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.service.Create(c.GetString("user_id"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+// List godoc
+// @Summary Lista as categorias
+// @Description Lista o catálogo de categorias do usuário em ordem alfabética. As arquivadas só aparecem com include_archived=true
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param include_archived query bool false "Incluir categorias arquivadas"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /categories [get]
+func (h *Handler) List(c *gin.Context) {
+	var query ListCategoriesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	categories, err := h.service.List(c.GetString("user_id"), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"categories": categories,
+		"count":      len(categories),
+	})
+}
+
+// GetByID godoc
+// @Summary Busca uma categoria
+// @Description Retorna uma categoria do catálogo do usuário
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da categoria"
+// @Success 200 {object} category.Category
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /categories/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	category, err := h.service.GetByID(c.Param("id"), c.GetString("user_id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+// Update godoc
+// @Summary Atualiza uma categoria
+// @Description Altera só os campos enviados. Ao renomear, as despesas, parcelamentos, contas e regras da categoria passam a usar o novo nome. parent_id vazio torna a categoria de primeiro nível
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da categoria"
+// @Param request body UpdateCategoryRequest true "Campos a alterar"
+// @Success 200 {object} category.Category
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /categories/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	var req UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.service.Update(c.Param("id"), c.GetString("user_id"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+// Delete godoc
+// @Summary Remove uma categoria
+// @Description Remove a categoria do catálogo. As despesas mantêm o nome e as subcategorias sobem para a categoria pai dela
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da categoria"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /categories/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Param("id"), c.GetString("user_id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Categoria removida",
+	})
+}
+
+// Merge godoc
+// @Summary Junta categorias
+// @Description Junta outras categorias do catálogo e nomes livres usados nas despesas à categoria. As categorias juntadas são removidas, suas subcategorias passam para esta, e todas as despesas com esses nomes, ou com outra grafia do nome desta, são renomeadas em uma única transação
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da categoria que fica"
+// @Param request body MergeCategoriesRequest true "Categorias e nomes a juntar"
+// @Success 200 {object} MergeCategoriesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /categories/{id}/merge [post]
+func (h *Handler) Merge(c *gin.Context) {
+	var req MergeCategoriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.Merge(c.Param("id"), c.GetString("user_id"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrCategoryNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrCategoryExists):
+		status = http.StatusConflict
+	case errors.Is(err, ErrInvalidName), errors.Is(err, ErrInvalidParent), errors.Is(err, ErrInvalidMerge), errors.Is(err, ErrNothingToMerge):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,55 @@
+package category
+
+import "time"
+
+// Category is an entry of the user's catalog. Expenses keep the category
+// name, so a category matches every expense whose category is the same
+// name up to case and accents. ParentID makes it a subcategory
+// (Alimentação > Delivery); archived categories stay in the catalog, and
+// in the roll-ups, but are left out of the default listing.
+type Category struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	ParentID  string    `json:"parent_id,omitempty"`
+	Color     string    `json:"color,omitempty"`
+	Icon      string    `json:"icon,omitempty"`
+	Archived  bool      `json:"archived"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CreateCategoryRequest struct {
+	Name     string `json:"name" binding:"required,max=60"`
+	ParentID string `json:"parent_id"`
+	Color    string `json:"color" binding:"omitempty,hexcolor"`
+	Icon     string `json:"icon" binding:"max=40"`
+	Archived bool   `json:"archived"`
+}
+
+// UpdateCategoryRequest changes only the fields sent. An empty parent_id
+// turns the category into a top-level one.
+type UpdateCategoryRequest struct {
+	Name     *string `json:"name" binding:"omitempty,min=1,max=60"`
+	ParentID *string `json:"parent_id"`
+	Color    *string `json:"color" binding:"omitempty,hexcolor"`
+	Icon     *string `json:"icon" binding:"omitempty,max=40"`
+	Archived *bool   `json:"archived"`
+}
+
+type ListCategoriesQuery struct {
+	IncludeArchived bool `form:"include_archived"`
+}
+
+// MergeCategoriesRequest lists what is merged into the category: other
+// catalog categories, which are removed, and free-text names found in the
+// expenses.
+type MergeCategoriesRequest struct {
+	CategoryIDs []string `json:"category_ids"`
+	Names       []string `json:"names"`
+}
+
+type MergeCategoriesResponse struct {
+	Category        *Category `json:"category"`
+	UpdatedExpenses int       `json:"updated_expenses"`
+}
@@ -0,0 +1,151 @@
+package category
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrCategoryNotFound = errors.New("categoria não encontrada")
+	ErrCategoryExists   = errors.New("já existe uma categoria com esse nome")
+	ErrInvalidName      = errors.New("nome de categoria inválido")
+	ErrInvalidParent    = errors.New("categoria pai inválida")
+	ErrInvalidMerge     = errors.New("uma categoria não pode ser juntada a uma subcategoria dela")
+	ErrNothingToMerge   = errors.New("informe as categorias ou os nomes a juntar")
+)
+
+// Repository keeps the catalog. Update and Merge also rename, in the same
+// transaction, the expenses, installment plans, bills and rules whose
+// category key is one of renamed; they return how many expenses changed.
+type Repository interface {
+	Create(category *Category) error
+	Update(category *Category, renamed []string) (int, error)
+	FindByID(id, userID string) (*Category, error)
+	FindByUserID(userID string) ([]*Category, error)
+	Delete(id, userID string) error
+	Merge(target *Category, sourceIDs []string, renamed []string) (int, error)
+}
+
+// memoryRepository keeps only the catalog; there are no expenses in it to
+// rename.
+type memoryRepository struct {
+	categories map[string]*Category
+	mu         sync.RWMutex
+}
+
+func NewRepository() Repository {
+	return &memoryRepository{
+		categories: make(map[string]*Category),
+	}
+}
+
+func (r *memoryRepository) Create(category *Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nameTaken(category) {
+		return ErrCategoryExists
+	}
+
+	stored := *category
+	r.categories[category.ID] = &stored
+	return nil
+}
+
+func (r *memoryRepository) Update(category *Category, renamed []string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.categories[category.ID]
+	if !ok || existing.UserID != category.UserID {
+		return 0, ErrCategoryNotFound
+	}
+	if r.nameTaken(category) {
+		return 0, ErrCategoryExists
+	}
+
+	stored := *category
+	r.categories[category.ID] = &stored
+	return 0, nil
+}
+
+func (r *memoryRepository) FindByID(id, userID string) (*Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	category, ok := r.categories[id]
+	if !ok || category.UserID != userID {
+		return nil, ErrCategoryNotFound
+	}
+
+	found := *category
+	return &found, nil
+}
+
+func (r *memoryRepository) FindByUserID(userID string) ([]*Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []*Category{}
+	for _, category := range r.categories {
+		if category.UserID == userID {
+			found := *category
+			result = append(result, &found)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+func (r *memoryRepository) Delete(id, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	category, ok := r.categories[id]
+	if !ok || category.UserID != userID {
+		return ErrCategoryNotFound
+	}
+
+	r.reparent(id, category.ParentID)
+	delete(r.categories, id)
+	return nil
+}
+
+func (r *memoryRepository) Merge(target *Category, sourceIDs []string, renamed []string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range sourceIDs {
+		if source, ok := r.categories[id]; !ok || source.UserID != target.UserID {
+			return 0, ErrCategoryNotFound
+		}
+	}
+
+	for _, id := range sourceIDs {
+		r.reparent(id, target.ID)
+		delete(r.categories, id)
+	}
+	return 0, nil
+}
+
+func (r *memoryRepository) reparent(id, parentID string) {
+	for _, child := range r.categories {
+		if child.ParentID == id {
+			child.ParentID = parentID
+		}
+	}
+}
+
+func (r *memoryRepository) nameTaken(category *Category) bool {
+	key := Key(category.Name)
+	for _, other := range r.categories {
+		if other.UserID == category.UserID && other.ID != category.ID && Key(other.Name) == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,285 @@
+package category
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// renamedTables have a free-text category per user, rewritten when a
+// category is renamed or merged.
+var renamedTables = []string{"expenses", "installment_plans", "bills", "category_rules"}
+
+type sqlRepository struct {
+	db *sql.DB
+}
+
+func NewSQLRepository(db *sql.DB) Repository {
+	return &sqlRepository{
+		db: db,
+	}
+}
+
+const categoryColumns = `id, user_id, name, parent_id, color, icon, archived, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCategory(row rowScanner) (*Category, error) {
+	category := &Category{}
+	var parentID, color, icon sql.NullString
+
+	err := row.Scan(
+		&category.ID,
+		&category.UserID,
+		&category.Name,
+		&parentID,
+		&color,
+		&icon,
+		&category.Archived,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	category.ParentID = parentID.String
+	category.Color = color.String
+	category.Icon = icon.String
+
+	return category, nil
+}
+
+func (r *sqlRepository) Create(category *Category) error {
+	query := `INSERT INTO categories (id, user_id, name, name_key, parent_id, color, icon, archived, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.Exec(
+		query,
+		category.ID,
+		category.UserID,
+		category.Name,
+		Key(category.Name),
+		nullString(category.ParentID),
+		nullString(category.Color),
+		nullString(category.Icon),
+		category.Archived,
+		category.CreatedAt,
+		category.UpdatedAt,
+	)
+
+	return uniqueName(err)
+}
+
+func (r *sqlRepository) Update(category *Category, renamed []string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE categories SET name = ?, name_key = ?, parent_id = ?, color = ?, icon = ?, archived = ?, updated_at = ?
+		WHERE id = ? AND user_id = ?`
+
+	result, err := tx.Exec(
+		query,
+		category.Name,
+		Key(category.Name),
+		nullString(category.ParentID),
+		nullString(category.Color),
+		nullString(category.Icon),
+		category.Archived,
+		category.UpdatedAt,
+		category.ID,
+		category.UserID,
+	)
+	if err != nil {
+		return 0, uniqueName(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if rowsAffected == 0 {
+		return 0, ErrCategoryNotFound
+	}
+
+	updated, err := renameCategories(tx, category.UserID, renamed, category.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	return updated, tx.Commit()
+}
+
+func (r *sqlRepository) FindByID(id, userID string) (*Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE id = ? AND user_id = ?`
+
+	category, err := scanCategory(r.db.QueryRow(query, id, userID))
+	if err == sql.ErrNoRows {
+		return nil, ErrCategoryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (r *sqlRepository) FindByUserID(userID string) ([]*Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE user_id = ? ORDER BY name`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []*Category{}
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, rows.Err()
+}
+
+// Delete moves the subcategories up to the parent of the removed category.
+func (r *sqlRepository) Delete(id, userID string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	category, err := scanCategory(tx.QueryRow(`SELECT `+categoryColumns+` FROM categories WHERE id = ? AND user_id = ?`, id, userID))
+	if err == sql.ErrNoRows {
+		return ErrCategoryNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`UPDATE categories SET parent_id = ? WHERE parent_id = ? AND user_id = ?`, nullString(category.ParentID), id, userID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM categories WHERE id = ? AND user_id = ?`, id, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Merge moves the subcategories of the sources under the target, removes
+// the sources and renames their expenses.
+func (r *sqlRepository) Merge(target *Category, sourceIDs []string, renamed []string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	for _, id := range sourceIDs {
+		if _, err := tx.Exec(`UPDATE categories SET parent_id = ? WHERE parent_id = ? AND user_id = ?`, target.ID, id, target.UserID); err != nil {
+			return 0, err
+		}
+
+		result, err := tx.Exec(`DELETE FROM categories WHERE id = ? AND user_id = ?`, id, target.UserID)
+		if err != nil {
+			return 0, err
+		}
+		if rowsAffected, err := result.RowsAffected(); err != nil {
+			return 0, err
+		} else if rowsAffected == 0 {
+			return 0, ErrCategoryNotFound
+		}
+	}
+
+	updated, err := renameCategories(tx, target.UserID, renamed, target.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	return updated, tx.Commit()
+}
+
+// renameCategories sets the category to name wherever its key is one of
+// renamed, and returns how many expenses changed.
+func renameCategories(tx *sql.Tx, userID string, renamed []string, name string) (int, error) {
+	if len(renamed) == 0 {
+		return 0, nil
+	}
+
+	keys := make(map[string]bool, len(renamed))
+	for _, key := range renamed {
+		keys[key] = true
+	}
+
+	updated := 0
+	for _, table := range renamedTables {
+		names, err := distinctCategories(tx, table, userID)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, old := range names {
+			if old == name || !keys[Key(old)] {
+				continue
+			}
+
+			result, err := tx.Exec(fmt.Sprintf("UPDATE %s SET category = ? WHERE user_id = ? AND category = ?", table), name, userID, old)
+			if err != nil {
+				return 0, err
+			}
+			if table == "expenses" {
+				rowsAffected, err := result.RowsAffected()
+				if err != nil {
+					return 0, err
+				}
+				updated += int(rowsAffected)
+			}
+		}
+	}
+
+	return updated, nil
+}
+
+func distinctCategories(tx *sql.Tx, table, userID string) ([]string, error) {
+	rows, err := tx.Query(fmt.Sprintf("SELECT DISTINCT category FROM %s WHERE user_id = ? AND category IS NOT NULL", table), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+func uniqueName(err error) error {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		return ErrCategoryExists
+	}
+	return err
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
@@ -0,0 +1,15 @@
+package category
+
+import "github.com/gin-gonic/gin"
+
+func RegisterRoutes(rg *gin.RouterGroup, handler *Handler) {
+	categories := rg.Group("/categories")
+	{
+		categories.GET("", handler.List)
+		categories.POST("", handler.Create)
+		categories.GET("/:id", handler.GetByID)
+		categories.PUT("/:id", handler.Update)
+		categories.DELETE("/:id", handler.Delete)
+		categories.POST("/:id/merge", handler.Merge)
+	}
+}
@@ -0,0 +1,294 @@
+package category
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gastei-quanto/src/internal/expense"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Create(userID string, req CreateCategoryRequest) (*Category, error)
+	GetByID(id, userID string) (*Category, error)
+	List(userID string, query ListCategoriesQuery) ([]*Category, error)
+	Update(id, userID string, req UpdateCategoryRequest) (*Category, error)
+	Delete(id, userID string) error
+	Merge(id, userID string, req MergeCategoriesRequest) (*MergeCategoriesResponse, error)
+	Roots(userID string) (func(category string) string, error)
+}
+
+// ChangeListener is told when expenses of the user had their category
+// renamed by a rename or a merge.
+type ChangeListener interface {
+	CategoriesRenamed(userID string)
+}
+
+type service struct {
+	repo      Repository
+	listeners []ChangeListener
+}
+
+func NewService(repo Repository, listeners ...ChangeListener) Service {
+	return &service{
+		repo:      repo,
+		listeners: listeners,
+	}
+}
+
+// Key is what makes two category names the same category: case, accents
+// and punctuation are ignored.
+func Key(name string) string {
+	return expense.NormalizeDescription(name)
+}
+
+func (s *service) Create(userID string, req CreateCategoryRequest) (*Category, error) {
+	now := time.Now()
+
+	category := &Category{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      strings.TrimSpace(req.Name),
+		Color:     strings.ToLower(req.Color),
+		Icon:      strings.TrimSpace(req.Icon),
+		Archived:  req.Archived,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if Key(category.Name) == "" {
+		return nil, ErrInvalidName
+	}
+
+	if req.ParentID != "" {
+		if err := s.checkParent(category, req.ParentID); err != nil {
+			return nil, err
+		}
+		category.ParentID = req.ParentID
+	}
+
+	if err := s.repo.Create(category); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (s *service) GetByID(id, userID string) (*Category, error) {
+	return s.repo.FindByID(id, userID)
+}
+
+func (s *service) List(userID string, query ListCategoriesQuery) ([]*Category, error) {
+	categories, err := s.repo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if query.IncludeArchived {
+		return categories, nil
+	}
+
+	active := []*Category{}
+	for _, category := range categories {
+		if !category.Archived {
+			active = append(active, category)
+		}
+	}
+	return active, nil
+}
+
+// Update renames the expenses along with the category, so they stay in it.
+func (s *service) Update(id, userID string, req UpdateCategoryRequest) (*Category, error) {
+	category, err := s.repo.FindByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var renamed []string
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if Key(name) == "" {
+			return nil, ErrInvalidName
+		}
+		if name != category.Name {
+			renamed = []string{Key(category.Name), Key(name)}
+			category.Name = name
+		}
+	}
+
+	if req.ParentID != nil && *req.ParentID != category.ParentID {
+		if *req.ParentID != "" {
+			if err := s.checkParent(category, *req.ParentID); err != nil {
+				return nil, err
+			}
+		}
+		category.ParentID = *req.ParentID
+	}
+
+	if req.Color != nil {
+		category.Color = strings.ToLower(*req.Color)
+	}
+
+	if req.Icon != nil {
+		category.Icon = strings.TrimSpace(*req.Icon)
+	}
+
+	if req.Archived != nil {
+		category.Archived = *req.Archived
+	}
+
+	category.UpdatedAt = time.Now()
+
+	updated, err := s.repo.Update(category, renamed)
+	if err != nil {
+		return nil, err
+	}
+	if updated > 0 {
+		s.notify(userID)
+	}
+
+	return category, nil
+}
+
+// Delete removes the category from the catalog; its expenses keep the
+// name and its subcategories move up to its parent.
+func (s *service) Delete(id, userID string) error {
+	return s.repo.Delete(id, userID)
+}
+
+// Merge folds other categories, and free-text names, into the category.
+// Every expense whose category is one of them, or a spelling of the
+// target's own name, gets the target name.
+func (s *service) Merge(id, userID string, req MergeCategoriesRequest) (*MergeCategoriesResponse, error) {
+	if len(req.CategoryIDs) == 0 && len(req.Names) == 0 {
+		return nil, ErrNothingToMerge
+	}
+
+	target, err := s.repo.FindByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	renamed := []string{Key(target.Name)}
+	var sourceIDs []string
+	for _, sourceID := range req.CategoryIDs {
+		if sourceID == target.ID {
+			continue
+		}
+
+		source, err := s.repo.FindByID(sourceID, userID)
+		if err != nil {
+			return nil, err
+		}
+		ancestor, err := s.isAncestor(source.ID, target)
+		if err != nil {
+			return nil, err
+		}
+		if ancestor {
+			return nil, ErrInvalidMerge
+		}
+
+		sourceIDs = append(sourceIDs, source.ID)
+		renamed = append(renamed, Key(source.Name))
+	}
+
+	for _, name := range req.Names {
+		if key := Key(name); key != "" {
+			renamed = append(renamed, key)
+		}
+	}
+
+	updated, err := s.repo.Merge(target, sourceIDs, renamed)
+	if err != nil {
+		return nil, err
+	}
+	if updated > 0 {
+		s.notify(userID)
+	}
+
+	return &MergeCategoriesResponse{
+		Category:        target,
+		UpdatedExpenses: updated,
+	}, nil
+}
+
+// Roots returns a function that maps a category name to the top-level
+// category it belongs to. Names outside the catalog map to themselves.
+func (s *service) Roots(userID string) (func(category string) string, error) {
+	categories, err := s.repo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*Category, len(categories))
+	byKey := make(map[string]*Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+		byKey[Key(category.Name)] = category
+	}
+
+	return func(name string) string {
+		category, ok := byKey[Key(name)]
+		if !ok {
+			return name
+		}
+		for i := 0; i < len(categories) && category.ParentID != ""; i++ {
+			parent, ok := byID[category.ParentID]
+			if !ok {
+				break
+			}
+			category = parent
+		}
+		return category.Name
+	}, nil
+}
+
+// checkParent makes sure the parent exists and is not the category itself
+// or one of its subcategories.
+func (s *service) checkParent(category *Category, parentID string) error {
+	parent, err := s.repo.FindByID(parentID, category.UserID)
+	if errors.Is(err, ErrCategoryNotFound) {
+		return ErrInvalidParent
+	}
+	if err != nil {
+		return err
+	}
+
+	ancestor, err := s.isAncestor(category.ID, parent)
+	if err != nil {
+		return err
+	}
+	if ancestor {
+		return ErrInvalidParent
+	}
+	return nil
+}
+
+// isAncestor reports whether id is the category itself or one of its
+// ancestors.
+func (s *service) isAncestor(id string, category *Category) (bool, error) {
+	categories, err := s.repo.FindByUserID(category.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	byID := make(map[string]*Category, len(categories))
+	for _, c := range categories {
+		byID[c.ID] = c
+	}
+
+	current := category
+	for i := 0; i <= len(categories) && current != nil; i++ {
+		if current.ID == id {
+			return true, nil
+		}
+		current = byID[current.ParentID]
+	}
+	return false, nil
+}
+
+func (s *service) notify(userID string) {
+	for _, listener := range s.listeners {
+		listener.CategoriesRenamed(userID)
+	}
+}
@@ -0,0 +1,73 @@
+package expense
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// fingerprintTransactions returns one fingerprint per transaction. The key is
+// the source's external ID when there is one, otherwise date, amount and
+// normalized description; the occurrence index keeps identical rows within
+// the same import (two equal rides on the same day) distinct.
+func fingerprintTransactions(source string, transactions []Transaction) []string {
+	return newFingerprinter(source).fingerprints(transactions)
+}
+
+// fingerprinter remembers how many times each key was seen, so an import
+// saved in chunks gets the same fingerprints as one saved at once.
+type fingerprinter struct {
+	source      string
+	occurrences map[string]int
+}
+
+func newFingerprinter(source string) *fingerprinter {
+	return &fingerprinter{source: source, occurrences: make(map[string]int)}
+}
+
+func (f *fingerprinter) fingerprints(transactions []Transaction) []string {
+	fingerprints := make([]string, len(transactions))
+
+	for i, t := range transactions {
+		key := transactionKey(f.source, t)
+		index := f.occurrences[key]
+		f.occurrences[key]++
+
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d", key, index)))
+		fingerprints[i] = hex.EncodeToString(sum[:])
+	}
+
+	return fingerprints
+}
+
+func transactionKey(source string, t Transaction) string {
+	if t.ExternalID != "" {
+		return "ext|" + source + "|" + t.ExternalID
+	}
+
+	return fmt.Sprintf("%s|%.2f|%s", t.Date.Format("2006-01-02"), t.Amount, NormalizeDescription(t.Description))
+}
+
+func NormalizeDescription(description string) string {
+	stripAccents := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	if stripped, _, err := transform.String(stripAccents, description); err == nil {
+		description = stripped
+	}
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(description) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
@@ -0,0 +1,251 @@
+package expense
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// iofRates are the IOF rates charged on card purchases abroad: 6.38% until
+// 2022, stepping down to 3.38% in 2025, and 3.5% since then.
+var iofRates = []float64{0.0638, 0.0538, 0.0438, 0.0338, 0.035}
+
+// iofTolerance absorbs the rounding of the IOF line to cents.
+const iofTolerance = 0.02
+
+// tripGap is the longest pause between purchases abroad of the same trip.
+const tripGap = 7 * 24 * time.Hour
+
+func isIOF(description string) bool {
+	for _, word := range strings.Fields(NormalizeDescription(description)) {
+		if word == "iof" {
+			return true
+		}
+	}
+	return false
+}
+
+// isForeignIOF reports IOF lines that are about purchases abroad, as opposed
+// to the IOF charged on loans and overdrafts.
+func isForeignIOF(description string) bool {
+	normalized := NormalizeDescription(description)
+	return isIOF(description) && (strings.Contains(normalized, "internacional") ||
+		strings.Contains(normalized, "exterior") ||
+		strings.Contains(normalized, "compra int"))
+}
+
+// iofDistance is how far an IOF amount is from what one of the rates gives
+// for the purchase, or -1 when it matches none.
+func iofDistance(iof, purchase float64) float64 {
+	best := -1.0
+	for _, rate := range iofRates {
+		distance := math.Abs(iof - purchase*rate)
+		if distance <= iofTolerance && (best < 0 || distance < best) {
+			best = distance
+		}
+	}
+	return best
+}
+
+// linkIOF links the IOF lines of an import to the purchase each one taxes:
+// an expense of the same day whose amount, at one of the IOF rates, gives
+// the IOF charged. Purchases with an original currency are preferred, and
+// the purchase may come from an earlier import. Linked IOF lines are moved
+// after the purchases so they are saved after what they reference.
+func linkIOF(repo Repository, userID string, expenses []*Expense) error {
+	taxed := make(map[string]bool)
+	byDay := make(map[string][]*Expense)
+	for _, expense := range expenses {
+		day := expense.Date.Format("2006-01-02")
+		byDay[day] = append(byDay[day], expense)
+	}
+
+	stored := make(map[string][]*Expense)
+	linked := false
+
+	for _, iof := range expenses {
+		if iof.Type != "expense" || !isIOF(iof.Description) {
+			continue
+		}
+
+		day := iof.Date.Format("2006-01-02")
+		if _, loaded := stored[day]; !loaded {
+			start := time.Date(iof.Date.Year(), iof.Date.Month(), iof.Date.Day(), 0, 0, 0, 0, iof.Date.Location())
+			end := start.Add(24*time.Hour - time.Nanosecond)
+			existing, err := repo.FindByUserID(userID, ListExpensesQuery{StartDate: &start, EndDate: &end})
+			if err != nil {
+				return err
+			}
+			for _, expense := range existing {
+				if expense.TaxedExpenseID != "" {
+					taxed[expense.TaxedExpenseID] = true
+				}
+			}
+			stored[day] = existing
+		}
+
+		candidates := make([]*Expense, 0, len(byDay[day])+len(stored[day]))
+		candidates = append(append(candidates, byDay[day]...), stored[day]...)
+
+		var purchase *Expense
+		best := 0.0
+		for _, candidate := range candidates {
+			if candidate.Type != "expense" || isIOF(candidate.Description) || taxed[candidate.ID] {
+				continue
+			}
+
+			distance := iofDistance(iof.Amount, candidate.Amount)
+			if distance < 0 {
+				continue
+			}
+			if candidate.OriginalCurrency == "" {
+				distance += iofTolerance
+			}
+
+			if purchase == nil || distance < best {
+				purchase, best = candidate, distance
+			}
+		}
+
+		if purchase != nil {
+			iof.TaxedExpenseID = purchase.ID
+			taxed[purchase.ID] = true
+			linked = true
+		}
+	}
+
+	if linked {
+		sort.SliceStable(expenses, func(i, j int) bool {
+			return expenses[i].TaxedExpenseID == "" && expenses[j].TaxedExpenseID != ""
+		})
+	}
+
+	return nil
+}
+
+// ForeignSpending reports the spending in foreign currency, grouped by month
+// or by trip (purchases abroad with no more than a week between them), with
+// the IOF charged on each purchase added to its cost.
+func (s *service) ForeignSpending(userID string, query ForeignSpendingQuery) (*ForeignSpendingReport, error) {
+	expenses, err := s.repo.FindByUserID(userID, ListExpensesQuery{
+		StartDate: query.StartDate,
+		EndDate:   query.EndDate,
+		Type:      "expense",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*Expense, len(expenses))
+	for _, expense := range expenses {
+		byID[expense.ID] = expense
+	}
+
+	iofOf := make(map[string][]*Expense)
+	var purchases []*Expense
+	for _, expense := range expenses {
+		switch {
+		case expense.TaxedExpenseID != "" && byID[expense.TaxedExpenseID] != nil:
+			iofOf[expense.TaxedExpenseID] = append(iofOf[expense.TaxedExpenseID], expense)
+		case expense.OriginalCurrency != "":
+			purchases = append(purchases, expense)
+		case isForeignIOF(expense.Description):
+			// IOF whose purchase was not found still counts as a cost abroad.
+			purchases = append(purchases, expense)
+		}
+	}
+	for id := range iofOf {
+		if byID[id].OriginalCurrency == "" {
+			purchases = append(purchases, byID[id])
+		}
+	}
+
+	sort.Slice(purchases, func(i, j int) bool {
+		return purchases[i].Date.Before(purchases[j].Date)
+	})
+
+	report := &ForeignSpendingReport{
+		GroupBy: query.GroupBy,
+		Groups:  []ForeignSpendingGroup{},
+	}
+
+	var group *ForeignSpendingGroup
+	reais := make(map[string]float64)
+
+	closeGroup := func() {
+		if group == nil {
+			return
+		}
+		for currency, amount := range group.Currencies {
+			group.Currencies[currency] = round2(amount)
+			if amount > 0 {
+				group.EffectiveRates[currency] = math.Round(reais[currency]/amount*10000) / 10000
+			}
+		}
+		group.PurchaseAmount = round2(group.PurchaseAmount)
+		group.IOFAmount = round2(group.IOFAmount)
+		group.TotalCost = round2(group.PurchaseAmount + group.IOFAmount)
+
+		report.PurchaseAmount += group.PurchaseAmount
+		report.IOFAmount += group.IOFAmount
+		report.Groups = append(report.Groups, *group)
+		group = nil
+	}
+
+	trips := 0
+	for _, purchase := range purchases {
+		period := purchase.Date.Format("2006-01")
+		if query.GroupBy == ForeignGroupByTrip {
+			if group == nil || purchase.Date.Sub(group.EndDate) > tripGap {
+				trips++
+			}
+			period = fmt.Sprintf("trip-%d", trips)
+		}
+
+		if group == nil || group.Period != period {
+			closeGroup()
+			group = &ForeignSpendingGroup{
+				Period:         period,
+				StartDate:      purchase.Date,
+				Currencies:     make(map[string]float64),
+				EffectiveRates: make(map[string]float64),
+				Expenses:       []*Expense{},
+			}
+			reais = make(map[string]float64)
+		}
+		group.EndDate = purchase.Date
+
+		group.Expenses = append(group.Expenses, purchase)
+		iofAmount := 0.0
+		for _, iof := range iofOf[purchase.ID] {
+			iofAmount += iof.Amount
+			group.Expenses = append(group.Expenses, iof)
+		}
+		group.IOFAmount += iofAmount
+
+		if isIOF(purchase.Description) && purchase.OriginalCurrency == "" {
+			group.IOFAmount += purchase.Amount
+			continue
+		}
+
+		group.Purchases++
+		group.PurchaseAmount += purchase.Amount
+		if purchase.OriginalCurrency != "" {
+			group.Currencies[purchase.OriginalCurrency] += purchase.OriginalAmount
+			reais[purchase.OriginalCurrency] += purchase.Amount + iofAmount
+		}
+	}
+	closeGroup()
+
+	report.PurchaseAmount = round2(report.PurchaseAmount)
+	report.IOFAmount = round2(report.IOFAmount)
+	report.TotalCost = round2(report.PurchaseAmount + report.IOFAmount)
+
+	return report, nil
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -1,7 +1,9 @@
 package expense
 
 import (
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -88,6 +90,10 @@ func (h *Handler) GetByID(c *gin.Context) {
 // @Param start_date query string false "Data inicial (YYYY-MM-DD)"
 // @Param end_date query string false "Data final (YYYY-MM-DD)"
 // @Param category query string false "Filtrar por categoria"
+// @Param batch_id query string false "Filtrar por lote de importação"
+// @Param installment_plan_id query string false "Filtrar por compra parcelada"
+// @Param account query string false "Filtrar por conta (migrada de outro app)"
+// @Param tag query string false "Filtrar por tag"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
@@ -205,13 +211,14 @@ func (h *Handler) Delete(c *gin.Context) {
 
 // GetStats godoc
 // @Summary Obtém estatísticas das despesas
-// @Description Retorna estatísticas agregadas das despesas do usuário autenticado
+// @Description Retorna estatísticas agregadas das despesas do usuário autenticado, com os totais por categoria. Com rollup=true, as subcategorias do catálogo entram no total da categoria de primeiro nível
 // @Tags expenses
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param start_date query string false "Data inicial (YYYY-MM-DD)"
 // @Param end_date query string false "Data final (YYYY-MM-DD)"
+// @Param rollup query bool false "Agrupar subcategorias na categoria de primeiro nível"
 // @Success 200 {object} ExpenseStats
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
@@ -240,7 +247,17 @@ func (h *Handler) GetStats(c *gin.Context) {
 		endDate = &parsed
 	}
 
-	stats, err := h.service.GetStats(userID, startDate, endDate)
+	rollup := false
+	if rollupStr := c.Query("rollup"); rollupStr != "" {
+		parsed, err := strconv.ParseBool(rollupStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid rollup, use true or false"})
+			return
+		}
+		rollup = parsed
+	}
+
+	stats, err := h.service.GetStats(userID, startDate, endDate, rollup)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -249,6 +266,57 @@ func (h *Handler) GetStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+// ForeignSpending godoc
+// @Summary Relatório de gastos em moeda estrangeira
+// @Description Retorna as compras em moeda estrangeira agrupadas por mês ou por viagem (compras com até 7 dias de intervalo), com o IOF de cada compra somado ao custo total e a cotação efetiva por moeda
+// @Tags expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Data inicial (YYYY-MM-DD)"
+// @Param end_date query string false "Data final (YYYY-MM-DD)"
+// @Param group_by query string false "Agrupamento (month ou trip, padrão month)"
+// @Success 200 {object} ForeignSpendingReport
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /expenses/foreign [get]
+func (h *Handler) ForeignSpending(c *gin.Context) {
+	query := ForeignSpendingQuery{GroupBy: c.DefaultQuery("group_by", ForeignGroupByMonth)}
+	if query.GroupBy != ForeignGroupByMonth && query.GroupBy != ForeignGroupByTrip {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group_by, use month or trip"})
+		return
+	}
+
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		parsed, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format, use YYYY-MM-DD"})
+			return
+		}
+		query.StartDate = &parsed
+	}
+
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		parsed, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_date format, use YYYY-MM-DD"})
+			return
+		}
+		query.EndDate = &parsed
+	}
+
+	userID := c.GetString("user_id")
+
+	report, err := h.service.ForeignSpending(userID, query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
 // ImportTransactions godoc
 // @Summary Importa transações em lote
 // @Description Importa múltiplas transações de uma só vez para o usuário autenticado
@@ -271,14 +339,212 @@ func (h *Handler) ImportTransactions(c *gin.Context) {
 
 	userID := c.GetString("user_id")
 
-	count, err := h.service.ImportTransactions(userID, req.Transactions)
+	result, err := h.service.ImportTransactions(userID, ImportSource{
+		Filename:  req.Filename,
+		Source:    "api",
+		TotalRows: len(req.Transactions),
+	}, req.Transactions)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "transactions imported successfully",
-		"count":   count,
+		"message":            "transactions imported successfully",
+		"count":              result.Saved,
+		"batch_id":           result.BatchID,
+		"skipped_duplicates": result.SkippedDuplicates,
 	})
-}
\ No newline at end of file
+}
+
+// ListImportBatches godoc
+// @Summary Lista os lotes de importação
+// @Description Retorna o histórico de importações do usuário autenticado
+// @Tags expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /expenses/imports [get]
+func (h *Handler) ListImportBatches(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	batches, err := h.service.ListImportBatches(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"imports": batches,
+		"count":   len(batches),
+	})
+}
+
+// GetImportBatch godoc
+// @Summary Busca um lote de importação
+// @Description Retorna um lote de importação e as despesas criadas por ele
+// @Tags expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do lote de importação"
+// @Success 200 {object} ImportBatchDetail
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /expenses/imports/{id} [get]
+func (h *Handler) GetImportBatch(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.GetString("user_id")
+
+	batch, err := h.service.GetImportBatch(id, userID)
+	if err != nil {
+		if err.Error() == "import batch not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, batch)
+}
+
+// RollbackImportBatch godoc
+// @Summary Desfaz um lote de importação
+// @Description Remove, em uma única transação, todas as despesas criadas por um lote de importação. Lotes ainda em processamento precisam ser cancelados antes
+// @Tags expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do lote de importação"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /expenses/imports/{id}/rollback [post]
+func (h *Handler) RollbackImportBatch(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.GetString("user_id")
+
+	deleted, err := h.service.RollbackImportBatch(id, userID)
+	if err != nil {
+		switch err.Error() {
+		case "import batch not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "import batch already rolled back", "import batch is still running":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "import batch rolled back successfully",
+		"deleted": deleted,
+	})
+}
+
+// ListInstallmentPlans godoc
+// @Summary Lista as compras parceladas
+// @Description Retorna as compras parceladas detectadas nas importações, com parcelas pagas, restantes e a data da próxima parcela
+// @Tags expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /expenses/installments [get]
+func (h *Handler) ListInstallmentPlans(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	plans, err := h.service.ListInstallmentPlans(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"installments": plans,
+		"count":        len(plans),
+	})
+}
+
+// GetInstallmentPlan godoc
+// @Summary Busca uma compra parcelada
+// @Description Retorna uma compra parcelada com todas as parcelas, pagas e pendentes, e as despesas importadas de cada uma
+// @Tags expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da compra parcelada"
+// @Success 200 {object} InstallmentPlanDetail
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /expenses/installments/{id} [get]
+func (h *Handler) GetInstallmentPlan(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.GetString("user_id")
+
+	plan, err := h.service.GetInstallmentPlan(id, userID)
+	if err != nil {
+		if err.Error() == "installment plan not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+// UpcomingInstallments godoc
+// @Summary Projeta as parcelas futuras
+// @Description Retorna as parcelas ainda não pagas agrupadas por mês, a partir do mês atual, com o total comprometido em cada mês
+// @Tags expenses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param months query int false "Quantidade de meses projetados (1 a 60, padrão 12)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /expenses/installments/upcoming [get]
+func (h *Handler) UpcomingInstallments(c *gin.Context) {
+	months := 12
+	if monthsStr := c.Query("months"); monthsStr != "" {
+		parsed, err := strconv.Atoi(monthsStr)
+		if err != nil || parsed < 1 || parsed > 60 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid months, use a number between 1 and 60"})
+			return
+		}
+		months = parsed
+	}
+
+	userID := c.GetString("user_id")
+
+	upcoming, err := h.service.UpcomingInstallments(userID, months)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	total := 0.0
+	for _, month := range upcoming {
+		total += month.Total
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"months": upcoming,
+		"total":  math.Round(total*100) / 100,
+	})
+}
@@ -0,0 +1,166 @@
+package expense
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportWriter saves an import in chunks, for files too large to hold in
+// memory at once. Each Write is its own transaction and updates the batch
+// counters, so an import that fails or is cancelled halfway keeps what was
+// already saved and can be rolled back like any other batch.
+type ImportWriter struct {
+	repo         Repository
+	batch        ImportBatch
+	fingerprints *fingerprinter
+	planner      *installmentPlanner
+	notify       func(before, after *Expense)
+}
+
+func (s *service) BeginImport(userID string, source ImportSource) (*ImportWriter, error) {
+	writer := &ImportWriter{
+		repo: s.repo,
+		batch: ImportBatch{
+			ID:           uuid.New().String(),
+			UserID:       userID,
+			Filename:     source.Filename,
+			Source:       source.Source,
+			TotalRows:    source.TotalRows,
+			RejectedRows: source.RejectedRows,
+			Status:       ImportBatchRunning,
+			CreatedAt:    time.Now(),
+		},
+		fingerprints: newFingerprinter(source.Source),
+		planner:      newInstallmentPlanner(s.repo, userID),
+		notify:       s.notify,
+	}
+
+	batch := writer.batch
+	if err := s.repo.CreateImportBatch(&batch, nil, nil); err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+func (w *ImportWriter) BatchID() string {
+	return w.batch.ID
+}
+
+// Write saves the chunk's transactions, skipping the ones already imported,
+// and counts rejected rows of the file that were read along with them.
+func (w *ImportWriter) Write(transactions []Transaction, rejected int) (*ImportResult, error) {
+	if w.batch.Status != ImportBatchRunning {
+		return nil, fmt.Errorf("import batch %s is %s", w.batch.ID, w.batch.Status)
+	}
+
+	now := time.Now()
+	fingerprints := w.fingerprints.fingerprints(transactions)
+
+	existing, err := w.repo.FindExistingFingerprints(w.batch.UserID, fingerprints)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := w.batch
+	expenses := make([]*Expense, 0, len(transactions))
+	skipped := 0
+
+	for i, t := range transactions {
+		if existing[fingerprints[i]] {
+			skipped++
+			continue
+		}
+
+		expense := importedExpense(batch.UserID, batch.ID, fingerprints[i], t, now)
+		if err := w.planner.link(expense, t); err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, expense)
+	}
+
+	if err := linkIOF(w.repo, batch.UserID, expenses); err != nil {
+		return nil, err
+	}
+
+	batch.SavedRows += len(expenses)
+	batch.SkippedDuplicates += skipped
+	batch.RejectedRows += rejected
+	if batch.TotalRows < batch.SavedRows+batch.SkippedDuplicates+batch.RejectedRows {
+		batch.TotalRows = batch.SavedRows + batch.SkippedDuplicates + batch.RejectedRows
+	}
+
+	if err := w.repo.AppendImportBatch(&batch, expenses, w.planner.takeCreated()); err != nil {
+		return nil, err
+	}
+	w.batch = batch
+
+	for _, expense := range expenses {
+		w.notify(nil, expense)
+	}
+
+	return &ImportResult{
+		BatchID:           batch.ID,
+		Saved:             len(expenses),
+		SkippedDuplicates: skipped,
+	}, nil
+}
+
+// Close records the final status of the batch: ImportBatchCompleted,
+// ImportBatchFailed or ImportBatchCancelled.
+func (w *ImportWriter) Close(status string) (*ImportBatch, error) {
+	batch := w.batch
+	batch.Status = status
+
+	if err := w.repo.AppendImportBatch(&batch, nil, nil); err != nil {
+		return nil, err
+	}
+	w.batch = batch
+
+	return &batch, nil
+}
+
+func importedExpense(userID, batchID, fingerprint string, t Transaction, now time.Time) *Expense {
+	expenseType := t.Type
+	if expenseType == "" {
+		expenseType = "expense"
+		if t.Amount > 0 {
+			expenseType = "income"
+		}
+	}
+
+	amount := t.Amount
+	if amount < 0 {
+		amount = -amount
+	}
+
+	categorySource := t.CategorySource
+	if categorySource == "" && t.Category != "" {
+		categorySource = CategorySourceImported
+	}
+
+	return &Expense{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Date:        t.Date,
+		Description: t.Description,
+		Category:    t.Category,
+		Amount:      amount,
+		Type:        expenseType,
+		BatchID:     batchID,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+
+		OriginalCurrency: t.OriginalCurrency,
+		OriginalAmount:   t.OriginalAmount,
+		ExchangeRate:     t.ExchangeRate,
+
+		Account: t.Account,
+		Tags:    t.Tags,
+
+		CategorySource: categorySource,
+	}
+}
@@ -0,0 +1,302 @@
+package expense
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// installmentPatterns are the ways statements write "installment N of M":
+// "Amazon - Parcela 1/4", "Parcela 2 de 10", "LOJA X PARC 02/10" and
+// "Curso 3 de 12".
+var installmentPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\bparcela\s*(\d{1,2})\s*(?:/|de)\s*(\d{1,2})\b`),
+	regexp.MustCompile(`(?i)\bparc\.?\s*(\d{1,2})\s*/\s*(\d{1,2})\b`),
+	regexp.MustCompile(`(?i)\b(\d{1,2})\s+de\s+(\d{1,2})\s*$`),
+}
+
+// ParseInstallment finds an installment marker in a description and returns
+// the description without it.
+func ParseInstallment(description string) (string, Installment, bool) {
+	for _, pattern := range installmentPatterns {
+		loc := pattern.FindStringSubmatchIndex(description)
+		if loc == nil {
+			continue
+		}
+
+		number, _ := strconv.Atoi(description[loc[2]:loc[3]])
+		total, _ := strconv.Atoi(description[loc[4]:loc[5]])
+		if number < 1 || total < 2 || number > total {
+			continue
+		}
+
+		base := description[:loc[0]] + " " + description[loc[1]:]
+		base = strings.Trim(strings.Join(strings.Fields(base), " "), " -–")
+
+		return base, Installment{Number: number, Total: total}, true
+	}
+
+	return "", Installment{}, false
+}
+
+func transactionInstallment(t Transaction) (string, Installment, bool) {
+	base, installment, ok := ParseInstallment(t.Description)
+	if t.Installment != nil {
+		if !ok {
+			base = strings.TrimSpace(t.Description)
+		}
+		return base, *t.Installment, true
+	}
+	return base, installment, ok
+}
+
+func installmentPlanKey(description string, total int, amount float64) string {
+	return fmt.Sprintf("%s|%d|%d", NormalizeDescription(description), total, int64(math.Round(amount*100)))
+}
+
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
+
+// addMonths moves t by n calendar months, clamping the day to the end of the
+// target month: unlike AddDate, Jan 31 plus one month is Feb 28, not Mar 3.
+func addMonths(t time.Time, n int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// installmentPlanner links the installments of an import to their plans,
+// creating the plans seen for the first time.
+type installmentPlanner struct {
+	repo    Repository
+	userID  string
+	plans   map[string][]*InstallmentPlan
+	created []*InstallmentPlan
+}
+
+func newInstallmentPlanner(repo Repository, userID string) *installmentPlanner {
+	return &installmentPlanner{
+		repo:   repo,
+		userID: userID,
+		plans:  make(map[string][]*InstallmentPlan),
+	}
+}
+
+// link sets the plan of an installment expense. Among the plans of the same
+// purchase (description, amount and number of installments), it picks the one
+// whose first installment is closest to the one this installment implies and
+// that does not have this installment yet; two equal purchases in different
+// months stay separate plans.
+func (p *installmentPlanner) link(expense *Expense, t Transaction) error {
+	if expense.Type != "expense" {
+		return nil
+	}
+
+	base, installment, ok := transactionInstallment(t)
+	if !ok || base == "" {
+		return nil
+	}
+
+	key := installmentPlanKey(base, installment.Total, expense.Amount)
+	candidates, loaded := p.plans[key]
+	if !loaded {
+		found, err := p.repo.FindInstallmentPlansByKey(p.userID, key)
+		if err != nil {
+			return err
+		}
+		candidates = found
+		p.plans[key] = candidates
+	}
+
+	first := addMonths(expense.Date, -(installment.Number - 1))
+
+	var plan *InstallmentPlan
+	best := installment.Total
+	for _, candidate := range candidates {
+		if candidate.linked[installment.Number] {
+			continue
+		}
+		distance := monthsBetween(candidate.FirstInstallmentDate, first)
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance < best {
+			plan, best = candidate, distance
+		}
+	}
+
+	if plan == nil {
+		plan = &InstallmentPlan{
+			ID:                   uuid.New().String(),
+			UserID:               p.userID,
+			Description:          base,
+			Category:             expense.Category,
+			InstallmentAmount:    expense.Amount,
+			TotalInstallments:    installment.Total,
+			TotalAmount:          math.Round(expense.Amount*float64(installment.Total)*100) / 100,
+			FirstInstallmentDate: first,
+			CreatedAt:            time.Now(),
+			key:                  key,
+			linked:               make(map[int]bool),
+		}
+		p.plans[key] = append(p.plans[key], plan)
+		p.created = append(p.created, plan)
+	}
+
+	plan.linked[installment.Number] = true
+	expense.InstallmentPlanID = plan.ID
+	expense.InstallmentNumber = installment.Number
+
+	return nil
+}
+
+// takeCreated returns the plans created since the last call, to be saved
+// along with the expenses that reference them.
+func (p *installmentPlanner) takeCreated() []*InstallmentPlan {
+	created := p.created
+	p.created = nil
+	return created
+}
+
+func (s *service) ListInstallmentPlans(userID string) ([]*InstallmentPlan, error) {
+	plans, err := s.repo.FindInstallmentPlans(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, plan := range plans {
+		fillInstallmentProgress(plan)
+	}
+
+	return plans, nil
+}
+
+func (s *service) GetInstallmentPlan(id, userID string) (*InstallmentPlanDetail, error) {
+	plan, err := s.repo.FindInstallmentPlanByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	fillInstallmentProgress(plan)
+
+	expenses, err := s.repo.FindByUserID(userID, ListExpensesQuery{InstallmentPlanID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	byNumber := make(map[int]*Expense, len(expenses))
+	for _, expense := range expenses {
+		byNumber[expense.InstallmentNumber] = expense
+	}
+
+	detail := &InstallmentPlanDetail{
+		InstallmentPlan: *plan,
+		Installments:    make([]InstallmentEntry, plan.TotalInstallments),
+	}
+
+	for i := range detail.Installments {
+		number := i + 1
+		entry := InstallmentEntry{
+			Number: number,
+			Date:   addMonths(plan.FirstInstallmentDate, i),
+			Amount: plan.InstallmentAmount,
+			Status: InstallmentPending,
+		}
+		if number <= plan.PaidInstallments {
+			entry.Status = InstallmentPaid
+		}
+		if expense, ok := byNumber[number]; ok {
+			entry.Date = expense.Date
+			entry.Amount = expense.Amount
+			entry.ExpenseID = expense.ID
+		}
+		detail.Installments[i] = entry
+	}
+
+	return detail, nil
+}
+
+// UpcomingInstallments lists the installments still to be charged, grouped
+// by month, for the next months (the current month included).
+func (s *service) UpcomingInstallments(userID string, months int) ([]InstallmentMonth, error) {
+	plans, err := s.repo.FindInstallmentPlans(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := addMonths(start, months)
+
+	byMonth := make(map[string]*InstallmentMonth)
+	for _, plan := range plans {
+		fillInstallmentProgress(plan)
+
+		for number := plan.PaidInstallments + 1; number <= plan.TotalInstallments; number++ {
+			date := addMonths(plan.FirstInstallmentDate, number-1)
+			if date.Before(start) || !date.Before(end) {
+				continue
+			}
+
+			key := date.Format("2006-01")
+			month, exists := byMonth[key]
+			if !exists {
+				month = &InstallmentMonth{Month: key, Installments: []UpcomingInstallment{}}
+				byMonth[key] = month
+			}
+
+			month.Total += plan.InstallmentAmount
+			month.Installments = append(month.Installments, UpcomingInstallment{
+				PlanID:            plan.ID,
+				Description:       plan.Description,
+				Category:          plan.Category,
+				Number:            number,
+				TotalInstallments: plan.TotalInstallments,
+				Amount:            plan.InstallmentAmount,
+				Date:              date,
+			})
+		}
+	}
+
+	result := make([]InstallmentMonth, 0, len(byMonth))
+	for _, month := range byMonth {
+		month.Total = math.Round(month.Total*100) / 100
+		sort.Slice(month.Installments, func(i, j int) bool {
+			return month.Installments[i].Date.Before(month.Installments[j].Date)
+		})
+		result = append(result, *month)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Month < result[j].Month
+	})
+
+	return result, nil
+}
+
+// fillInstallmentProgress derives the remaining installments and amounts from
+// PaidInstallments, which the repository reads from the linked expenses.
+func fillInstallmentProgress(plan *InstallmentPlan) {
+	plan.RemainingInstallments = plan.TotalInstallments - plan.PaidInstallments
+	if plan.RemainingInstallments < 0 {
+		plan.RemainingInstallments = 0
+	}
+
+	plan.PaidAmount = math.Round(plan.InstallmentAmount*float64(plan.PaidInstallments)*100) / 100
+	plan.RemainingAmount = math.Round((plan.TotalAmount-plan.PaidAmount)*100) / 100
+
+	plan.NextInstallmentDate = nil
+	if plan.RemainingInstallments > 0 {
+		next := addMonths(plan.FirstInstallmentDate, plan.PaidInstallments)
+		plan.NextInstallmentDate = &next
+	}
+}
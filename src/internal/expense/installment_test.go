@@ -0,0 +1,89 @@
+package expense
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAddMonths(t *testing.T) {
+	tests := []struct {
+		from   time.Time
+		months int
+		want   time.Time
+	}{
+		{date(2026, 1, 31), 1, date(2026, 2, 28)},
+		{date(2026, 1, 31), 2, date(2026, 3, 31)},
+		{date(2026, 1, 31), 3, date(2026, 4, 30)},
+		{date(2028, 1, 31), 1, date(2028, 2, 29)},
+		{date(2026, 3, 31), -1, date(2026, 2, 28)},
+		{date(2026, 3, 31), -3, date(2025, 12, 31)},
+		{date(2026, 12, 31), 2, date(2027, 2, 28)},
+		{date(2026, 5, 15), 0, date(2026, 5, 15)},
+		{time.Date(2026, 1, 31, 14, 30, 0, 0, time.UTC), 1, time.Date(2026, 2, 28, 14, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := addMonths(tt.from, tt.months); !got.Equal(tt.want) {
+			t.Errorf("addMonths(%s, %d) = %s, want %s", tt.from.Format(time.DateTime), tt.months, got.Format(time.DateTime), tt.want.Format(time.DateTime))
+		}
+	}
+}
+
+func TestInstallmentPlannerFirstDate(t *testing.T) {
+	tests := []struct {
+		charged     time.Time
+		description string
+		want        time.Time
+	}{
+		{date(2026, 3, 31), "Loja - Parcela 2/4", date(2026, 2, 28)},
+		{date(2026, 3, 31), "Loja - Parcela 4/4", date(2025, 12, 31)},
+		{date(2026, 1, 31), "Loja - Parcela 1/4", date(2026, 1, 31)},
+	}
+
+	for _, tt := range tests {
+		planner := newInstallmentPlanner(NewRepository(), "user")
+		expense := &Expense{Type: "expense", Amount: 100, Date: tt.charged}
+		if err := planner.link(expense, Transaction{Description: tt.description, Date: tt.charged, Amount: 100}); err != nil {
+			t.Fatalf("link(%q): %v", tt.description, err)
+		}
+
+		created := planner.takeCreated()
+		if len(created) != 1 {
+			t.Fatalf("link(%q) created %d plans, want 1", tt.description, len(created))
+		}
+		if got := created[0].FirstInstallmentDate; !got.Equal(tt.want) {
+			t.Errorf("%q charged on %s: first installment %s, want %s", tt.description, tt.charged.Format(time.DateOnly), got.Format(time.DateOnly), tt.want.Format(time.DateOnly))
+		}
+	}
+}
+
+func TestFillInstallmentProgressNextDate(t *testing.T) {
+	tests := []struct {
+		paid int
+		want time.Time
+	}{
+		{0, date(2026, 1, 31)},
+		{1, date(2026, 2, 28)},
+		{2, date(2026, 3, 31)},
+		{3, date(2026, 4, 30)},
+	}
+
+	for _, tt := range tests {
+		plan := &InstallmentPlan{
+			TotalInstallments:    4,
+			InstallmentAmount:    100,
+			TotalAmount:          400,
+			FirstInstallmentDate: date(2026, 1, 31),
+			PaidInstallments:     tt.paid,
+		}
+		fillInstallmentProgress(plan)
+
+		if plan.NextInstallmentDate == nil || !plan.NextInstallmentDate.Equal(tt.want) {
+			t.Errorf("paid %d: next installment %v, want %s", tt.paid, plan.NextInstallmentDate, tt.want.Format(time.DateOnly))
+		}
+	}
+}
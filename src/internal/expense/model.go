@@ -10,16 +10,65 @@ type Expense struct {
 	Category    string    `json:"category"`
 	Amount      float64   `json:"amount"`
 	Type        string    `json:"type"`
+	BatchID     string    `json:"batch_id,omitempty"`
+	Fingerprint string    `json:"fingerprint,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+
+	InstallmentPlanID string `json:"installment_plan_id,omitempty"`
+	InstallmentNumber int    `json:"installment_number,omitempty"`
+
+	// Purchases made in another currency keep the charged amount and the
+	// exchange rate; Amount is always in reais. TaxedExpenseID links an IOF
+	// charge to the purchase it taxes.
+	OriginalCurrency string  `json:"original_currency,omitempty"`
+	OriginalAmount   float64 `json:"original_amount,omitempty"`
+	ExchangeRate     float64 `json:"exchange_rate,omitempty"`
+	TaxedExpenseID   string  `json:"taxed_expense_id,omitempty"`
+
+	// Account and Tags come from the app the expenses were migrated from.
+	Account string   `json:"account,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+
+	// CategorySource tells who chose the category; see the CategorySource
+	// constants. Empty for expenses without a category and for ones saved
+	// before it was recorded, which re-categorization may overwrite.
+	CategorySource string `json:"category_source,omitempty"`
+}
+
+// Categories chosen by the user, by hand or in the file or app they were
+// imported from, are kept by re-categorization unless it is forced; the
+// others came from the categorization pipeline.
+const (
+	CategorySourceManual     = "manual"
+	CategorySourceImported   = "imported"
+	CategorySourceRule       = "rule"
+	CategorySourceClassifier = "classifier"
+	CategorySourceKeywords   = "keywords"
+)
+
+// CategoryChosenByUser reports whether the category came from the user
+// rather than from the categorization pipeline.
+func CategoryChosenByUser(source string) bool {
+	return source == CategorySourceManual || source == CategorySourceImported
+}
+
+// CategoryUpdate sets the category of one expense.
+type CategoryUpdate struct {
+	ID       string
+	Category string
+	Source   string
 }
 
+// CategorySource is set by the services that create expenses on the
+// user's behalf; a category sent through the API is the user's.
 type CreateExpenseRequest struct {
-	Date        time.Time `json:"date" binding:"required"`
-	Description string    `json:"description" binding:"required"`
-	Category    string    `json:"category"`
-	Amount      float64   `json:"amount" binding:"required"`
-	Type        string    `json:"type" binding:"required,oneof=income expense"`
+	Date           time.Time `json:"date" binding:"required"`
+	Description    string    `json:"description" binding:"required"`
+	Category       string    `json:"category"`
+	Amount         float64   `json:"amount" binding:"required"`
+	Type           string    `json:"type" binding:"required,oneof=income expense"`
+	CategorySource string    `json:"-"`
 }
 
 type UpdateExpenseRequest struct {
@@ -38,25 +87,199 @@ type ListExpensesQuery struct {
 	MinAmount   *float64   `form:"min_amount"`
 	MaxAmount   *float64   `form:"max_amount"`
 	Description string     `form:"description"`
+	BatchID     string     `form:"batch_id"`
+
+	InstallmentPlanID string `form:"installment_plan_id"`
+	Account           string `form:"account"`
+	Tag               string `form:"tag"`
 }
 
 type ImportTransactionsRequest struct {
-	Transactions []Transaction `json:"transactions" binding:"required"`
+	Filename     string        `json:"filename"`
+	Transactions []Transaction `json:"transactions" binding:"required,dive"`
 }
 
+// Transaction is a row to import. Type says whether it is an expense or an
+// income; when it is empty, the sign of Amount decides (positive amounts are
+// income), as in an account statement.
 type Transaction struct {
 	Date        time.Time `json:"date"`
 	Description string    `json:"description"`
 	Category    string    `json:"category"`
 	Amount      float64   `json:"amount"`
+	Type        string    `json:"type,omitempty" binding:"omitempty,oneof=income expense"`
+	ExternalID  string    `json:"external_id,omitempty"`
+
+	// Installment is read from the description ("Parcela 2/10") when the
+	// source does not provide it.
+	Installment *Installment `json:"installment,omitempty"`
+
+	OriginalCurrency string  `json:"original_currency,omitempty" binding:"omitempty,len=3,uppercase"`
+	OriginalAmount   float64 `json:"original_amount,omitempty" binding:"omitempty,gt=0"`
+	ExchangeRate     float64 `json:"exchange_rate,omitempty" binding:"omitempty,gt=0"`
+
+	Account string   `json:"account,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+
+	// CategorySource is set by the importer for the categories it filled
+	// in; categories that came with the rows are imported.
+	CategorySource string `json:"-"`
+}
+
+type Installment struct {
+	Number int `json:"number" binding:"min=1"`
+	Total  int `json:"total" binding:"min=2"`
 }
 
 type ExpenseStats struct {
-	TotalIncome   float64 `json:"total_income"`
-	TotalExpense  float64 `json:"total_expense"`
-	Balance       float64 `json:"balance"`
-	Count         int     `json:"count"`
-	IncomeCount   int     `json:"income_count"`
-	ExpenseCount  int     `json:"expense_count"`
+	TotalIncome  float64 `json:"total_income"`
+	TotalExpense float64 `json:"total_expense"`
+	Balance      float64 `json:"balance"`
+	Count        int     `json:"count"`
+	IncomeCount  int     `json:"income_count"`
+	ExpenseCount int     `json:"expense_count"`
+
+	ByCategory []CategoryStats `json:"by_category"`
 }
 
+// CategoryStats totals one category. Rolled up, a category also adds up
+// its subcategories.
+type CategoryStats struct {
+	Category     string  `json:"category"`
+	TotalIncome  float64 `json:"total_income"`
+	TotalExpense float64 `json:"total_expense"`
+	Count        int     `json:"count"`
+}
+
+const (
+	ImportBatchRunning    = "running"
+	ImportBatchCompleted  = "completed"
+	ImportBatchFailed     = "failed"
+	ImportBatchCancelled  = "cancelled"
+	ImportBatchRolledBack = "rolled_back"
+)
+
+type ImportBatch struct {
+	ID                string     `json:"id"`
+	UserID            string     `json:"user_id"`
+	Filename          string     `json:"filename"`
+	Source            string     `json:"source"`
+	TotalRows         int        `json:"total_rows"`
+	SavedRows         int        `json:"saved_rows"`
+	RejectedRows      int        `json:"rejected_rows"`
+	SkippedDuplicates int        `json:"skipped_duplicates"`
+	Status            string     `json:"status"`
+	CreatedAt         time.Time  `json:"created_at"`
+	RolledBackAt      *time.Time `json:"rolled_back_at,omitempty"`
+}
+
+type ImportBatchDetail struct {
+	ImportBatch
+	Expenses []*Expense `json:"expenses"`
+}
+
+type ImportSource struct {
+	Filename     string
+	Source       string
+	TotalRows    int
+	RejectedRows int
+}
+
+type ImportResult struct {
+	BatchID           string `json:"batch_id"`
+	Saved             int    `json:"saved"`
+	SkippedDuplicates int    `json:"skipped_duplicates"`
+}
+
+// InstallmentPlan groups the installments of one purchase. The first
+// installment date is estimated from any imported installment, one month
+// apart each. Paid installments go up to the highest one imported so far.
+type InstallmentPlan struct {
+	ID                    string     `json:"id"`
+	UserID                string     `json:"user_id"`
+	Description           string     `json:"description"`
+	Category              string     `json:"category"`
+	InstallmentAmount     float64    `json:"installment_amount"`
+	TotalInstallments     int        `json:"total_installments"`
+	TotalAmount           float64    `json:"total_amount"`
+	FirstInstallmentDate  time.Time  `json:"first_installment_date"`
+	CreatedAt             time.Time  `json:"created_at"`
+	ImportedInstallments  int        `json:"imported_installments"`
+	PaidInstallments      int        `json:"paid_installments"`
+	RemainingInstallments int        `json:"remaining_installments"`
+	PaidAmount            float64    `json:"paid_amount"`
+	RemainingAmount       float64    `json:"remaining_amount"`
+	NextInstallmentDate   *time.Time `json:"next_installment_date,omitempty"`
+
+	key    string
+	linked map[int]bool
+}
+
+const (
+	InstallmentPaid    = "paid"
+	InstallmentPending = "pending"
+)
+
+type InstallmentEntry struct {
+	Number    int       `json:"number"`
+	Date      time.Time `json:"date"`
+	Amount    float64   `json:"amount"`
+	Status    string    `json:"status"`
+	ExpenseID string    `json:"expense_id,omitempty"`
+}
+
+type InstallmentPlanDetail struct {
+	InstallmentPlan
+	Installments []InstallmentEntry `json:"installments"`
+}
+
+type UpcomingInstallment struct {
+	PlanID            string    `json:"plan_id"`
+	Description       string    `json:"description"`
+	Category          string    `json:"category"`
+	Number            int       `json:"number"`
+	TotalInstallments int       `json:"total_installments"`
+	Amount            float64   `json:"amount"`
+	Date              time.Time `json:"date"`
+}
+
+type InstallmentMonth struct {
+	Month        string                `json:"month"`
+	Total        float64               `json:"total"`
+	Installments []UpcomingInstallment `json:"installments"`
+}
+
+const (
+	ForeignGroupByMonth = "month"
+	ForeignGroupByTrip  = "trip"
+)
+
+type ForeignSpendingQuery struct {
+	StartDate *time.Time
+	EndDate   *time.Time
+	GroupBy   string
+}
+
+// ForeignSpendingGroup is the spending of a month or a trip in foreign
+// currency. TotalCost adds the IOF charged on the purchases to what they
+// cost in reais; EffectiveRates is what each unit of a currency really cost.
+type ForeignSpendingGroup struct {
+	Period         string             `json:"period"`
+	StartDate      time.Time          `json:"start_date"`
+	EndDate        time.Time          `json:"end_date"`
+	Purchases      int                `json:"purchases"`
+	Currencies     map[string]float64 `json:"currencies"`
+	PurchaseAmount float64            `json:"purchase_amount"`
+	IOFAmount      float64            `json:"iof_amount"`
+	TotalCost      float64            `json:"total_cost"`
+	EffectiveRates map[string]float64 `json:"effective_rates"`
+	Expenses       []*Expense         `json:"expenses"`
+}
+
+type ForeignSpendingReport struct {
+	GroupBy        string                 `json:"group_by"`
+	Groups         []ForeignSpendingGroup `json:"groups"`
+	PurchaseAmount float64                `json:"purchase_amount"`
+	IOFAmount      float64                `json:"iof_amount"`
+	TotalCost      float64                `json:"total_cost"`
+}
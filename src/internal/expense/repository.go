@@ -3,6 +3,7 @@ package expense
 import (
 	"errors"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -14,16 +15,30 @@ type Repository interface {
 	Update(expense *Expense) error
 	Delete(id, userID string) error
 	GetStats(userID string, startDate, endDate *time.Time) (*ExpenseStats, error)
+	UpdateCategories(userID string, updates []CategoryUpdate) (int, error)
+	CreateImportBatch(batch *ImportBatch, expenses []*Expense, plans []*InstallmentPlan) error
+	AppendImportBatch(batch *ImportBatch, expenses []*Expense, plans []*InstallmentPlan) error
+	FindExistingFingerprints(userID string, fingerprints []string) (map[string]bool, error)
+	FindImportBatches(userID string) ([]*ImportBatch, error)
+	FindImportBatchByID(id, userID string) (*ImportBatch, error)
+	RollbackImportBatch(id, userID string) (int, error)
+	FindInstallmentPlansByKey(userID, key string) ([]*InstallmentPlan, error)
+	FindInstallmentPlans(userID string) ([]*InstallmentPlan, error)
+	FindInstallmentPlanByID(id, userID string) (*InstallmentPlan, error)
 }
 
 type memoryRepository struct {
 	expenses map[string]*Expense
+	batches  map[string]*ImportBatch
+	plans    map[string]*InstallmentPlan
 	mu       sync.RWMutex
 }
 
 func NewRepository() Repository {
 	return &memoryRepository{
 		expenses: make(map[string]*Expense),
+		batches:  make(map[string]*ImportBatch),
+		plans:    make(map[string]*InstallmentPlan),
 	}
 }
 
@@ -116,6 +131,7 @@ func (r *memoryRepository) GetStats(userID string, startDate, endDate *time.Time
 	defer r.mu.RUnlock()
 
 	stats := &ExpenseStats{}
+	byCategory := make(map[string]*CategoryStats)
 
 	for _, expense := range r.expenses {
 		if expense.UserID != userID {
@@ -132,20 +148,263 @@ func (r *memoryRepository) GetStats(userID string, startDate, endDate *time.Time
 
 		stats.Count++
 
+		category, ok := byCategory[expense.Category]
+		if !ok {
+			category = &CategoryStats{Category: expense.Category}
+			byCategory[expense.Category] = category
+		}
+		category.Count++
+
 		if expense.Type == "income" {
 			stats.TotalIncome += expense.Amount
 			stats.IncomeCount++
+			category.TotalIncome += expense.Amount
 		} else {
 			stats.TotalExpense += expense.Amount
 			stats.ExpenseCount++
+			category.TotalExpense += expense.Amount
 		}
 	}
 
 	stats.Balance = stats.TotalIncome - stats.TotalExpense
 
+	stats.ByCategory = make([]CategoryStats, 0, len(byCategory))
+	for _, category := range byCategory {
+		stats.ByCategory = append(stats.ByCategory, *category)
+	}
+	sortCategoryStats(stats.ByCategory)
+
 	return stats, nil
 }
 
+func (r *memoryRepository) UpdateCategories(userID string, updates []CategoryUpdate) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	updated := 0
+	for _, update := range updates {
+		expense, exists := r.expenses[update.ID]
+		if !exists || expense.UserID != userID {
+			continue
+		}
+		expense.Category = update.Category
+		expense.CategorySource = update.Source
+		expense.UpdatedAt = now
+		updated++
+	}
+	return updated, nil
+}
+
+func (r *memoryRepository) CreateImportBatch(batch *ImportBatch, expenses []*Expense, plans []*InstallmentPlan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.batches[batch.ID] = batch
+	r.savePlans(plans)
+	for _, expense := range expenses {
+		r.expenses[expense.ID] = expense
+	}
+	return nil
+}
+
+func (r *memoryRepository) AppendImportBatch(batch *ImportBatch, expenses []*Expense, plans []*InstallmentPlan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.batches[batch.ID]
+	if !exists || existing.UserID != batch.UserID {
+		return errors.New("import batch not found")
+	}
+
+	if existing.Status == ImportBatchRolledBack {
+		return errors.New("import batch already rolled back")
+	}
+
+	copied := *batch
+	r.batches[batch.ID] = &copied
+	r.savePlans(plans)
+	for _, expense := range expenses {
+		r.expenses[expense.ID] = expense
+	}
+	return nil
+}
+
+func (r *memoryRepository) FindExistingFingerprints(userID string, fingerprints []string) (map[string]bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(fingerprints))
+	for _, fingerprint := range fingerprints {
+		wanted[fingerprint] = true
+	}
+
+	existing := make(map[string]bool)
+	for _, expense := range r.expenses {
+		if expense.UserID == userID && expense.Fingerprint != "" && wanted[expense.Fingerprint] {
+			existing[expense.Fingerprint] = true
+		}
+	}
+
+	return existing, nil
+}
+
+func (r *memoryRepository) FindImportBatches(userID string) ([]*ImportBatch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []*ImportBatch{}
+	for _, batch := range r.batches {
+		if batch.UserID == userID {
+			result = append(result, batch)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (r *memoryRepository) FindImportBatchByID(id, userID string) (*ImportBatch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	batch, exists := r.batches[id]
+	if !exists || batch.UserID != userID {
+		return nil, errors.New("import batch not found")
+	}
+
+	return batch, nil
+}
+
+func (r *memoryRepository) RollbackImportBatch(id, userID string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	batch, exists := r.batches[id]
+	if !exists || batch.UserID != userID {
+		return 0, errors.New("import batch not found")
+	}
+
+	if batch.Status == ImportBatchRolledBack {
+		return 0, errors.New("import batch already rolled back")
+	}
+
+	if batch.Status == ImportBatchRunning {
+		return 0, errors.New("import batch is still running")
+	}
+
+	deleted := 0
+	used := make(map[string]bool)
+	for expenseID, expense := range r.expenses {
+		if expense.BatchID == id && expense.UserID == userID {
+			if expense.InstallmentPlanID != "" {
+				used[expense.InstallmentPlanID] = true
+			}
+			delete(r.expenses, expenseID)
+			deleted++
+		}
+	}
+
+	for _, expense := range r.expenses {
+		delete(used, expense.InstallmentPlanID)
+	}
+	for planID := range used {
+		if plan, exists := r.plans[planID]; exists && plan.UserID == userID {
+			delete(r.plans, planID)
+		}
+	}
+
+	now := time.Now()
+	batch.Status = ImportBatchRolledBack
+	batch.RolledBackAt = &now
+
+	return deleted, nil
+}
+
+func (r *memoryRepository) savePlans(plans []*InstallmentPlan) {
+	for _, plan := range plans {
+		copied := *plan
+		copied.linked = nil
+		r.plans[plan.ID] = &copied
+	}
+}
+
+func (r *memoryRepository) FindInstallmentPlansByKey(userID, key string) ([]*InstallmentPlan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*InstallmentPlan
+	for _, plan := range r.plans {
+		if plan.UserID != userID || plan.key != key {
+			continue
+		}
+
+		copied := r.installmentProgress(plan)
+		copied.linked = make(map[int]bool)
+		for _, expense := range r.expenses {
+			if expense.InstallmentPlanID == plan.ID {
+				copied.linked[expense.InstallmentNumber] = true
+			}
+		}
+		result = append(result, copied)
+	}
+
+	return result, nil
+}
+
+func (r *memoryRepository) FindInstallmentPlans(userID string) ([]*InstallmentPlan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []*InstallmentPlan{}
+	for _, plan := range r.plans {
+		if plan.UserID == userID {
+			result = append(result, r.installmentProgress(plan))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].FirstInstallmentDate.After(result[j].FirstInstallmentDate)
+	})
+
+	return result, nil
+}
+
+func (r *memoryRepository) FindInstallmentPlanByID(id, userID string) (*InstallmentPlan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	plan, exists := r.plans[id]
+	if !exists || plan.UserID != userID {
+		return nil, errors.New("installment plan not found")
+	}
+
+	return r.installmentProgress(plan), nil
+}
+
+// installmentProgress returns a copy of the plan with the installments read
+// from its expenses. The caller must hold the lock.
+func (r *memoryRepository) installmentProgress(plan *InstallmentPlan) *InstallmentPlan {
+	copied := *plan
+	copied.ImportedInstallments = 0
+	copied.PaidInstallments = 0
+
+	for _, expense := range r.expenses {
+		if expense.InstallmentPlanID != plan.ID {
+			continue
+		}
+		copied.ImportedInstallments++
+		if expense.InstallmentNumber > copied.PaidInstallments {
+			copied.PaidInstallments = expense.InstallmentNumber
+		}
+	}
+
+	return &copied
+}
+
 func (r *memoryRepository) matchesQuery(expense *Expense, query ListExpensesQuery) bool {
 	if query.StartDate != nil && expense.Date.Before(*query.StartDate) {
 		return false
@@ -163,6 +422,22 @@ func (r *memoryRepository) matchesQuery(expense *Expense, query ListExpensesQuer
 		return false
 	}
 
+	if query.BatchID != "" && expense.BatchID != query.BatchID {
+		return false
+	}
+
+	if query.InstallmentPlanID != "" && expense.InstallmentPlanID != query.InstallmentPlanID {
+		return false
+	}
+
+	if query.Account != "" && !strings.EqualFold(expense.Account, query.Account) {
+		return false
+	}
+
+	if query.Tag != "" && !hasTag(expense.Tags, query.Tag) {
+		return false
+	}
+
 	if query.MinAmount != nil && expense.Amount < *query.MinAmount {
 		return false
 	}
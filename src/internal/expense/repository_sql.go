@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 )
@@ -18,11 +19,25 @@ func NewSQLRepository(db *sql.DB) Repository {
 	}
 }
 
+const expenseColumns = `id, user_id, date, description, category, amount, type, batch_id, fingerprint, installment_plan_id, installment_number, original_currency, original_amount, exchange_rate, taxed_expense_id, account, tags, category_source, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
 func (r *sqlRepository) Create(expense *Expense) error {
-	query := `INSERT INTO expenses (id, user_id, date, description, category, amount, type, created_at, updated_at) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	return insertExpense(r.db, expense)
+}
 
-	_, err := r.db.Exec(
+func insertExpense(db execer, expense *Expense) error {
+	query := `INSERT INTO expenses (` + expenseColumns + `) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := db.Exec(
 		query,
 		expense.ID,
 		expense.UserID,
@@ -31,6 +46,17 @@ func (r *sqlRepository) Create(expense *Expense) error {
 		expense.Category,
 		expense.Amount,
 		expense.Type,
+		nullString(expense.BatchID),
+		nullString(expense.Fingerprint),
+		nullString(expense.InstallmentPlanID),
+		nullInt(expense.InstallmentNumber),
+		nullString(expense.OriginalCurrency),
+		nullFloat(expense.OriginalAmount),
+		nullFloat(expense.ExchangeRate),
+		nullString(expense.TaxedExpenseID),
+		nullString(expense.Account),
+		nullString(joinTags(expense.Tags)),
+		expense.CategorySource,
 		expense.CreatedAt,
 		expense.UpdatedAt,
 	)
@@ -38,12 +64,60 @@ func (r *sqlRepository) Create(expense *Expense) error {
 	return err
 }
 
-func (r *sqlRepository) FindByID(id, userID string) (*Expense, error) {
-	query := `SELECT id, user_id, date, description, category, amount, type, created_at, updated_at 
-		FROM expenses WHERE id = ? AND user_id = ?`
+// expenseInsertChunk keeps each multi-row INSERT well below SQLite's limit
+// of 999 bound parameters.
+const expenseInsertChunk = 40
+
+func insertExpenses(db execer, expenses []*Expense) error {
+	for start := 0; start < len(expenses); start += expenseInsertChunk {
+		end := start + expenseInsertChunk
+		if end > len(expenses) {
+			end = len(expenses)
+		}
+		chunk := expenses[start:end]
+
+		placeholders := strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?), ", len(chunk)), ", ")
+		args := make([]interface{}, 0, len(chunk)*20)
+		for _, expense := range chunk {
+			args = append(args,
+				expense.ID,
+				expense.UserID,
+				expense.Date,
+				expense.Description,
+				expense.Category,
+				expense.Amount,
+				expense.Type,
+				nullString(expense.BatchID),
+				nullString(expense.Fingerprint),
+				nullString(expense.InstallmentPlanID),
+				nullInt(expense.InstallmentNumber),
+				nullString(expense.OriginalCurrency),
+				nullFloat(expense.OriginalAmount),
+				nullFloat(expense.ExchangeRate),
+				nullString(expense.TaxedExpenseID),
+				nullString(expense.Account),
+				nullString(joinTags(expense.Tags)),
+				expense.CategorySource,
+				expense.CreatedAt,
+				expense.UpdatedAt,
+			)
+		}
+
+		if _, err := db.Exec(`INSERT INTO expenses (`+expenseColumns+`) VALUES `+placeholders, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
+func scanExpense(row rowScanner) (*Expense, error) {
 	expense := &Expense{}
-	err := r.db.QueryRow(query, id, userID).Scan(
+	var batchID, fingerprint, installmentPlanID, originalCurrency, taxedExpenseID, account, tags, categorySource sql.NullString
+	var installmentNumber sql.NullInt64
+	var originalAmount, exchangeRate sql.NullFloat64
+
+	err := row.Scan(
 		&expense.ID,
 		&expense.UserID,
 		&expense.Date,
@@ -51,10 +125,55 @@ func (r *sqlRepository) FindByID(id, userID string) (*Expense, error) {
 		&expense.Category,
 		&expense.Amount,
 		&expense.Type,
+		&batchID,
+		&fingerprint,
+		&installmentPlanID,
+		&installmentNumber,
+		&originalCurrency,
+		&originalAmount,
+		&exchangeRate,
+		&taxedExpenseID,
+		&account,
+		&tags,
+		&categorySource,
 		&expense.CreatedAt,
 		&expense.UpdatedAt,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	expense.BatchID = batchID.String
+	expense.Fingerprint = fingerprint.String
+	expense.InstallmentPlanID = installmentPlanID.String
+	expense.InstallmentNumber = int(installmentNumber.Int64)
+	expense.OriginalCurrency = originalCurrency.String
+	expense.OriginalAmount = originalAmount.Float64
+	expense.ExchangeRate = exchangeRate.Float64
+	expense.TaxedExpenseID = taxedExpenseID.String
+	expense.Account = account.String
+	expense.Tags = splitTags(tags.String)
+	expense.CategorySource = categorySource.String
+	return expense, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func nullInt(value int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(value), Valid: value != 0}
+}
+
+func nullFloat(value float64) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: value, Valid: value != 0}
+}
 
+func (r *sqlRepository) FindByID(id, userID string) (*Expense, error) {
+	query := `SELECT ` + expenseColumns + ` 
+		FROM expenses WHERE id = ? AND user_id = ?`
+
+	expense, err := scanExpense(r.db.QueryRow(query, id, userID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.New("expense not found")
@@ -66,7 +185,7 @@ func (r *sqlRepository) FindByID(id, userID string) (*Expense, error) {
 }
 
 func (r *sqlRepository) FindByUserID(userID string, query ListExpensesQuery) ([]*Expense, error) {
-	queryStr := `SELECT id, user_id, date, description, category, amount, type, created_at, updated_at 
+	queryStr := `SELECT ` + expenseColumns + ` 
 		FROM expenses WHERE user_id = ?`
 
 	args := []interface{}{userID}
@@ -107,6 +226,26 @@ func (r *sqlRepository) FindByUserID(userID string, query ListExpensesQuery) ([]
 		args = append(args, "%"+query.Description+"%")
 	}
 
+	if query.BatchID != "" {
+		conditions = append(conditions, "batch_id = ?")
+		args = append(args, query.BatchID)
+	}
+
+	if query.InstallmentPlanID != "" {
+		conditions = append(conditions, "installment_plan_id = ?")
+		args = append(args, query.InstallmentPlanID)
+	}
+
+	if query.Account != "" {
+		conditions = append(conditions, "account = ? COLLATE NOCASE")
+		args = append(args, query.Account)
+	}
+
+	if query.Tag != "" {
+		conditions = append(conditions, "(',' || tags || ',') LIKE ?")
+		args = append(args, "%,"+query.Tag+",%")
+	}
+
 	if len(conditions) > 0 {
 		queryStr += " AND " + strings.Join(conditions, " AND ")
 	}
@@ -121,18 +260,7 @@ func (r *sqlRepository) FindByUserID(userID string, query ListExpensesQuery) ([]
 
 	expenses := []*Expense{}
 	for rows.Next() {
-		expense := &Expense{}
-		err := rows.Scan(
-			&expense.ID,
-			&expense.UserID,
-			&expense.Date,
-			&expense.Description,
-			&expense.Category,
-			&expense.Amount,
-			&expense.Type,
-			&expense.CreatedAt,
-			&expense.UpdatedAt,
-		)
+		expense, err := scanExpense(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -147,7 +275,7 @@ func (r *sqlRepository) FindByUserID(userID string, query ListExpensesQuery) ([]
 }
 
 func (r *sqlRepository) Update(expense *Expense) error {
-	query := `UPDATE expenses SET date = ?, description = ?, category = ?, amount = ?, type = ?, updated_at = ? 
+	query := `UPDATE expenses SET date = ?, description = ?, category = ?, category_source = ?, amount = ?, type = ?, updated_at = ? 
 		WHERE id = ? AND user_id = ?`
 
 	result, err := r.db.Exec(
@@ -155,6 +283,7 @@ func (r *sqlRepository) Update(expense *Expense) error {
 		expense.Date,
 		expense.Description,
 		expense.Category,
+		expense.CategorySource,
 		expense.Amount,
 		expense.Type,
 		expense.UpdatedAt,
@@ -208,19 +337,20 @@ func (r *sqlRepository) GetStats(userID string, startDate, endDate *time.Time) (
 		FROM expenses WHERE user_id = ?`
 
 	args := []interface{}{userID}
+	periodFilter := ""
 
 	if startDate != nil {
-		query += " AND date >= ?"
+		periodFilter += " AND date >= ?"
 		args = append(args, startDate)
 	}
 
 	if endDate != nil {
-		query += " AND date <= ?"
+		periodFilter += " AND date <= ?"
 		args = append(args, endDate)
 	}
 
 	stats := &ExpenseStats{}
-	err := r.db.QueryRow(query, args...).Scan(
+	err := r.db.QueryRow(query+periodFilter, args...).Scan(
 		&stats.TotalIncome,
 		&stats.TotalExpense,
 		&stats.Count,
@@ -234,7 +364,499 @@ func (r *sqlRepository) GetStats(userID string, startDate, endDate *time.Time) (
 
 	stats.Balance = stats.TotalIncome - stats.TotalExpense
 
+	categoryQuery := `SELECT COALESCE(category, ''),
+		COALESCE(SUM(CASE WHEN type = 'income' THEN amount ELSE 0 END), 0),
+		COALESCE(SUM(CASE WHEN type = 'expense' THEN amount ELSE 0 END), 0),
+		COUNT(*)
+		FROM expenses WHERE user_id = ?` + periodFilter + ` GROUP BY COALESCE(category, '')`
+
+	rows, err := r.db.Query(categoryQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats.ByCategory = []CategoryStats{}
+	for rows.Next() {
+		var category CategoryStats
+		if err := rows.Scan(&category.Category, &category.TotalIncome, &category.TotalExpense, &category.Count); err != nil {
+			return nil, err
+		}
+		stats.ByCategory = append(stats.ByCategory, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sortCategoryStats(stats.ByCategory)
+
 	return stats, nil
 }
 
+// UpdateCategories sets the categories in a single transaction. Expenses
+// deleted in the meantime are skipped; the result is how many changed.
+func (r *sqlRepository) UpdateCategories(userID string, updates []CategoryUpdate) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	updated := 0
+	for _, update := range updates {
+		result, err := tx.Exec(`UPDATE expenses SET category = ?, category_source = ?, updated_at = ? WHERE id = ? AND user_id = ?`,
+			update.Category, update.Source, now, update.ID, userID)
+		if err != nil {
+			return 0, err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		updated += int(rowsAffected)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return updated, nil
+}
+
+func (r *sqlRepository) CreateImportBatch(batch *ImportBatch, expenses []*Expense, plans []*InstallmentPlan) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO import_batches (id, user_id, filename, source, total_rows, saved_rows, rejected_rows, skipped_duplicates, status, created_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err = tx.Exec(
+		query,
+		batch.ID,
+		batch.UserID,
+		batch.Filename,
+		batch.Source,
+		batch.TotalRows,
+		batch.SavedRows,
+		batch.RejectedRows,
+		batch.SkippedDuplicates,
+		batch.Status,
+		batch.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := insertInstallmentPlans(tx, plans); err != nil {
+		return err
+	}
+
+	if err := insertExpenses(tx, expenses); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *sqlRepository) AppendImportBatch(batch *ImportBatch, expenses []*Expense, plans []*InstallmentPlan) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRow(`SELECT status FROM import_batches WHERE id = ? AND user_id = ?`, batch.ID, batch.UserID).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("import batch not found")
+		}
+		return err
+	}
+
+	if status == ImportBatchRolledBack {
+		return errors.New("import batch already rolled back")
+	}
+
+	if err := insertInstallmentPlans(tx, plans); err != nil {
+		return err
+	}
+
+	if err := insertExpenses(tx, expenses); err != nil {
+		return err
+	}
+
+	query := `UPDATE import_batches 
+		SET total_rows = ?, saved_rows = ?, rejected_rows = ?, skipped_duplicates = ?, status = ? 
+		WHERE id = ? AND user_id = ?`
+
+	result, err := tx.Exec(
+		query,
+		batch.TotalRows,
+		batch.SavedRows,
+		batch.RejectedRows,
+		batch.SkippedDuplicates,
+		batch.Status,
+		batch.ID,
+		batch.UserID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return errors.New("import batch not found")
+	}
+
+	return tx.Commit()
+}
+
+func (r *sqlRepository) FindExistingFingerprints(userID string, fingerprints []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+
+	const chunkSize = 500
+	for start := 0; start < len(fingerprints); start += chunkSize {
+		end := start + chunkSize
+		if end > len(fingerprints) {
+			end = len(fingerprints)
+		}
+		chunk := fingerprints[start:end]
+
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(chunk)), ", ")
+		args := []interface{}{userID}
+		for _, fingerprint := range chunk {
+			args = append(args, fingerprint)
+		}
+
+		rows, err := r.db.Query(`SELECT fingerprint FROM expenses WHERE user_id = ? AND fingerprint IN (`+placeholders+`)`, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var fingerprint string
+			if err := rows.Scan(&fingerprint); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			existing[fingerprint] = true
+		}
+
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return existing, nil
+}
+
+const importBatchColumns = `id, user_id, filename, source, total_rows, saved_rows, rejected_rows, skipped_duplicates, status, created_at, rolled_back_at`
+
+func scanImportBatch(row rowScanner) (*ImportBatch, error) {
+	batch := &ImportBatch{}
+	var filename sql.NullString
+	var rolledBackAt sql.NullTime
+
+	err := row.Scan(
+		&batch.ID,
+		&batch.UserID,
+		&filename,
+		&batch.Source,
+		&batch.TotalRows,
+		&batch.SavedRows,
+		&batch.RejectedRows,
+		&batch.SkippedDuplicates,
+		&batch.Status,
+		&batch.CreatedAt,
+		&rolledBackAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	batch.Filename = filename.String
+	if rolledBackAt.Valid {
+		batch.RolledBackAt = &rolledBackAt.Time
+	}
+
+	return batch, nil
+}
+
+func (r *sqlRepository) FindImportBatches(userID string) ([]*ImportBatch, error) {
+	query := `SELECT ` + importBatchColumns + ` 
+		FROM import_batches WHERE user_id = ? ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batches := []*ImportBatch{}
+	for rows.Next() {
+		batch, err := scanImportBatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return batches, nil
+}
+
+func (r *sqlRepository) FindImportBatchByID(id, userID string) (*ImportBatch, error) {
+	query := `SELECT ` + importBatchColumns + ` 
+		FROM import_batches WHERE id = ? AND user_id = ?`
+
+	batch, err := scanImportBatch(r.db.QueryRow(query, id, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("import batch not found")
+		}
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+func (r *sqlRepository) RollbackImportBatch(id, userID string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRow(`SELECT status FROM import_batches WHERE id = ? AND user_id = ?`, id, userID).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.New("import batch not found")
+		}
+		return 0, err
+	}
+
+	if status == ImportBatchRolledBack {
+		return 0, errors.New("import batch already rolled back")
+	}
+
+	if status == ImportBatchRunning {
+		return 0, errors.New("import batch is still running")
+	}
+
+	planIDs, err := batchInstallmentPlanIDs(tx, id, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := tx.Exec(`DELETE FROM expenses WHERE batch_id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	// Plans the batch used are removed only when no other expense is left
+	// in them; a plan an earlier import started keeps its installments.
+	for _, planID := range planIDs {
+		_, err = tx.Exec(
+			`DELETE FROM installment_plans 
+			WHERE id = ? AND user_id = ? AND NOT EXISTS (SELECT 1 FROM expenses WHERE expenses.installment_plan_id = installment_plans.id)`,
+			planID,
+			userID,
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	_, err = tx.Exec(
+		`UPDATE import_batches SET status = ?, rolled_back_at = ? WHERE id = ? AND user_id = ?`,
+		ImportBatchRolledBack,
+		time.Now(),
+		id,
+		userID,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
+func batchInstallmentPlanIDs(tx *sql.Tx, batchID, userID string) ([]string, error) {
+	rows, err := tx.Query(
+		`SELECT DISTINCT installment_plan_id FROM expenses 
+		WHERE batch_id = ? AND user_id = ? AND installment_plan_id IS NOT NULL`,
+		batchID,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var planIDs []string
+	for rows.Next() {
+		var planID string
+		if err := rows.Scan(&planID); err != nil {
+			return nil, err
+		}
+		planIDs = append(planIDs, planID)
+	}
+
+	return planIDs, rows.Err()
+}
+
+func insertInstallmentPlans(db execer, plans []*InstallmentPlan) error {
+	query := `INSERT INTO installment_plans (id, user_id, description, category, installment_amount, total_installments, first_installment_date, plan_key, created_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	for _, plan := range plans {
+		_, err := db.Exec(
+			query,
+			plan.ID,
+			plan.UserID,
+			plan.Description,
+			plan.Category,
+			plan.InstallmentAmount,
+			plan.TotalInstallments,
+			plan.FirstInstallmentDate,
+			plan.key,
+			plan.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// installmentPlanQuery reads the plans with the count and the highest number
+// of the installments already imported.
+const installmentPlanQuery = `SELECT p.id, p.user_id, p.description, p.category, p.installment_amount, p.total_installments, p.first_installment_date, p.plan_key, p.created_at, 
+		COUNT(e.id), COALESCE(MAX(e.installment_number), 0) 
+		FROM installment_plans p LEFT JOIN expenses e ON e.installment_plan_id = p.id`
+
+func scanInstallmentPlan(row rowScanner) (*InstallmentPlan, error) {
+	plan := &InstallmentPlan{}
+
+	err := row.Scan(
+		&plan.ID,
+		&plan.UserID,
+		&plan.Description,
+		&plan.Category,
+		&plan.InstallmentAmount,
+		&plan.TotalInstallments,
+		&plan.FirstInstallmentDate,
+		&plan.key,
+		&plan.CreatedAt,
+		&plan.ImportedInstallments,
+		&plan.PaidInstallments,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.TotalAmount = math.Round(plan.InstallmentAmount*float64(plan.TotalInstallments)*100) / 100
+	return plan, nil
+}
+
+func (r *sqlRepository) queryInstallmentPlans(query string, args ...interface{}) ([]*InstallmentPlan, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	plans := []*InstallmentPlan{}
+	for rows.Next() {
+		plan, err := scanInstallmentPlan(rows)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return plans, nil
+}
+
+func (r *sqlRepository) FindInstallmentPlansByKey(userID, key string) ([]*InstallmentPlan, error) {
+	plans, err := r.queryInstallmentPlans(installmentPlanQuery+` 
+		WHERE p.user_id = ? AND p.plan_key = ? GROUP BY p.id`, userID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, plan := range plans {
+		plan.linked = make(map[int]bool)
+
+		rows, err := r.db.Query(`SELECT installment_number FROM expenses WHERE installment_plan_id = ?`, plan.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var number int
+			if err := rows.Scan(&number); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			plan.linked[number] = true
+		}
+
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return plans, nil
+}
+
+func (r *sqlRepository) FindInstallmentPlans(userID string) ([]*InstallmentPlan, error) {
+	return r.queryInstallmentPlans(installmentPlanQuery+` 
+		WHERE p.user_id = ? GROUP BY p.id ORDER BY p.first_installment_date DESC`, userID)
+}
+
+func (r *sqlRepository) FindInstallmentPlanByID(id, userID string) (*InstallmentPlan, error) {
+	plan, err := scanInstallmentPlan(r.db.QueryRow(installmentPlanQuery+` 
+		WHERE p.id = ? AND p.user_id = ? GROUP BY p.id`, id, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("installment plan not found")
+		}
+		return nil, err
+	}
+
+	return plan, nil
+}
+
 var _ = fmt.Sprint("")
@@ -8,9 +8,16 @@ func RegisterRoutes(rg *gin.RouterGroup, handler *Handler) {
 		expenses.POST("", handler.Create)
 		expenses.GET("", handler.List)
 		expenses.GET("/stats", handler.GetStats)
+		expenses.GET("/foreign", handler.ForeignSpending)
+		expenses.GET("/installments", handler.ListInstallmentPlans)
+		expenses.GET("/installments/upcoming", handler.UpcomingInstallments)
+		expenses.GET("/installments/:id", handler.GetInstallmentPlan)
 		expenses.GET("/:id", handler.GetByID)
 		expenses.PUT("/:id", handler.Update)
 		expenses.DELETE("/:id", handler.Delete)
 		expenses.POST("/import", handler.ImportTransactions)
+		expenses.GET("/imports", handler.ListImportBatches)
+		expenses.GET("/imports/:id", handler.GetImportBatch)
+		expenses.POST("/imports/:id/rollback", handler.RollbackImportBatch)
 	}
 }
@@ -1,6 +1,7 @@
 package expense
 
 import (
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,39 +13,72 @@ type Service interface {
 	List(userID string, query ListExpensesQuery) ([]*Expense, error)
 	Update(id, userID string, req UpdateExpenseRequest) (*Expense, error)
 	Delete(id, userID string) error
-	GetStats(userID string, startDate, endDate *time.Time) (*ExpenseStats, error)
-	ImportTransactions(userID string, transactions []Transaction) (int, error)
+	GetStats(userID string, startDate, endDate *time.Time, rollup bool) (*ExpenseStats, error)
+	ImportTransactions(userID string, source ImportSource, transactions []Transaction) (*ImportResult, error)
+	BeginImport(userID string, source ImportSource) (*ImportWriter, error)
+	ListImportBatches(userID string) ([]*ImportBatch, error)
+	GetImportBatch(id, userID string) (*ImportBatchDetail, error)
+	RollbackImportBatch(id, userID string) (int, error)
+	ListInstallmentPlans(userID string) ([]*InstallmentPlan, error)
+	GetInstallmentPlan(id, userID string) (*InstallmentPlanDetail, error)
+	UpcomingInstallments(userID string, months int) ([]InstallmentMonth, error)
+	ForeignSpending(userID string, query ForeignSpendingQuery) (*ForeignSpendingReport, error)
+}
+
+// ChangeListener is told about every expense created, updated or deleted
+// through the service, imports and rollbacks included. Before is nil for a
+// new expense and after is nil for a deleted one.
+type ChangeListener interface {
+	ExpenseChanged(before, after *Expense)
+}
+
+// CategoryRoots maps a category to the top-level category it belongs to,
+// so stats can be rolled up. Categories with no parent map to themselves.
+type CategoryRoots interface {
+	Roots(userID string) (func(category string) string, error)
 }
 
 type service struct {
-	repo Repository
+	repo      Repository
+	roots     CategoryRoots
+	listeners []ChangeListener
 }
 
-func NewService(repo Repository) Service {
+func NewService(repo Repository, roots CategoryRoots, listeners ...ChangeListener) Service {
 	return &service{
-		repo: repo,
+		repo:      repo,
+		roots:     roots,
+		listeners: listeners,
 	}
 }
 
 func (s *service) Create(userID string, req CreateExpenseRequest) (*Expense, error) {
 	now := time.Now()
 
+	categorySource := req.CategorySource
+	if categorySource == "" && req.Category != "" {
+		categorySource = CategorySourceManual
+	}
+
 	expense := &Expense{
-		ID:          uuid.New().String(),
-		UserID:      userID,
-		Date:        req.Date,
-		Description: req.Description,
-		Category:    req.Category,
-		Amount:      req.Amount,
-		Type:        req.Type,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:             uuid.New().String(),
+		UserID:         userID,
+		Date:           req.Date,
+		Description:    req.Description,
+		Category:       req.Category,
+		Amount:         req.Amount,
+		Type:           req.Type,
+		CategorySource: categorySource,
+		CreatedAt:      now,
+		UpdatedAt:      now,
 	}
 
 	if err := s.repo.Create(expense); err != nil {
 		return nil, err
 	}
 
+	s.notify(nil, expense)
+
 	return expense, nil
 }
 
@@ -61,6 +95,7 @@ func (s *service) Update(id, userID string, req UpdateExpenseRequest) (*Expense,
 	if err != nil {
 		return nil, err
 	}
+	before := *expense
 
 	if req.Date != nil {
 		expense.Date = *req.Date
@@ -72,6 +107,7 @@ func (s *service) Update(id, userID string, req UpdateExpenseRequest) (*Expense,
 
 	if req.Category != nil {
 		expense.Category = *req.Category
+		expense.CategorySource = CategorySourceManual
 	}
 
 	if req.Amount != nil {
@@ -82,53 +118,184 @@ func (s *service) Update(id, userID string, req UpdateExpenseRequest) (*Expense,
 		expense.Type = *req.Type
 	}
 
+	expense.UpdatedAt = time.Now()
+
 	if err := s.repo.Update(expense); err != nil {
 		return nil, err
 	}
 
+	s.notify(&before, expense)
+
 	return expense, nil
 }
 
 func (s *service) Delete(id, userID string) error {
-	return s.repo.Delete(id, userID)
+	if len(s.listeners) == 0 {
+		return s.repo.Delete(id, userID)
+	}
+
+	expense, err := s.repo.FindByID(id, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(id, userID); err != nil {
+		return err
+	}
+
+	s.notify(expense, nil)
+
+	return nil
 }
 
-func (s *service) GetStats(userID string, startDate, endDate *time.Time) (*ExpenseStats, error) {
-	return s.repo.GetStats(userID, startDate, endDate)
+func (s *service) notify(before, after *Expense) {
+	for _, listener := range s.listeners {
+		listener.ExpenseChanged(before, after)
+	}
 }
 
-func (s *service) ImportTransactions(userID string, transactions []Transaction) (int, error) {
-	count := 0
+// GetStats totals the period. Rolled up, the categories are added into
+// their top-level category.
+func (s *service) GetStats(userID string, startDate, endDate *time.Time, rollup bool) (*ExpenseStats, error) {
+	stats, err := s.repo.GetStats(userID, startDate, endDate)
+	if err != nil || !rollup || s.roots == nil {
+		return stats, err
+	}
 
-	for _, t := range transactions {
-		expenseType := "expense"
-		if t.Amount > 0 {
-			expenseType = "income"
+	root, err := s.roots.Roots(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byRoot := make(map[string]int)
+	rolledUp := []CategoryStats{}
+	for _, category := range stats.ByCategory {
+		name := root(category.Category)
+		index, ok := byRoot[name]
+		if !ok {
+			index = len(rolledUp)
+			byRoot[name] = index
+			rolledUp = append(rolledUp, CategoryStats{Category: name})
 		}
+		rolledUp[index].TotalIncome += category.TotalIncome
+		rolledUp[index].TotalExpense += category.TotalExpense
+		rolledUp[index].Count += category.Count
+	}
+	sortCategoryStats(rolledUp)
+	stats.ByCategory = rolledUp
+
+	return stats, nil
+}
 
-		amount := t.Amount
-		if amount < 0 {
-			amount = -amount
+// sortCategoryStats puts the categories with the most spending first.
+func sortCategoryStats(categories []CategoryStats) {
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].TotalExpense != categories[j].TotalExpense {
+			return categories[i].TotalExpense > categories[j].TotalExpense
 		}
+		return categories[i].Category < categories[j].Category
+	})
+}
+
+func (s *service) ImportTransactions(userID string, source ImportSource, transactions []Transaction) (*ImportResult, error) {
+	now := time.Now()
+
+	fingerprints := fingerprintTransactions(source.Source, transactions)
+
+	existing, err := s.repo.FindExistingFingerprints(userID, fingerprints)
+	if err != nil {
+		return nil, err
+	}
 
-		expense := &Expense{
-			ID:          uuid.New().String(),
-			UserID:      userID,
-			Date:        t.Date,
-			Description: t.Description,
-			Category:    t.Category,
-			Amount:      amount,
-			Type:        expenseType,
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
+	batch := &ImportBatch{
+		ID:           uuid.New().String(),
+		UserID:       userID,
+		Filename:     source.Filename,
+		Source:       source.Source,
+		TotalRows:    source.TotalRows,
+		RejectedRows: source.RejectedRows,
+		Status:       ImportBatchCompleted,
+		CreatedAt:    now,
+	}
+
+	expenses := make([]*Expense, 0, len(transactions))
+	planner := newInstallmentPlanner(s.repo, userID)
+
+	for i, t := range transactions {
+		if existing[fingerprints[i]] {
+			batch.SkippedDuplicates++
+			continue
+		}
+
+		expense := importedExpense(userID, batch.ID, fingerprints[i], t, now)
+		if err := planner.link(expense, t); err != nil {
+			return nil, err
 		}
+		expenses = append(expenses, expense)
+	}
+
+	if err := linkIOF(s.repo, userID, expenses); err != nil {
+		return nil, err
+	}
+
+	batch.SavedRows = len(expenses)
+	if batch.TotalRows < len(transactions)+batch.RejectedRows {
+		batch.TotalRows = len(transactions) + batch.RejectedRows
+	}
+
+	if err := s.repo.CreateImportBatch(batch, expenses, planner.takeCreated()); err != nil {
+		return nil, err
+	}
+
+	for _, expense := range expenses {
+		s.notify(nil, expense)
+	}
+
+	return &ImportResult{
+		BatchID:           batch.ID,
+		Saved:             len(expenses),
+		SkippedDuplicates: batch.SkippedDuplicates,
+	}, nil
+}
+
+func (s *service) ListImportBatches(userID string) ([]*ImportBatch, error) {
+	return s.repo.FindImportBatches(userID)
+}
 
-		if err := s.repo.Create(expense); err != nil {
-			return count, err
+func (s *service) GetImportBatch(id, userID string) (*ImportBatchDetail, error) {
+	batch, err := s.repo.FindImportBatchByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	expenses, err := s.repo.FindByUserID(userID, ListExpensesQuery{BatchID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImportBatchDetail{
+		ImportBatch: *batch,
+		Expenses:    expenses,
+	}, nil
+}
+
+func (s *service) RollbackImportBatch(id, userID string) (int, error) {
+	var expenses []*Expense
+	if len(s.listeners) > 0 {
+		var err error
+		if expenses, err = s.repo.FindByUserID(userID, ListExpensesQuery{BatchID: id}); err != nil {
+			return 0, err
 		}
+	}
+
+	deleted, err := s.repo.RollbackImportBatch(id, userID)
+	if err != nil {
+		return 0, err
+	}
 
-		count++
+	for _, expense := range expenses {
+		s.notify(expense, nil)
 	}
 
-	return count, nil
+	return deleted, nil
 }
@@ -0,0 +1,57 @@
+package inbox
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// GetAddress godoc
+// @Summary Endereço de e-mail para notificações
+// @Description Retorna o endereço para onde encaminhar os e-mails de compra do banco, criando-o no primeiro acesso. As compras das notificações reconhecidas são salvas assim que o e-mail chega. enabled indica se o servidor SMTP está ativo (SMTP_ADDR)
+// @Tags inbox
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} inbox.Address
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /inbox/address [get]
+func (h *Handler) GetAddress(c *gin.Context) {
+	address, err := h.service.GetAddress(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, address)
+}
+
+// RegenerateAddress godoc
+// @Summary Gera um novo endereço de e-mail
+// @Description Troca o endereço de e-mail do usuário; e-mails enviados ao endereço anterior passam a ser recusados
+// @Tags inbox
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} inbox.Address
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /inbox/address/regenerate [post]
+func (h *Handler) RegenerateAddress(c *gin.Context) {
+	address, err := h.service.RegenerateAddress(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, address)
+}
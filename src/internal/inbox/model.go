@@ -0,0 +1,13 @@
+package inbox
+
+import "time"
+
+// Address is the e-mail address a user forwards bank notifications to. Its
+// local part is a random token, so it cannot be guessed from the user.
+type Address struct {
+	UserID    string    `json:"user_id"`
+	Token     string    `json:"-"`
+	Address   string    `json:"address"`
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,62 @@
+package inbox
+
+import (
+	"errors"
+	"sync"
+)
+
+var ErrAddressNotFound = errors.New("endereço de e-mail não encontrado")
+
+type Repository interface {
+	Save(address *Address) error
+	FindByUserID(userID string) (*Address, error)
+	FindByToken(token string) (*Address, error)
+}
+
+type memoryRepository struct {
+	addresses map[string]*Address
+	mu        sync.RWMutex
+}
+
+func NewRepository() Repository {
+	return &memoryRepository{
+		addresses: make(map[string]*Address),
+	}
+}
+
+// Save stores the user's address, replacing the previous one.
+func (r *memoryRepository) Save(address *Address) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *address
+	r.addresses[address.UserID] = &stored
+	return nil
+}
+
+func (r *memoryRepository) FindByUserID(userID string) (*Address, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	address, ok := r.addresses[userID]
+	if !ok {
+		return nil, ErrAddressNotFound
+	}
+
+	copied := *address
+	return &copied, nil
+}
+
+func (r *memoryRepository) FindByToken(token string) (*Address, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, address := range r.addresses {
+		if address.Token == token {
+			copied := *address
+			return &copied, nil
+		}
+	}
+
+	return nil, ErrAddressNotFound
+}
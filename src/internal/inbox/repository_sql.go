@@ -0,0 +1,46 @@
+package inbox
+
+import (
+	"database/sql"
+)
+
+type sqlRepository struct {
+	db *sql.DB
+}
+
+func NewSQLRepository(db *sql.DB) Repository {
+	return &sqlRepository{
+		db: db,
+	}
+}
+
+// Save stores the user's address, replacing the previous one.
+func (r *sqlRepository) Save(address *Address) error {
+	query := `INSERT INTO inbox_addresses (user_id, token, created_at) 
+		VALUES (?, ?, ?) 
+		ON CONFLICT (user_id) DO UPDATE SET token = excluded.token, created_at = excluded.created_at`
+
+	_, err := r.db.Exec(query, address.UserID, address.Token, address.CreatedAt)
+	return err
+}
+
+func (r *sqlRepository) FindByUserID(userID string) (*Address, error) {
+	return r.find(`SELECT user_id, token, created_at FROM inbox_addresses WHERE user_id = ?`, userID)
+}
+
+func (r *sqlRepository) FindByToken(token string) (*Address, error) {
+	return r.find(`SELECT user_id, token, created_at FROM inbox_addresses WHERE token = ?`, token)
+}
+
+func (r *sqlRepository) find(query string, arg string) (*Address, error) {
+	address := &Address{}
+	err := r.db.QueryRow(query, arg).Scan(&address.UserID, &address.Token, &address.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAddressNotFound
+		}
+		return nil, err
+	}
+
+	return address, nil
+}
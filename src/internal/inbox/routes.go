@@ -0,0 +1,11 @@
+package inbox
+
+import "github.com/gin-gonic/gin"
+
+func RegisterRoutes(rg *gin.RouterGroup, handler *Handler) {
+	inbox := rg.Group("/inbox")
+	{
+		inbox.GET("/address", handler.GetAddress)
+		inbox.POST("/address/regenerate", handler.RegenerateAddress)
+	}
+}
@@ -0,0 +1,121 @@
+package inbox
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gastei-quanto/src/internal/parser"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnknownRecipient = errors.New("destinatário desconhecido")
+
+type Service interface {
+	GetAddress(userID string) (*Address, error)
+	RegenerateAddress(userID string) (*Address, error)
+	ResolveRecipient(recipient string) (string, error)
+	Deliver(userID string, message []byte) (*parser.ImportAndSaveResponse, error)
+}
+
+type service struct {
+	repo               Repository
+	integrationService parser.IntegrationService
+	domain             string
+	enabled            bool
+}
+
+// NewService builds the inbox of the given mail domain. enabled tells users
+// whether the SMTP listener is running, that is, whether mail sent to their
+// address arrives.
+func NewService(repo Repository, integrationService parser.IntegrationService, domain string, enabled bool) Service {
+	return &service{
+		repo:               repo,
+		integrationService: integrationService,
+		domain:             strings.ToLower(domain),
+		enabled:            enabled,
+	}
+}
+
+// GetAddress returns the user's address, creating it on first use.
+func (s *service) GetAddress(userID string) (*Address, error) {
+	address, err := s.repo.FindByUserID(userID)
+	if errors.Is(err, ErrAddressNotFound) {
+		return s.RegenerateAddress(userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.fill(address)
+	return address, nil
+}
+
+// RegenerateAddress replaces the user's address; mail sent to the old one is
+// refused from then on.
+func (s *service) RegenerateAddress(userID string) (*Address, error) {
+	address := &Address{
+		UserID:    userID,
+		Token:     "gq" + strings.ReplaceAll(uuid.New().String(), "-", "")[:16],
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.repo.Save(address); err != nil {
+		return nil, err
+	}
+
+	s.fill(address)
+	return address, nil
+}
+
+// ResolveRecipient returns the user a recipient address belongs to. A
+// "+suffix" in the local part is ignored, as most providers do.
+func (s *service) ResolveRecipient(recipient string) (string, error) {
+	local, domain, ok := strings.Cut(strings.ToLower(strings.Trim(strings.TrimSpace(recipient), "<>")), "@")
+	if !ok || domain != s.domain {
+		return "", ErrUnknownRecipient
+	}
+
+	local, _, _ = strings.Cut(local, "+")
+	address, err := s.repo.FindByToken(local)
+	if errors.Is(err, ErrAddressNotFound) {
+		return "", ErrUnknownRecipient
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return address.UserID, nil
+}
+
+// Deliver saves the purchases in a message received for the user. Messages
+// that are not known notifications are accepted and only logged, so they do
+// not bounce back to whoever forwarded them.
+func (s *service) Deliver(userID string, message []byte) (*parser.ImportAndSaveResponse, error) {
+	result, err := s.integrationService.ProcessAndSaveEmail(userID, bytes.NewReader(message), parser.ImportOptions{
+		Filename: "smtp",
+	})
+
+	var rejectedErr *parser.RejectedRowsError
+	if errors.As(err, &rejectedErr) {
+		for _, row := range rejectedErr.Rows {
+			log.Printf("Inbox: e-mail for user %s ignored (%s): %s", userID, row.Raw, row.Reason)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao salvar e-mail: %w", err)
+	}
+
+	log.Printf("Inbox: %d transaction(s) saved for user %s (batch %s)", result.Saved, userID, result.BatchID)
+	return result, nil
+}
+
+func (s *service) fill(address *Address) {
+	address.Address = address.Token + "@" + s.domain
+	address.Enabled = s.enabled
+}
@@ -0,0 +1,261 @@
+package inbox
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+const (
+	maxMessageSize   = 10 << 20
+	maxRecipients    = 20
+	maxCommandLine   = 512
+	maxSMTPSessions  = 100
+	smtpIdleTimeout  = 5 * time.Minute
+	smtpDataDeadline = 10 * time.Minute
+)
+
+var errCommandTooLong = errors.New("linha de comando longa demais")
+
+// SMTPServer is a minimal SMTP listener that receives mail for the users'
+// inbox addresses. It has no TLS and no authentication: run it behind a
+// relay or use it with a local client. Mail to any other address is
+// refused at RCPT, so it is not an open relay.
+type SMTPServer struct {
+	addr     string
+	domain   string
+	service  Service
+	sessions chan struct{}
+}
+
+func NewSMTPServer(addr, domain string, service Service) *SMTPServer {
+	return &SMTPServer{
+		addr:     addr,
+		domain:   domain,
+		service:  service,
+		sessions: make(chan struct{}, maxSMTPSessions),
+	}
+}
+
+func (s *SMTPServer) ListenAndServe() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+			return err
+		}
+
+		select {
+		case s.sessions <- struct{}{}:
+			go func() {
+				defer func() { <-s.sessions }()
+				s.serve(conn)
+			}()
+		default:
+			conn.SetDeadline(time.Now().Add(time.Second))
+			fmt.Fprintf(conn, "421 4.3.2 %s ocupado, tente mais tarde\r\n", s.domain)
+			conn.Close()
+		}
+	}
+}
+
+type smtpSession struct {
+	server     *SMTPServer
+	conn       net.Conn
+	text       *textproto.Conn
+	greeted    bool
+	sender     bool
+	recipients []string
+}
+
+func (s *SMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	session := &smtpSession{
+		server: s,
+		conn:   conn,
+		text:   textproto.NewConn(conn),
+	}
+	session.reply(220, s.domain+" ESMTP Gastei Quanto")
+
+	for {
+		conn.SetDeadline(time.Now().Add(smtpIdleTimeout))
+		line, err := session.readCommand()
+		if errors.Is(err, errCommandTooLong) {
+			session.reply(500, "5.5.2 Linha de comando longa demais")
+			continue
+		}
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		if !session.handle(strings.ToUpper(verb), strings.TrimSpace(arg)) {
+			return
+		}
+	}
+}
+
+// readCommand reads one command line without buffering more than
+// maxCommandLine bytes of it; the rest of a longer line is discarded.
+func (c *smtpSession) readCommand() (string, error) {
+	line, err := c.text.R.ReadSlice('\n')
+	if err == nil && len(line) <= maxCommandLine {
+		return strings.TrimRight(string(line), "\r\n"), nil
+	}
+
+	for errors.Is(err, bufio.ErrBufferFull) {
+		_, err = c.text.R.ReadSlice('\n')
+	}
+	if err != nil {
+		return "", err
+	}
+	return "", errCommandTooLong
+}
+
+// handle runs one command and reports whether the session goes on.
+func (c *smtpSession) handle(verb, arg string) bool {
+	switch verb {
+	case "HELO":
+		c.reset()
+		c.greeted = true
+		c.reply(250, c.server.domain)
+	case "EHLO":
+		c.reset()
+		c.greeted = true
+		c.reply(250, c.server.domain, fmt.Sprintf("SIZE %d", maxMessageSize), "8BITMIME")
+	case "MAIL":
+		if !c.greeted {
+			c.reply(503, "5.5.1 Envie HELO/EHLO primeiro")
+			return true
+		}
+		if !strings.HasPrefix(strings.ToUpper(arg), "FROM:") {
+			c.reply(501, "5.5.4 Sintaxe: MAIL FROM:<endereço>")
+			return true
+		}
+		c.reset()
+		c.sender = true
+		c.reply(250, "2.1.0 OK")
+	case "RCPT":
+		c.recipient(arg)
+	case "DATA":
+		c.data()
+	case "RSET":
+		c.reset()
+		c.reply(250, "2.0.0 OK")
+	case "NOOP":
+		c.reply(250, "2.0.0 OK")
+	case "VRFY":
+		c.reply(252, "2.1.5 Não verificado")
+	case "QUIT":
+		c.reply(221, "2.0.0 Até logo")
+		return false
+	default:
+		c.reply(502, "5.5.2 Comando não suportado")
+	}
+	return true
+}
+
+func (c *smtpSession) recipient(arg string) {
+	if !c.sender {
+		c.reply(503, "5.5.1 Envie MAIL primeiro")
+		return
+	}
+	if !strings.HasPrefix(strings.ToUpper(arg), "TO:") {
+		c.reply(501, "5.5.4 Sintaxe: RCPT TO:<endereço>")
+		return
+	}
+	if len(c.recipients) >= maxRecipients {
+		c.reply(452, "4.5.3 Destinatários demais")
+		return
+	}
+
+	address, _, _ := strings.Cut(strings.TrimSpace(arg[3:]), " ")
+	userID, err := c.server.service.ResolveRecipient(address)
+	if errors.Is(err, ErrUnknownRecipient) {
+		c.reply(550, "5.1.1 Destinatário desconhecido")
+		return
+	}
+	if err != nil {
+		log.Printf("Inbox: error resolving recipient %s: %v", address, err)
+		c.reply(451, "4.3.0 Erro temporário")
+		return
+	}
+
+	c.recipients = append(c.recipients, userID)
+	c.reply(250, "2.1.5 OK")
+}
+
+func (c *smtpSession) data() {
+	if len(c.recipients) == 0 {
+		c.reply(503, "5.5.1 Envie RCPT primeiro")
+		return
+	}
+
+	c.reply(354, "Envie a mensagem terminando com <CRLF>.<CRLF>")
+	c.conn.SetDeadline(time.Now().Add(smtpDataDeadline))
+
+	reader := c.text.DotReader()
+	message, err := io.ReadAll(io.LimitReader(reader, maxMessageSize+1))
+	if err != nil {
+		return
+	}
+	if len(message) > maxMessageSize {
+		io.Copy(io.Discard, reader)
+		c.reset()
+		c.reply(552, "5.3.4 Mensagem grande demais")
+		return
+	}
+
+	delivered := make(map[string]bool)
+	for _, userID := range c.recipients {
+		if delivered[userID] {
+			continue
+		}
+		delivered[userID] = true
+
+		if _, err := c.server.service.Deliver(userID, message); err != nil {
+			log.Printf("Inbox: error delivering to user %s: %v", userID, err)
+			c.reset()
+			c.reply(451, "4.3.0 Erro ao processar a mensagem")
+			return
+		}
+	}
+
+	c.reset()
+	c.reply(250, "2.0.0 Mensagem recebida")
+}
+
+func (c *smtpSession) reset() {
+	c.sender = false
+	c.recipients = nil
+}
+
+// reply writes a response, one line per text, as a multiline reply when
+// there is more than one.
+func (c *smtpSession) reply(code int, lines ...string) {
+	w := bufio.NewWriter(c.conn)
+	for i, line := range lines {
+		separator := " "
+		if i < len(lines)-1 {
+			separator = "-"
+		}
+		fmt.Fprintf(w, "%d%s%s\r\n", code, separator, line)
+	}
+	w.Flush()
+}
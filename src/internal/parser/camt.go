@@ -0,0 +1,339 @@
+package parser
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CAMT.053 (ISO 20022 bank to customer statement) elements. Only the local
+// names are matched, so every version of the camt.053.001 schema is read.
+type camtAmount struct {
+	Value string `xml:",chardata"`
+	Ccy   string `xml:"Ccy,attr"`
+}
+
+type camtDate struct {
+	Dt   string `xml:"Dt"`
+	DtTm string `xml:"DtTm"`
+}
+
+// camtStatus is "<Sts>BOOK</Sts>" up to version 6 and
+// "<Sts><Cd>BOOK</Cd></Sts>" after it.
+type camtStatus struct {
+	Text string `xml:",chardata"`
+	Cd   string `xml:"Cd"`
+}
+
+// camtParty holds the name directly up to version 6 and inside Pty after it.
+type camtParty struct {
+	Nm    string `xml:"Nm"`
+	PtyNm string `xml:"Pty>Nm"`
+}
+
+type camtTxDetails struct {
+	Refs struct {
+		AcctSvcrRef string `xml:"AcctSvcrRef"`
+		EndToEndID  string `xml:"EndToEndId"`
+		TxID        string `xml:"TxId"`
+	} `xml:"Refs"`
+	Amt       *camtAmount `xml:"Amt"`
+	TxAmt     *camtAmount `xml:"AmtDtls>TxAmt>Amt"`
+	CdtDbtInd string      `xml:"CdtDbtInd"`
+	InstdAmt  camtAmount  `xml:"AmtDtls>InstdAmt>Amt"`
+	Dbtr      camtParty   `xml:"RltdPties>Dbtr"`
+	Cdtr      camtParty   `xml:"RltdPties>Cdtr"`
+	Ustrd     []string    `xml:"RmtInf>Ustrd"`
+	StrdRef   []string    `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+	AddtlInf  string      `xml:"AddtlTxInf"`
+}
+
+type camtEntry struct {
+	NtryRef     string          `xml:"NtryRef"`
+	Amt         camtAmount      `xml:"Amt"`
+	CdtDbtInd   string          `xml:"CdtDbtInd"`
+	Sts         camtStatus      `xml:"Sts"`
+	BookgDt     camtDate        `xml:"BookgDt"`
+	ValDt       camtDate        `xml:"ValDt"`
+	AcctSvcrRef string          `xml:"AcctSvcrRef"`
+	AddtlInf    string          `xml:"AddtlNtryInf"`
+	TxDtls      []camtTxDetails `xml:"NtryDtls>TxDtls"`
+}
+
+// ParseCAMT053 reads an ISO 20022 CAMT.053 statement. Only booked entries
+// are imported; pending and informational ones are left out.
+func (s *service) ParseCAMT053(file io.Reader) (*ParseResult, error) {
+	text, err := newTextReader(file, EncodingAuto)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := xml.NewDecoder(text)
+	// The text reader already converted the file to UTF-8.
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	result := &ParseResult{
+		Profile: &ImportProfile{
+			Name:           "camt053",
+			Description:    "Extrato CAMT.053 (ISO 20022)",
+			StatementKind:  StatementChecking,
+			SignConvention: SignDebitNegative,
+		},
+	}
+	found := false
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("arquivo CAMT.053 inválido: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "BkToCstmrStmt":
+			found = true
+		case "BkToCstmrAcctRpt", "BkToCstmrDbtCdtNtfctn":
+			return nil, fmt.Errorf("arquivo é um relatório CAMT.052/054, não um extrato CAMT.053")
+		case "Ntry":
+			if !found {
+				continue
+			}
+
+			line, _ := decoder.InputPos()
+			var entry camtEntry
+			if err := decoder.DecodeElement(&entry, &start); err != nil {
+				return nil, fmt.Errorf("arquivo CAMT.053 inválido: %w", err)
+			}
+
+			transactions, err := entry.transactions()
+			if err != nil {
+				result.Rejected = append(result.Rejected, RejectedRow{
+					Line:   line,
+					Raw:    entry.raw(),
+					Reason: err.Error(),
+				})
+				continue
+			}
+			result.Transactions = append(result.Transactions, transactions...)
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("arquivo CAMT.053 inválido: elemento BkToCstmrStmt não encontrado")
+	}
+
+	return result, nil
+}
+
+// transactions maps a booked entry to one transaction or, for a batch entry
+// whose details carry their own amounts, to one transaction per detail.
+// Amounts are from the account holder's side: debits are negative.
+func (e *camtEntry) transactions() ([]Transaction, error) {
+	if status := e.Sts.code(); status != "" && status != "BOOK" {
+		return nil, nil
+	}
+
+	date, err := e.BookgDt.parse()
+	if err != nil {
+		if date, err = e.ValDt.parse(); err != nil {
+			return nil, fmt.Errorf("data de lançamento ausente ou inválida")
+		}
+	}
+
+	var valueDate *time.Time
+	if parsed, err := e.ValDt.parse(); err == nil {
+		valueDate = &parsed
+	}
+
+	ref := firstNonEmpty(e.AcctSvcrRef, e.NtryRef)
+
+	split := len(e.TxDtls) > 1
+	for _, details := range e.TxDtls {
+		split = split && details.amount() != nil
+	}
+
+	if !split {
+		amount, err := camtSignedAmount(e.Amt, e.CdtDbtInd)
+		if err != nil {
+			return nil, err
+		}
+
+		var details camtTxDetails
+		if len(e.TxDtls) > 0 {
+			details = e.TxDtls[0]
+		}
+		if ref == "" {
+			ref = details.ref()
+		}
+
+		t := Transaction{
+			Date:        date,
+			ValueDate:   valueDate,
+			Description: details.description(amount, e.AddtlInf),
+			Amount:      amount,
+			ExternalID:  ref,
+		}
+		details.setOriginal(&t, e.Amt.Ccy)
+
+		return []Transaction{t}, nil
+	}
+
+	transactions := make([]Transaction, 0, len(e.TxDtls))
+	for i, details := range e.TxDtls {
+		indicator := firstNonEmpty(details.CdtDbtInd, e.CdtDbtInd)
+		amount, err := camtSignedAmount(*details.amount(), indicator)
+		if err != nil {
+			return nil, err
+		}
+
+		detailRef := firstNonEmpty(details.ref(), strconv.Itoa(i+1))
+		if ref != "" {
+			detailRef = ref + "/" + detailRef
+		}
+
+		t := Transaction{
+			Date:        date,
+			ValueDate:   valueDate,
+			Description: details.description(amount, e.AddtlInf),
+			Amount:      amount,
+			ExternalID:  detailRef,
+		}
+		details.setOriginal(&t, details.amount().Ccy)
+
+		transactions = append(transactions, t)
+	}
+
+	return transactions, nil
+}
+
+func (e *camtEntry) raw() string {
+	return strings.Join(strings.Fields(fmt.Sprintf("Ntry %s %s %s %s %s",
+		firstNonEmpty(e.AcctSvcrRef, e.NtryRef), e.Amt.Value, e.Amt.Ccy, e.CdtDbtInd, e.BookgDt.value())), " ")
+}
+
+// description is the counterparty (who was paid on a debit, who paid on a
+// credit) followed by the remittance information.
+func (d *camtTxDetails) description(amount float64, entryInfo string) string {
+	party := d.Dbtr
+	if amount < 0 {
+		party = d.Cdtr
+	}
+
+	remittance := strings.Join(d.Ustrd, " ")
+	if remittance == "" {
+		remittance = strings.Join(d.StrdRef, " ")
+	}
+
+	var parts []string
+	for _, part := range []string{party.name(), remittance} {
+		if part = strings.Join(strings.Fields(part), " "); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	if len(parts) == 0 {
+		return strings.Join(strings.Fields(firstNonEmpty(d.AddtlInf, entryInfo, "Lançamento CAMT.053")), " ")
+	}
+
+	return strings.Join(parts, " - ")
+}
+
+// amount is the detail's own amount, written directly in TxDtls from version
+// 8 on and in AmtDtls before it.
+func (d *camtTxDetails) amount() *camtAmount {
+	if d.Amt != nil {
+		return d.Amt
+	}
+	return d.TxAmt
+}
+
+func (d *camtTxDetails) ref() string {
+	endToEnd := d.Refs.EndToEndID
+	if strings.EqualFold(endToEnd, "NOTPROVIDED") {
+		endToEnd = ""
+	}
+	return firstNonEmpty(d.Refs.AcctSvcrRef, endToEnd, d.Refs.TxID)
+}
+
+// setOriginal records the instructed amount when it is in a currency other
+// than the one the account was charged in.
+func (d *camtTxDetails) setOriginal(t *Transaction, chargedCurrency string) {
+	if d.InstdAmt.Ccy == "" || strings.EqualFold(d.InstdAmt.Ccy, chargedCurrency) {
+		return
+	}
+
+	value := strings.TrimSpace(d.InstdAmt.Value)
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || !plainDecimal.MatchString(value) {
+		return
+	}
+
+	t.setOriginal(d.InstdAmt.Ccy, amount, 0)
+}
+
+func (p camtParty) name() string {
+	return firstNonEmpty(p.Nm, p.PtyNm)
+}
+
+func (s camtStatus) code() string {
+	return strings.ToUpper(firstNonEmpty(s.Cd, s.Text))
+}
+
+func (d camtDate) value() string {
+	return firstNonEmpty(d.Dt, d.DtTm)
+}
+
+func (d camtDate) parse() (time.Time, error) {
+	if value := strings.TrimSpace(d.Dt); value != "" {
+		return time.Parse("2006-01-02", value)
+	}
+
+	if value := strings.TrimSpace(d.DtTm); len(value) >= 10 {
+		// Keep the calendar day of the bank, whatever the offset.
+		return time.Parse("2006-01-02", value[:10])
+	}
+
+	return time.Time{}, fmt.Errorf("data ausente")
+}
+
+// camtSignedAmount applies the credit/debit indicator. Reversals need no
+// special handling: their indicator already gives the direction the money
+// moved.
+func camtSignedAmount(amt camtAmount, indicator string) (float64, error) {
+	value := strings.TrimSpace(amt.Value)
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || !plainDecimal.MatchString(value) {
+		return 0, fmt.Errorf("valor inválido: %s", amt.Value)
+	}
+
+	switch strings.ToUpper(strings.TrimSpace(indicator)) {
+	case "DBIT":
+		amount = -amount
+	case "CRDT":
+	default:
+		return 0, fmt.Errorf("indicador de crédito/débito inválido: %q", indicator)
+	}
+
+	return amount, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
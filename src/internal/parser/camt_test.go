@@ -0,0 +1,138 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const camtStatement = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+<BkToCstmrStmt>
+<Stmt>
+<Ntry>
+  <NtryRef>E1</NtryRef>
+  <Amt Ccy="BRL">42.50</Amt>
+  <CdtDbtInd>DBIT</CdtDbtInd>
+  <Sts><Cd>BOOK</Cd></Sts>
+  <BookgDt><Dt>2026-01-05</Dt></BookgDt>
+  <ValDt><Dt>2026-01-06</Dt></ValDt>
+  <NtryDtls><TxDtls>
+    <Refs><EndToEndId>NOTPROVIDED</EndToEndId></Refs>
+    <RltdPties><Cdtr><Pty><Nm>Padaria São João</Nm></Pty></Cdtr></RltdPties>
+    <RmtInf><Ustrd>Pão e café</Ustrd></RmtInf>
+  </TxDtls></NtryDtls>
+</Ntry>
+<Ntry>
+  <AcctSvcrRef>LOTE7</AcctSvcrRef>
+  <Amt Ccy="BRL">300.00</Amt>
+  <CdtDbtInd>CRDT</CdtDbtInd>
+  <Sts>BOOK</Sts>
+  <BookgDt><DtTm>2026-01-07T23:30:00-03:00</DtTm></BookgDt>
+  <NtryDtls>
+    <TxDtls>
+      <Refs><EndToEndId>E2E-1</EndToEndId></Refs>
+      <Amt Ccy="BRL">100.00</Amt>
+      <RltdPties><Dbtr><Nm>Maria</Nm></Dbtr></RltdPties>
+    </TxDtls>
+    <TxDtls>
+      <Amt Ccy="BRL">200.00</Amt>
+      <RltdPties><Dbtr><Nm>José</Nm></Dbtr></RltdPties>
+    </TxDtls>
+  </NtryDtls>
+</Ntry>
+<Ntry>
+  <NtryRef>E3</NtryRef>
+  <Amt Ccy="BRL">520.00</Amt>
+  <CdtDbtInd>DBIT</CdtDbtInd>
+  <Sts><Cd>BOOK</Cd></Sts>
+  <BookgDt><Dt>2026-01-08</Dt></BookgDt>
+  <NtryDtls><TxDtls>
+    <AmtDtls><InstdAmt><Amt Ccy="USD">100.00</Amt></InstdAmt></AmtDtls>
+    <RltdPties><Cdtr><Nm>Hotel NY</Nm></Cdtr></RltdPties>
+  </TxDtls></NtryDtls>
+</Ntry>
+<Ntry>
+  <NtryRef>E4</NtryRef>
+  <Amt Ccy="BRL">10.00</Amt>
+  <CdtDbtInd>DBIT</CdtDbtInd>
+  <Sts><Cd>PDNG</Cd></Sts>
+  <BookgDt><Dt>2026-01-09</Dt></BookgDt>
+</Ntry>
+<Ntry>
+  <NtryRef>E5</NtryRef>
+  <Amt Ccy="BRL">abc</Amt>
+  <CdtDbtInd>DBIT</CdtDbtInd>
+  <Sts><Cd>BOOK</Cd></Sts>
+  <BookgDt><Dt>2026-01-10</Dt></BookgDt>
+</Ntry>
+<Ntry>
+  <NtryRef>E6</NtryRef>
+  <Amt Ccy="BRL">NaN</Amt>
+  <CdtDbtInd>CRDT</CdtDbtInd>
+  <Sts><Cd>BOOK</Cd></Sts>
+  <BookgDt><Dt>2026-01-10</Dt></BookgDt>
+</Ntry>
+</Stmt>
+</BkToCstmrStmt>
+</Document>`
+
+func TestParseCAMT053(t *testing.T) {
+	result, err := NewService().ParseCAMT053(strings.NewReader(camtStatement))
+	if err != nil {
+		t.Fatalf("ParseCAMT053: %v", err)
+	}
+
+	type want struct {
+		date        string
+		description string
+		amount      float64
+		externalID  string
+	}
+	wants := []want{
+		{"2026-01-05", "Padaria São João - Pão e café", -42.50, "E1"},
+		{"2026-01-07", "Maria", 100, "LOTE7/E2E-1"},
+		{"2026-01-07", "José", 200, "LOTE7/2"},
+		{"2026-01-08", "Hotel NY", -520, "E3"},
+	}
+
+	if len(result.Transactions) != len(wants) {
+		t.Fatalf("got %d transactions %+v, want %d", len(result.Transactions), result.Transactions, len(wants))
+	}
+	for i, w := range wants {
+		got := result.Transactions[i]
+		if got.Date.Format("2006-01-02") != w.date || got.Description != w.description || got.Amount != w.amount || got.ExternalID != w.externalID {
+			t.Errorf("transaction %d = %+v, want %+v", i, got, w)
+		}
+	}
+
+	if valueDate := result.Transactions[0].ValueDate; valueDate == nil || !valueDate.Equal(time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("value date = %v, want 2026-01-06", valueDate)
+	}
+	if hotel := result.Transactions[3]; hotel.OriginalCurrency != "USD" || hotel.OriginalAmount != 100 {
+		t.Errorf("original amount = %v %s, want 100 USD", hotel.OriginalAmount, hotel.OriginalCurrency)
+	}
+
+	if len(result.Rejected) != 2 || !strings.Contains(result.Rejected[0].Raw, "E5") || !strings.Contains(result.Rejected[1].Raw, "E6") {
+		t.Errorf("rejected = %+v, want the entries with invalid amounts", result.Rejected)
+	}
+}
+
+func TestParseCAMT053Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"camt.052 report", `<Document><BkToCstmrAcctRpt><Rpt></Rpt></BkToCstmrAcctRpt></Document>`},
+		{"no statement", `<Document></Document>`},
+		{"malformed xml", `<Document><BkToCstmrStmt><Ntry>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewService().ParseCAMT053(strings.NewReader(tt.data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
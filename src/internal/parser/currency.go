@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"math"
+	"regexp"
+	"strings"
+)
+
+// currencySymbols maps the symbols statements print to ISO 4217 codes.
+var currencySymbols = map[string]string{
+	"US$": "USD",
+	"U$":  "USD",
+	"$":   "USD",
+	"€":   "EUR",
+	"£":   "GBP",
+	"R$":  "BRL",
+}
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// normalizeCurrency returns the ISO code of a currency written as a code or
+// a symbol, or "" when it is not recognized.
+func normalizeCurrency(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if code, ok := currencySymbols[value]; ok {
+		return code
+	}
+	if currencyCode.MatchString(value) {
+		return value
+	}
+	return ""
+}
+
+// setOriginal records the foreign currency side of a purchase. Amounts in
+// reais are ignored, and a missing exchange rate is derived from the two
+// amounts.
+func (t *Transaction) setOriginal(currency string, amount, rate float64) {
+	currency = normalizeCurrency(currency)
+	amount = math.Abs(amount)
+	if currency == "" || currency == "BRL" || amount == 0 {
+		return
+	}
+
+	if rate <= 0 && t.Amount != 0 {
+		rate = math.Round(math.Abs(t.Amount)/amount*10000) / 10000
+	}
+
+	t.OriginalCurrency = currency
+	t.OriginalAmount = math.Round(amount*100) / 100
+	t.ExchangeRate = rate
+}
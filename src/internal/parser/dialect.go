@@ -0,0 +1,264 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+type Locale string
+
+const (
+	LocaleAuto Locale = ""
+	LocalePTBR Locale = "pt-BR"
+	LocaleENUS Locale = "en-US"
+)
+
+const (
+	EncodingAuto        = ""
+	EncodingUTF8        = "utf-8"
+	EncodingWindows1252 = "windows-1252"
+	EncodingLatin1      = "iso-8859-1"
+)
+
+const sniffSize = 64 * 1024
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+var delimiterCandidates = []rune{',', ';', '\t', '|'}
+
+func ParseLocale(value string) (Locale, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "auto":
+		return LocaleAuto, nil
+	case "pt-br", "pt_br", "br":
+		return LocalePTBR, nil
+	case "en-us", "en_us", "us":
+		return LocaleENUS, nil
+	}
+	return LocaleAuto, fmt.Errorf("locale inválido: %s (use pt-BR ou en-US)", value)
+}
+
+func ParseEncoding(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "auto":
+		return EncodingAuto, nil
+	case "utf-8", "utf8":
+		return EncodingUTF8, nil
+	case "windows-1252", "cp1252":
+		return EncodingWindows1252, nil
+	case "iso-8859-1", "latin1", "latin-1":
+		return EncodingLatin1, nil
+	}
+	return "", fmt.Errorf("encoding inválido: %s (use utf-8, windows-1252 ou iso-8859-1)", value)
+}
+
+func ParseDelimiter(value string) (rune, error) {
+	switch value {
+	case "", "auto":
+		return 0, nil
+	case "tab", "\\t", "\t":
+		return '\t', nil
+	}
+
+	r, size := utf8.DecodeRuneInString(value)
+	if size != len(value) || r == '"' || r == '\r' || r == '\n' || r == utf8.RuneError {
+		return 0, fmt.Errorf("delimitador inválido: %s", value)
+	}
+	return r, nil
+}
+
+// newTextReader strips a UTF-8 BOM and transcodes Latin-1/Windows-1252
+// input to UTF-8. With EncodingAuto the charset is picked by checking
+// whether the first bytes of the file are valid UTF-8.
+func newTextReader(r io.Reader, encoding string) (*bufio.Reader, error) {
+	br := bufio.NewReaderSize(r, sniffSize)
+
+	sample, err := br.Peek(sniffSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, fmt.Errorf("erro ao ler arquivo: %w", err)
+	}
+
+	if bytes.HasPrefix(sample, utf8BOM) {
+		br.Discard(len(utf8BOM))
+		return br, nil
+	}
+
+	if encoding == EncodingAuto {
+		encoding = EncodingUTF8
+		if !utf8.Valid(trimIncompleteRune(sample)) {
+			encoding = EncodingWindows1252
+		}
+	}
+
+	switch encoding {
+	case EncodingUTF8:
+		return br, nil
+	case EncodingWindows1252:
+		return bufio.NewReaderSize(charmap.Windows1252.NewDecoder().Reader(br), sniffSize), nil
+	case EncodingLatin1:
+		return bufio.NewReaderSize(charmap.ISO8859_1.NewDecoder().Reader(br), sniffSize), nil
+	}
+
+	return nil, fmt.Errorf("encoding inválido: %s", encoding)
+}
+
+func trimIncompleteRune(sample []byte) []byte {
+	for i := 1; i < utf8.UTFMax && i <= len(sample); i++ {
+		if utf8.RuneStart(sample[len(sample)-i]) {
+			if !utf8.FullRune(sample[len(sample)-i:]) {
+				return sample[:len(sample)-i]
+			}
+			break
+		}
+	}
+	return sample
+}
+
+// sniffDelimiter picks the candidate that appears in the header and splits
+// the following lines into the same number of fields most often.
+func sniffDelimiter(sample []byte) rune {
+	var lines []string
+	for _, line := range strings.Split(string(sample), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, line)
+		if len(lines) == 20 {
+			break
+		}
+	}
+
+	if len(lines) == 0 {
+		return ','
+	}
+
+	// Bank exports may open with a few lines about the account before the
+	// header, so the delimiter is the one giving the most lines the same
+	// number of fields, not necessarily the first line's.
+	best := ','
+	bestScore := 0
+	for _, candidate := range delimiterCandidates {
+		frequency := make(map[int]int)
+		for _, line := range lines {
+			if count := countDelimiter(line, candidate); count > 0 {
+				frequency[count]++
+			}
+		}
+
+		for count, consistent := range frequency {
+			score := consistent*1000 + count
+			if score > bestScore {
+				best = candidate
+				bestScore = score
+			}
+		}
+	}
+
+	return best
+}
+
+func countDelimiter(line string, delimiter rune) int {
+	count := 0
+	inQuotes := false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == delimiter && !inQuotes:
+			count++
+		}
+	}
+	return count
+}
+
+// detectLocale infers the decimal separator from sample amounts. When the
+// samples are ambiguous (e.g. "1.234"), semicolon-separated files are
+// assumed to come from a pt-BR spreadsheet.
+func detectLocale(values []string, delimiter rune) Locale {
+	for _, value := range values {
+		value = cleanAmount(value)
+
+		comma := strings.LastIndexByte(value, ',')
+		dot := strings.LastIndexByte(value, '.')
+
+		switch {
+		case comma >= 0 && dot >= 0:
+			if comma > dot {
+				return LocalePTBR
+			}
+			return LocaleENUS
+		case comma >= 0 && len(value)-comma-1 != 3:
+			return LocalePTBR
+		case dot >= 0 && len(value)-dot-1 != 3:
+			return LocaleENUS
+		}
+	}
+
+	if delimiter == ';' {
+		return LocalePTBR
+	}
+	return LocaleENUS
+}
+
+func cleanAmount(amountStr string) string {
+	amountStr = strings.TrimSpace(amountStr)
+	amountStr = strings.ReplaceAll(amountStr, "R$", "")
+	amountStr = strings.ReplaceAll(amountStr, "\u00a0", "")
+	amountStr = strings.ReplaceAll(amountStr, " ", "")
+	return amountStr
+}
+
+// plainDecimal is what an amount must look like once its separators are
+// normalized; ParseFloat alone would also take "NaN", "Inf", exponents and
+// hex floats such as "0x1p4".
+var plainDecimal = regexp.MustCompile(`^[-+]?(\d+(\.\d*)?|\.\d+)$`)
+
+func parseAmount(amountStr string, locale Locale) (float64, error) {
+	amountStr = cleanAmount(amountStr)
+
+	negative := false
+	if strings.HasPrefix(amountStr, "(") && strings.HasSuffix(amountStr, ")") {
+		negative = true
+		amountStr = amountStr[1 : len(amountStr)-1]
+	}
+	if strings.HasSuffix(amountStr, "-") {
+		negative = true
+		amountStr = strings.TrimSuffix(amountStr, "-")
+	}
+
+	if locale == LocaleAuto {
+		locale = detectLocale([]string{amountStr}, 0)
+	}
+
+	switch locale {
+	case LocalePTBR:
+		amountStr = strings.ReplaceAll(amountStr, ".", "")
+		amountStr = strings.ReplaceAll(amountStr, ",", ".")
+	default:
+		amountStr = strings.ReplaceAll(amountStr, ",", "")
+	}
+
+	if !plainDecimal.MatchString(amountStr) {
+		return 0, fmt.Errorf("valor inválido: %s", amountStr)
+	}
+
+	amount, err := strconv.ParseFloat(amountStr, 64)
+	if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return 0, fmt.Errorf("valor inválido: %s", amountStr)
+	}
+
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
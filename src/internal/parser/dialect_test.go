@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSniffDelimiter(t *testing.T) {
+	tests := []struct {
+		name   string
+		sample string
+		want   rune
+	}{
+		{"comma", "Data,Descrição,Valor\n01/01/2026,Padaria,10.00\n", ','},
+		{"semicolon with decimal commas", "Data;Descrição;Valor\n01/01/2026;Padaria;10,00\n02/01/2026;Mercado;1.234,56\n", ';'},
+		{"tab", "Data\tDescrição\tValor\n01/01/2026\tPadaria\t10,00\n", '\t'},
+		{"pipe", "Data|Descrição|Valor\n01/01/2026|Padaria|10,00\n", '|'},
+		{"quoted commas", "Data;Descrição;Valor\n01/01/2026;\"Loja, centro\";10,00\n", ';'},
+		{"account lines before the header", "Conta: 1234-5\nAgência: 0001\nData;Descrição;Valor\n01/01/2026;Padaria;10,00\n02/01/2026;Mercado;20,00\n", ';'},
+		{"empty", "", ','},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sniffDelimiter([]byte(tt.sample)); got != tt.want {
+				t.Errorf("sniffDelimiter = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		value  string
+		locale Locale
+		want   float64
+	}{
+		{"1.234,56", LocalePTBR, 1234.56},
+		{"R$ 1.234,56", LocaleAuto, 1234.56},
+		{"1,234.56", LocaleENUS, 1234.56},
+		{"1,234.56", LocaleAuto, 1234.56},
+		{"10,5", LocaleAuto, 10.5},
+		{"-42.10", LocaleAuto, -42.10},
+		{"(42,10)", LocalePTBR, -42.10},
+		{"42,10-", LocalePTBR, -42.10},
+		{"1 234,00", LocalePTBR, 1234},
+	}
+
+	for _, tt := range tests {
+		got, err := parseAmount(tt.value, tt.locale)
+		if err != nil {
+			t.Errorf("parseAmount(%q, %q): %v", tt.value, tt.locale, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseAmount(%q, %q) = %v, want %v", tt.value, tt.locale, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"abc", "", "NaN", "-Inf", "+inf", "Infinity", "0x1p4", "1e5", "1_000", "1" + strings.Repeat("0", 400), "--5", "1.2.3"} {
+		if got, err := parseAmount(value, LocaleENUS); err == nil {
+			t.Errorf("parseAmount(%q) = %v, want an error", value, got)
+		}
+	}
+}
+
+func TestDetectLocale(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    []string
+		delimiter rune
+		want      Locale
+	}{
+		{"both separators, comma last", []string{"1.234,56"}, ',', LocalePTBR},
+		{"both separators, dot last", []string{"1,234.56"}, ';', LocaleENUS},
+		{"decimal comma", []string{"10,5"}, ',', LocalePTBR},
+		{"decimal dot", []string{"10.50"}, ';', LocaleENUS},
+		{"ambiguous with semicolons", []string{"1.234"}, ';', LocalePTBR},
+		{"ambiguous with commas", []string{"1.234"}, ',', LocaleENUS},
+		{"first unambiguous sample wins", []string{"1.234", "5,00"}, ',', LocalePTBR},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectLocale(tt.values, tt.delimiter); got != tt.want {
+				t.Errorf("detectLocale = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTextReader(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		encoding string
+		want     string
+	}{
+		{"utf-8", "Padaria São João", EncodingAuto, "Padaria São João"},
+		{"utf-8 with BOM", "\xEF\xBB\xBFPadaria São João", EncodingAuto, "Padaria São João"},
+		{"windows-1252 detected", "Padaria S\xe3o Jo\xe3o \x80", EncodingAuto, "Padaria São João €"},
+		{"latin-1 forced", "Padaria S\xe3o Jo\xe3o", EncodingLatin1, "Padaria São João"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := newTextReader(strings.NewReader(tt.data), tt.encoding)
+			if err != nil {
+				t.Fatalf("newTextReader: %v", err)
+			}
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("ReadAll: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDelimiter(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    rune
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"auto", 0, false},
+		{"tab", '\t', false},
+		{";", ';', false},
+		{"\"", 0, true},
+		{";;", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDelimiter(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseDelimiter(%q) = %q, %v", tt.value, got, err)
+		}
+	}
+}
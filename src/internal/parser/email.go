@@ -0,0 +1,322 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+const maxEmailParts = 50
+
+var (
+	htmlHidden     = regexp.MustCompile(`(?is)<(style|script|head)[^>]*>.*?</(style|script|head)>`)
+	htmlLineBreaks = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|tr|li|h[1-6]|table)>`)
+	htmlTags       = regexp.MustCompile(`(?s)<[^>]*>`)
+	emailSpaces    = regexp.MustCompile(`[ \t\x{00a0}]+`)
+)
+
+// emailMessage is the part of a notification e-mail the templates read.
+// Senders has the From address and, for forwarded notifications, the ones
+// found in the forwarded headers or in an attached message.
+type emailMessage struct {
+	messageID string
+	subject   string
+	senders   []string
+	date      string
+	text      string
+}
+
+// ParseEmail reads purchase notification e-mails, one message (.eml) or
+// many (mbox), and turns the ones sent by a known bank template into
+// transactions. Other messages are reported as rejected. The Message-ID is
+// the external ID, so the same e-mail is never saved twice.
+func (s *service) ParseEmail(file io.Reader) (*ParseResult, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo: %w", err)
+	}
+
+	messages := splitMbox(data)
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("arquivo de e-mail vazio")
+	}
+
+	result := &ParseResult{
+		Profile: &ImportProfile{
+			Name:           "email",
+			Description:    "Notificações de compra recebidas por e-mail",
+			StatementKind:  StatementCreditCard,
+			SignConvention: SignDebitPositive,
+		},
+	}
+
+	for i, raw := range messages {
+		message, err := readEmail(raw)
+		if err != nil {
+			result.Rejected = append(result.Rejected, RejectedRow{
+				Line:   i + 1,
+				Raw:    firstLine(raw),
+				Reason: err.Error(),
+			})
+			continue
+		}
+
+		transaction, err := message.transaction()
+		if err != nil {
+			result.Rejected = append(result.Rejected, RejectedRow{
+				Line:   i + 1,
+				Raw:    message.subject,
+				Reason: err.Error(),
+			})
+			continue
+		}
+		result.Transactions = append(result.Transactions, *transaction)
+	}
+
+	return result, nil
+}
+
+// splitMbox splits an mbox file on its "From " separator lines and undoes
+// the ">From " quoting. Anything else is a single message.
+func splitMbox(data []byte) [][]byte {
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	data = bytes.TrimLeft(data, "\n")
+	if !bytes.HasPrefix(data, []byte("From ")) {
+		if len(bytes.TrimSpace(data)) == 0 {
+			return nil
+		}
+		return [][]byte{data}
+	}
+
+	var messages [][]byte
+	var current bytes.Buffer
+	flush := func() {
+		if len(bytes.TrimSpace(current.Bytes())) > 0 {
+			messages = append(messages, append([]byte(nil), current.Bytes()...))
+		}
+		current.Reset()
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "From ") {
+			flush()
+			continue
+		}
+		if strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") && strings.HasPrefix(line, ">") {
+			line = line[1:]
+		}
+		current.WriteString(line)
+		current.WriteByte('\n')
+	}
+	flush()
+
+	return messages
+}
+
+func readEmail(raw []byte) (*emailMessage, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("e-mail inválido: %w", err)
+	}
+
+	message := &emailMessage{
+		messageID: strings.Trim(strings.TrimSpace(msg.Header.Get("Message-Id")), "<>"),
+		subject:   decodeHeader(msg.Header.Get("Subject")),
+		date:      msg.Header.Get("Date"),
+	}
+	message.addSender(msg.Header.Get("From"))
+
+	var text strings.Builder
+	parts := 0
+	if err := message.walk(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body, &text, &parts); err != nil {
+		return nil, err
+	}
+	message.text = text.String()
+
+	return message, nil
+}
+
+func (m *emailMessage) addSender(from string) {
+	if address, err := mail.ParseAddress(decodeHeader(from)); err == nil {
+		m.senders = append(m.senders, strings.ToLower(address.Address))
+	}
+}
+
+// walk collects the text of the message. The plain text alternative is
+// preferred to the HTML one; attached messages (forwarded as attachment)
+// are read too.
+func (m *emailMessage) walk(contentType, encoding string, body io.Reader, text *strings.Builder, parts *int) error {
+	*parts++
+	if *parts > maxEmailParts {
+		return fmt.Errorf("e-mail com partes demais")
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/"):
+		reader := multipart.NewReader(body, params["boundary"])
+		var alternatives []string
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("e-mail inválido: %w", err)
+			}
+
+			var partText strings.Builder
+			if err := m.walk(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part, &partText, parts); err != nil {
+				return err
+			}
+
+			if mediaType == "multipart/alternative" {
+				alternatives = append(alternatives, partText.String())
+				continue
+			}
+			text.WriteString(partText.String())
+			text.WriteString("\n")
+		}
+		// The first alternative is the plain text one, when there is one.
+		for _, alternative := range alternatives {
+			if strings.TrimSpace(alternative) != "" {
+				text.WriteString(alternative)
+				break
+			}
+		}
+	case mediaType == "message/rfc822":
+		attached, err := mail.ReadMessage(decodeTransfer(body, encoding))
+		if err != nil {
+			return nil
+		}
+		m.addSender(attached.Header.Get("From"))
+		text.WriteString(decodeHeader(attached.Header.Get("Subject")))
+		text.WriteString("\n")
+		return m.walk(attached.Header.Get("Content-Type"), attached.Header.Get("Content-Transfer-Encoding"), attached.Body, text, parts)
+	case mediaType == "text/plain", mediaType == "text/html":
+		content, err := io.ReadAll(decodeTransfer(body, encoding))
+		if err != nil {
+			return fmt.Errorf("e-mail inválido: %w", err)
+		}
+		decoded := decodeCharset(content, params["charset"])
+		if mediaType == "text/html" {
+			decoded = htmlToText(decoded)
+		}
+		text.WriteString(normalizeEmailText(decoded))
+	}
+
+	return nil
+}
+
+func decodeTransfer(body io.Reader, encoding string) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, newBase64Cleaner(body))
+	}
+	return body
+}
+
+// base64Cleaner drops the line breaks of base64 bodies.
+type base64Cleaner struct {
+	r io.Reader
+}
+
+func newBase64Cleaner(r io.Reader) io.Reader {
+	return &base64Cleaner{r: r}
+}
+
+func (c *base64Cleaner) Read(p []byte) (int, error) {
+	for {
+		n, err := c.r.Read(p)
+		kept := 0
+		for _, b := range p[:n] {
+			if b != '\r' && b != '\n' && b != ' ' && b != '\t' {
+				p[kept] = b
+				kept++
+			}
+		}
+		if kept > 0 || err != nil {
+			return kept, err
+		}
+	}
+}
+
+func decodeCharset(content []byte, charset string) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1":
+		if decoded, err := charmap.ISO8859_1.NewDecoder().Bytes(content); err == nil {
+			return string(decoded)
+		}
+	case "windows-1252", "cp1252":
+		if decoded, err := charmap.Windows1252.NewDecoder().Bytes(content); err == nil {
+			return string(decoded)
+		}
+	}
+	return string(content)
+}
+
+// decodeHeader decodes RFC 2047 encoded words ("=?UTF-8?Q?Compra_aprovada?=").
+func decodeHeader(value string) string {
+	decoder := mime.WordDecoder{
+		CharsetReader: func(charset string, input io.Reader) (io.Reader, error) {
+			switch strings.ToLower(charset) {
+			case "iso-8859-1", "latin1":
+				return charmap.ISO8859_1.NewDecoder().Reader(input), nil
+			case "windows-1252", "cp1252":
+				return charmap.Windows1252.NewDecoder().Reader(input), nil
+			}
+			return nil, fmt.Errorf("charset não suportado: %s", charset)
+		},
+	}
+
+	decoded, err := decoder.DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return strings.TrimSpace(decoded)
+}
+
+func htmlToText(content string) string {
+	content = htmlHidden.ReplaceAllString(content, "")
+	content = htmlLineBreaks.ReplaceAllString(content, "\n")
+	content = htmlTags.ReplaceAllString(content, " ")
+	return html.UnescapeString(content)
+}
+
+// normalizeEmailText collapses the spaces of each line and drops the empty
+// ones, so templates can match sentences split over HTML cells.
+func normalizeEmailText(content string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(emailSpaces.ReplaceAllString(line, " "))
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func firstLine(raw []byte) string {
+	line, _, _ := bytes.Cut(bytes.TrimSpace(raw), []byte("\n"))
+	return strings.TrimSpace(string(line))
+}
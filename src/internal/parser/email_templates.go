@@ -0,0 +1,173 @@
+package parser
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// emailTemplate reads the purchase notifications of one bank. A message
+// uses the template when it was sent, or forwarded, from one of the sender
+// domains. Each field regexp has one or more groups; the first non-empty
+// one is the value. Amount and merchant are required, and messages matching
+// refund are credits.
+type emailTemplate struct {
+	bank     string
+	senders  []string
+	amount   *regexp.Regexp
+	merchant *regexp.Regexp
+	card     *regexp.Regexp
+	date     *regexp.Regexp
+	refund   *regexp.Regexp
+}
+
+var emailTemplates = []emailTemplate{
+	{
+		// "Compra de R$ 45,90 APROVADA em PADARIA REAL para o cartão com
+		// final 1234", the newer "Sua compra de R$ 45,90 em PADARIA REAL
+		// foi aprovada" and "Você fez uma transferência de R$ 50,00 para
+		// FULANO".
+		bank:     "Nubank",
+		senders:  []string{"nubank.com.br"},
+		amount:   regexp.MustCompile(`(?i)(?:compra|estorno|transferência|pix) de R\$ ?([\d.]+,\d{2})`),
+		merchant: regexp.MustCompile(`(?i)(?:compra|estorno) de R\$ ?[\d.]+,\d{2}(?: aprovada)? em (.+?)(?: foi | para o cartão| no cartão|\. |[.,]?\n)|(?:transferência|pix) de R\$ ?[\d.]+,\d{2} (?:via pix )?para (.+?)(?: foi | com sucesso|\. |[.,]?\n)`),
+		card:     regexp.MustCompile(`(?i)final (\d{4})`),
+		refund:   regexp.MustCompile(`(?i)estorno`),
+	},
+	{
+		// "Compra aprovada no seu cartão final 1234 em 15/01/2025 às
+		// 10:32, no valor de R$ 99,90, em LOJA X."
+		bank:     "Itaú",
+		senders:  []string{"itau.com.br", "itau-unibanco.com.br"},
+		amount:   regexp.MustCompile(`(?i)valor de R\$ ?([\d.]+,\d{2})`),
+		merchant: regexp.MustCompile(`(?i)valor de R\$ ?[\d.]+,\d{2},? (?:em|no estabelecimento|no local) (.+?)(?:\. |[.,]?\n)`),
+		card:     regexp.MustCompile(`(?i)final (\d{4})`),
+		date:     regexp.MustCompile(`(\d{2}/\d{2}/\d{4})(?:,? (?:às )?(\d{2}[:h]\d{2}))?`),
+		refund:   regexp.MustCompile(`(?i)estorno`),
+	},
+	{
+		// One field per line: "Valor: R$ 50,00", "Estabelecimento: XPTO",
+		// "Data: 15/01/2025 10:32", "Cartão final: 1234".
+		bank:     "Inter",
+		senders:  []string{"bancointer.com.br", "inter.co"},
+		amount:   regexp.MustCompile(`(?im)^valor(?: da compra)?:? R\$ ?([\d.]+,\d{2})`),
+		merchant: regexp.MustCompile(`(?im)^(?:estabelecimento|local):? (.+)$`),
+		card:     regexp.MustCompile(`(?i)final:? (\d{4})`),
+		date:     regexp.MustCompile(`(?im)^data(?: da compra)?:? (\d{2}/\d{2}/\d{4})(?:,? (?:às )?(\d{2}[:h]\d{2}))?`),
+		refund:   regexp.MustCompile(`(?i)estorno`),
+	},
+	{
+		// "Compra de R$ 20,00 aprovada em MERCADO X no cartão final 1234".
+		bank:     "C6 Bank",
+		senders:  []string{"c6bank.com.br"},
+		amount:   regexp.MustCompile(`(?i)(?:compra|estorno) (?:aprovada )?(?:de|no valor de) R\$ ?([\d.]+,\d{2})`),
+		merchant: regexp.MustCompile(`(?i)R\$ ?[\d.]+,\d{2}(?: aprovada)? em (.+?)(?: no cartão| com o cartão| foi |\. |[.,]?\n)`),
+		card:     regexp.MustCompile(`(?i)final (\d{4})`),
+		refund:   regexp.MustCompile(`(?i)estorno`),
+	},
+}
+
+func (m *emailMessage) template() *emailTemplate {
+	for i := range emailTemplates {
+		template := &emailTemplates[i]
+		for _, domain := range template.senders {
+			for _, sender := range m.senders {
+				if strings.HasSuffix(sender, "@"+domain) || strings.HasSuffix(sender, "."+domain) {
+					return template
+				}
+			}
+			// Forwarded inline, the original sender is only in the text.
+			if strings.Contains(strings.ToLower(m.text), "@"+domain) {
+				return template
+			}
+		}
+	}
+	return nil
+}
+
+func (m *emailMessage) transaction() (*Transaction, error) {
+	template := m.template()
+	if template == nil {
+		return nil, fmt.Errorf("remetente não é um modelo de notificação conhecido (%s)", strings.Join(m.senders, ", "))
+	}
+
+	content := m.subject + "\n" + m.text
+
+	amountText := firstGroup(template.amount, content)
+	if amountText == "" {
+		return nil, fmt.Errorf("e-mail do %s sem valor de compra reconhecido", template.bank)
+	}
+	amount, err := parseAmount(amountText, LocalePTBR)
+	if err != nil {
+		return nil, err
+	}
+
+	merchant := strings.TrimSpace(firstGroup(template.merchant, content))
+	if merchant == "" {
+		return nil, fmt.Errorf("e-mail do %s sem estabelecimento reconhecido", template.bank)
+	}
+
+	date, err := m.transactionDate(template, content)
+	if err != nil {
+		return nil, err
+	}
+
+	if template.refund != nil && template.refund.MatchString(content) {
+		amount = -amount
+	}
+
+	account := template.bank
+	if card := firstGroup(template.card, content); card != "" {
+		account += " final " + card
+	}
+
+	return &Transaction{
+		Date:        date,
+		Description: merchant,
+		Amount:      amount,
+		ExternalID:  m.messageID,
+		Account:     account,
+	}, nil
+}
+
+// transactionDate is the purchase date in the text or, for templates that
+// do not print it, the date the e-mail was sent. Either is kept as the
+// local wall clock time, so a purchase late at night stays on its day.
+func (m *emailMessage) transactionDate(template *emailTemplate, content string) (time.Time, error) {
+	if template.date != nil {
+		if match := template.date.FindStringSubmatch(content); match != nil {
+			layout, value := "02/01/2006", match[1]
+			if len(match) > 2 && match[2] != "" {
+				layout, value = "02/01/2006 15:04", value+" "+strings.Replace(match[2], "h", ":", 1)
+			}
+			if date, err := time.Parse(layout, value); err == nil {
+				return date, nil
+			}
+		}
+	}
+
+	sent, err := mail.ParseDate(m.date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("e-mail sem data válida: %q", m.date)
+	}
+
+	return time.Date(sent.Year(), sent.Month(), sent.Day(), sent.Hour(), sent.Minute(), sent.Second(), 0, time.UTC), nil
+}
+
+func firstGroup(re *regexp.Regexp, content string) string {
+	if re == nil {
+		return ""
+	}
+	match := re.FindStringSubmatch(content)
+	if match == nil {
+		return ""
+	}
+	for _, group := range match[1:] {
+		if group != "" {
+			return group
+		}
+	}
+	return ""
+}
@@ -1,25 +1,34 @@
 package parser
 
 import (
+	"errors"
+	"fmt"
+	"mime/multipart"
 	"net/http"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
 type Handler struct {
 	service            Service
 	integrationService IntegrationService
+	mappingService     MappingService
 }
 
 func NewHandler(service Service) *Handler {
 	return &Handler{service: service}
 }
 
-func NewIntegrationHandler(service Service, integrationService IntegrationService) *Handler {
+func NewIntegrationHandler(service Service, integrationService IntegrationService, mappingService MappingService) *Handler {
 	return &Handler{
 		service:            service,
 		integrationService: integrationService,
+		mappingService:     mappingService,
 	}
 }
 
@@ -35,42 +44,193 @@ func (h *Handler) GetUserID(c *gin.Context) string {
 // @Produce json
 // @Security BearerAuth
 // @Param file formData file true "CSV file"
+// @Param profile formData string false "Perfil do banco (ex.: nubank_conta, inter_conta); detectado automaticamente se vazio"
+// @Param mapping_id formData string false "ID de um mapeamento de colunas salvo (substitui a detecção automática)"
+// @Param delimiter formData string false "Delimitador (auto, ',', ';', tab, '|')"
+// @Param encoding formData string false "Encoding (auto, utf-8, windows-1252, iso-8859-1)"
+// @Param locale formData string false "Formato dos valores (auto, pt-BR, en-US)"
+// @Param strict formData bool false "Rejeita o arquivo inteiro se alguma linha for inválida"
+// @Param statement_kind formData string false "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos"
+// @Param sign_convention formData string false "Convenção de sinal (auto, debit_positive, debit_negative)"
 // @Success 200 {object} parser.ImportAndSaveResponse
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]interface{}
 // @Failure 500 {object} map[string]string
 // @Router /parser/upload/csv [post]
 func (h *Handler) UploadCSV(c *gin.Context) {
-	if h.integrationService == nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Serviço de integração não disponível",
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	f, filename, ok := h.openUpload(c, "CSV", []string{"text/csv"}, ".csv")
+	if !ok {
+		return
+	}
+	defer f.Close()
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Usuário não autenticado",
 		})
 		return
 	}
 
-	file, err := c.FormFile("file")
+	opts, err := h.csvOptionsFromForm(c)
+	if err != nil {
+		respondOptionsError(c, err)
+		return
+	}
+
+	importOpts, err := importOptionsFromForm(c, filename)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Arquivo não encontrado. Use o campo 'file' no form-data",
+			"error": err.Error(),
 		})
 		return
 	}
 
-	if file.Header.Get("Content-Type") != "text/csv" &&
-		!strings.HasSuffix(file.Filename, ".csv") {
+	result, err := h.integrationService.ProcessAndSaveCSV(userID, f, opts, importOpts)
+	if err != nil {
+		respondImportError(c, "CSV", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UploadOFX godoc
+// @Summary Upload OFX e salvar automaticamente
+// @Description Faz upload de um extrato OFX (1.x SGML ou 2.x XML), categoriza e salva as transações automaticamente
+// @Tags parser
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "OFX file"
+// @Param strict formData bool false "Rejeita o arquivo inteiro se alguma transação for inválida"
+// @Param statement_kind formData string false "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos"
+// @Param sign_convention formData string false "Convenção de sinal (auto, debit_positive, debit_negative)"
+// @Success 200 {object} parser.ImportAndSaveResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /parser/upload/ofx [post]
+func (h *Handler) UploadOFX(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	f, filename, ok := h.openUpload(c, "OFX", []string{"application/x-ofx", "application/ofx"}, ".ofx")
+	if !ok {
+		return
+	}
+	defer f.Close()
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Usuário não autenticado",
+		})
+		return
+	}
+
+	importOpts, err := importOptionsFromForm(c, filename)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Apenas arquivos CSV são aceitos",
+			"error": err.Error(),
 		})
 		return
 	}
 
-	f, err := file.Open()
+	result, err := h.integrationService.ProcessAndSaveOFX(userID, f, importOpts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Erro ao abrir arquivo",
+		respondImportError(c, "OFX", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UploadCAMT053 godoc
+// @Summary Upload CAMT.053 e salvar automaticamente
+// @Description Faz upload de um extrato ISO 20022 CAMT.053 (XML), categoriza e salva os lançamentos contabilizados automaticamente. A referência de cada lançamento é usada para evitar duplicatas
+// @Tags parser
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CAMT.053 XML file"
+// @Param strict formData bool false "Rejeita o arquivo inteiro se algum lançamento for inválido"
+// @Param statement_kind formData string false "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos"
+// @Param sign_convention formData string false "Convenção de sinal (auto, debit_positive, debit_negative)"
+// @Success 200 {object} parser.ImportAndSaveResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /parser/upload/camt053 [post]
+func (h *Handler) UploadCAMT053(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	f, filename, ok := h.openUpload(c, "CAMT.053 (XML)", []string{"application/xml", "text/xml"}, ".xml")
+	if !ok {
+		return
+	}
+	defer f.Close()
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Usuário não autenticado",
+		})
+		return
+	}
+
+	importOpts, err := importOptionsFromForm(c, filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
 		})
 		return
 	}
+
+	result, err := h.integrationService.ProcessAndSaveCAMT053(userID, f, importOpts)
+	if err != nil {
+		respondImportError(c, "CAMT.053", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UploadEmail godoc
+// @Summary Upload de e-mails de notificação de compra
+// @Description Faz upload de um e-mail (.eml) ou de uma caixa exportada (.mbox) com as notificações de compra enviadas pelo banco (Nubank, Itaú, Inter e C6), inclusive encaminhadas. Cada notificação vira uma transação categorizada e salva; o Message-ID evita salvar o mesmo e-mail duas vezes. E-mails que não são notificações conhecidas voltam em rejected_rows
+// @Tags parser
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Arquivo .eml ou .mbox"
+// @Param strict formData bool false "Rejeita o arquivo inteiro se algum e-mail não for reconhecido"
+// @Success 200 {object} parser.ImportAndSaveResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /parser/upload/email [post]
+func (h *Handler) UploadEmail(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	f, filename, ok := h.openUpload(c, "de e-mail (.eml ou .mbox)", []string{"message/rfc822", "application/mbox"}, ".eml", ".mbox")
+	if !ok {
+		return
+	}
 	defer f.Close()
 
 	userID := c.GetString("user_id")
@@ -81,13 +241,397 @@ func (h *Handler) UploadCSV(c *gin.Context) {
 		return
 	}
 
-	result, err := h.integrationService.ProcessAndSaveCSV(userID, f)
+	importOpts, err := importOptionsFromForm(c, filename)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Erro ao processar e salvar CSV: " + err.Error(),
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
 		})
 		return
 	}
 
+	result, err := h.integrationService.ProcessAndSaveEmail(userID, f, importOpts)
+	if err != nil {
+		respondImportError(c, "e-mail", err)
+		return
+	}
+
 	c.JSON(http.StatusOK, result)
 }
+
+// UploadPDF godoc
+// @Summary Upload de fatura PDF e salvar automaticamente
+// @Description Faz upload da fatura do cartão em PDF (layout Nubank), extrai compras, parcelas e IOF, categoriza e salva as transações automaticamente
+// @Tags parser
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "PDF file"
+// @Param strict formData bool false "Rejeita o arquivo inteiro se alguma linha for inválida"
+// @Param statement_kind formData string false "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos"
+// @Param sign_convention formData string false "Convenção de sinal (auto, debit_positive, debit_negative)"
+// @Success 200 {object} parser.ImportAndSaveResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /parser/upload/pdf [post]
+func (h *Handler) UploadPDF(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	f, filename, ok := h.openUpload(c, "PDF", []string{"application/pdf"}, ".pdf")
+	if !ok {
+		return
+	}
+	defer f.Close()
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Usuário não autenticado",
+		})
+		return
+	}
+
+	importOpts, err := importOptionsFromForm(c, filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.integrationService.ProcessAndSavePDF(userID, f, importOpts)
+	if err != nil {
+		respondImportError(c, "PDF", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UploadXLSX godoc
+// @Summary Upload de planilha XLSX e salvar automaticamente
+// @Description Faz upload de uma planilha Excel (.xlsx), detecta as colunas como no CSV, categoriza e salva as transações automaticamente
+// @Tags parser
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "XLSX file"
+// @Param sheet formData string false "Nome ou número (a partir de 1) da planilha; padrão é a primeira"
+// @Param header_row formData int false "Número da linha do cabeçalho; detectado automaticamente se vazio"
+// @Param profile formData string false "Perfil do banco (ex.: nubank_conta, inter_conta); detectado automaticamente se vazio"
+// @Param mapping_id formData string false "ID de um mapeamento de colunas salvo (substitui a detecção automática)"
+// @Param locale formData string false "Formato dos valores em células de texto (auto, pt-BR, en-US)"
+// @Param strict formData bool false "Rejeita o arquivo inteiro se alguma linha for inválida"
+// @Param statement_kind formData string false "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos"
+// @Param sign_convention formData string false "Convenção de sinal (auto, debit_positive, debit_negative)"
+// @Success 200 {object} parser.ImportAndSaveResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /parser/upload/xlsx [post]
+func (h *Handler) UploadXLSX(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	f, filename, ok := h.openUpload(c, "XLSX", []string{xlsxContentType}, ".xlsx")
+	if !ok {
+		return
+	}
+	defer f.Close()
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Usuário não autenticado",
+		})
+		return
+	}
+
+	opts, err := h.xlsxOptionsFromForm(c)
+	if err != nil {
+		respondOptionsError(c, err)
+		return
+	}
+
+	importOpts, err := importOptionsFromForm(c, filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.integrationService.ProcessAndSaveXLSX(userID, f, opts, importOpts)
+	if err != nil {
+		respondImportError(c, "XLSX", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// MigrateFrom godoc
+// @Summary Migrar lançamentos de outro app
+// @Description Importa a exportação de outro app de finanças (QIF, Mobills, Organizze, GnuCash ou Money Lover) mantendo as categorias, contas e tags do app de origem. Só os lançamentos sem categoria são categorizados automaticamente
+// @Tags parser
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param app path string true "App de origem (qif, mobills, organizze, gnucash, moneylover)"
+// @Param file formData file true "Arquivo exportado (CSV, XLSX ou QIF, conforme o app)"
+// @Param strict formData bool false "Rejeita o arquivo inteiro se alguma linha for inválida"
+// @Param statement_kind formData string false "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos"
+// @Param sign_convention formData string false "Convenção de sinal (auto, debit_positive, debit_negative)"
+// @Success 200 {object} parser.ImportAndSaveResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /parser/migrate/{app} [post]
+func (h *Handler) MigrateFrom(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	app, ok := FindMigrationApp(c.Param("app"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "App de origem desconhecido: " + c.Param("app"),
+		})
+		return
+	}
+
+	extension := "." + app.Formats[0]
+	if header, err := c.FormFile("file"); err == nil && app.Accepts(filepath.Ext(header.Filename)) {
+		extension = strings.ToLower(filepath.Ext(header.Filename))
+	}
+
+	f, filename, ok := h.openUpload(c, strings.ToUpper(strings.Join(app.Formats, ", ")), nil, extension)
+	if !ok {
+		return
+	}
+	defer f.Close()
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Usuário não autenticado",
+		})
+		return
+	}
+
+	importOpts, err := importOptionsFromForm(c, filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.integrationService.ProcessAndSaveMigration(userID, app, f, importOpts)
+	if err != nil {
+		respondImportError(c, app.Label, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ListMigrationApps godoc
+// @Summary Listar apps de origem para migração
+// @Description Lista os apps de finanças cujas exportações podem ser importadas e os formatos de arquivo aceitos de cada um
+// @Tags parser
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Router /parser/migrate [get]
+func (h *Handler) ListMigrationApps(c *gin.Context) {
+	apps := MigrationApps()
+
+	c.JSON(http.StatusOK, gin.H{
+		"apps":  apps,
+		"count": len(apps),
+	})
+}
+
+// ListProfiles godoc
+// @Summary Listar perfis de importação
+// @Description Lista os layouts de extrato reconhecidos na importação de CSV e a convenção de sinal de cada um
+// @Tags parser
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Router /parser/profiles [get]
+func (h *Handler) ListProfiles(c *gin.Context) {
+	profiles := h.service.Profiles()
+
+	c.JSON(http.StatusOK, gin.H{
+		"profiles": profiles,
+		"count":    len(profiles),
+	})
+}
+
+func (h *Handler) requireIntegration(c *gin.Context) bool {
+	if h.integrationService == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Serviço de integração não disponível",
+		})
+		return false
+	}
+	return true
+}
+
+func (h *Handler) openUpload(c *gin.Context, format string, contentTypes []string, extensions ...string) (multipart.File, string, bool) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Arquivo não encontrado. Use o campo 'file' no form-data",
+		})
+		return nil, "", false
+	}
+
+	accepted := false
+	for _, extension := range extensions {
+		if strings.HasSuffix(strings.ToLower(file.Filename), extension) {
+			accepted = true
+		}
+	}
+	for _, contentType := range contentTypes {
+		if file.Header.Get("Content-Type") == contentType {
+			accepted = true
+		}
+	}
+
+	if !accepted {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Apenas arquivos " + format + " são aceitos",
+		})
+		return nil, "", false
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erro ao abrir arquivo",
+		})
+		return nil, "", false
+	}
+
+	return f, file.Filename, true
+}
+
+func (h *Handler) csvOptionsFromForm(c *gin.Context) (CSVOptions, error) {
+	opts, err := h.tableOptionsFromForm(c)
+	if err != nil {
+		return opts, err
+	}
+
+	if opts.Delimiter, err = ParseDelimiter(c.PostForm("delimiter")); err != nil {
+		return opts, err
+	}
+
+	if opts.Encoding, err = ParseEncoding(c.PostForm("encoding")); err != nil {
+		return opts, err
+	}
+
+	return opts, nil
+}
+
+func (h *Handler) xlsxOptionsFromForm(c *gin.Context) (XLSXOptions, error) {
+	opts := XLSXOptions{Sheet: strings.TrimSpace(c.PostForm("sheet"))}
+	var err error
+
+	if opts.CSVOptions, err = h.tableOptionsFromForm(c); err != nil {
+		return opts, err
+	}
+
+	if headerRow := strings.TrimSpace(c.PostForm("header_row")); headerRow != "" {
+		opts.HeaderRow, err = strconv.Atoi(headerRow)
+		if err != nil || opts.HeaderRow < 1 {
+			return opts, fmt.Errorf("valor inválido para header_row: %s", headerRow)
+		}
+	}
+
+	return opts, nil
+}
+
+// tableOptionsFromForm reads the options shared by every tabular format:
+// profile or saved mapping, and the amount locale.
+func (h *Handler) tableOptionsFromForm(c *gin.Context) (CSVOptions, error) {
+	opts := CSVOptions{Profile: strings.TrimSpace(c.PostForm("profile"))}
+	var err error
+
+	if mappingID := strings.TrimSpace(c.PostForm("mapping_id")); mappingID != "" {
+		if opts.Profile != "" {
+			return opts, fmt.Errorf("use profile ou mapping_id, não ambos")
+		}
+		if h.mappingService == nil {
+			return opts, fmt.Errorf("mapeamentos de colunas não disponíveis")
+		}
+		if opts.Mapping, err = h.mappingService.Get(c.GetString("user_id"), mappingID); err != nil {
+			return opts, err
+		}
+	}
+
+	if opts.Locale, err = ParseLocale(c.PostForm("locale")); err != nil {
+		return opts, err
+	}
+
+	return opts, nil
+}
+
+func importOptionsFromForm(c *gin.Context, filename string) (ImportOptions, error) {
+	opts := ImportOptions{Filename: filename}
+
+	if strict := c.PostForm("strict"); strict != "" {
+		value, err := strconv.ParseBool(strict)
+		if err != nil {
+			return opts, fmt.Errorf("valor inválido para strict: %s", strict)
+		}
+		opts.Strict = value
+	}
+
+	var err error
+	if opts.StatementKind, err = ParseStatementKind(c.PostForm("statement_kind")); err != nil {
+		return opts, err
+	}
+
+	if opts.SignConvention, err = ParseSignConvention(c.PostForm("sign_convention")); err != nil {
+		return opts, err
+	}
+
+	return opts, nil
+}
+
+func respondOptionsError(c *gin.Context, err error) {
+	status := http.StatusBadRequest
+	if errors.Is(err, ErrColumnMappingNotFound) {
+		status = http.StatusNotFound
+	}
+
+	c.JSON(status, gin.H{
+		"error": err.Error(),
+	})
+}
+
+func respondImportError(c *gin.Context, format string, err error) {
+	var rejectedErr *RejectedRowsError
+	if errors.As(err, &rejectedErr) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":         rejectedErr.Error(),
+			"rejected_rows": rejectedErr.Rows,
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "Erro ao processar e salvar " + format + ": " + err.Error(),
+	})
+}
@@ -0,0 +1,457 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gastei-quanto/src/internal/expense"
+)
+
+const (
+	headerSearchRows = 20
+	detectSampleRows = 20
+)
+
+var installmentColumn = regexp.MustCompile(`(\d{1,2})\s*/\s*(\d{1,2})`)
+
+// Importer turns the rows of a tabular statement into transactions. Detect
+// scores how well a header row and the rows below it match the importer's
+// layout; zero means the importer does not apply.
+type Importer interface {
+	Profile() ImportProfile
+	Detect(header []string, sample [][]string) int
+	Parse(table *Table, opts CSVOptions) *ParseResult
+}
+
+// Table is a statement already split into records, with the header row
+// located. Rows keep their line number and raw text for rejection reports.
+type Table struct {
+	Header    []string
+	Rows      []TableRow
+	Delimiter rune
+}
+
+type TableRow struct {
+	Line   int
+	Raw    string
+	Record []string
+}
+
+type ImporterRegistry struct {
+	importers []Importer
+}
+
+func NewImporterRegistry(importers ...Importer) *ImporterRegistry {
+	return &ImporterRegistry{importers: importers}
+}
+
+func (r *ImporterRegistry) Register(importer Importer) {
+	r.importers = append(r.importers, importer)
+}
+
+func (r *ImporterRegistry) Get(name string) (Importer, bool) {
+	for _, importer := range r.importers {
+		if importer.Profile().Name == name {
+			return importer, true
+		}
+	}
+	return nil, false
+}
+
+func (r *ImporterRegistry) Profiles() []ImportProfile {
+	profiles := make([]ImportProfile, len(r.importers))
+	for i, importer := range r.importers {
+		profiles[i] = importer.Profile()
+	}
+	return profiles
+}
+
+// Select looks for the header among the first searchRows rows (bank exports
+// often start with a few lines about the account) and returns the best
+// scoring importer and the index of its header row. Ties go to the importer
+// registered first.
+func (r *ImporterRegistry) Select(rows []TableRow, forced string, searchRows int) (Importer, int, error) {
+	candidates := r.importers
+	if forced != "" {
+		importer, ok := r.Get(forced)
+		if !ok {
+			return nil, 0, fmt.Errorf("perfil de importação desconhecido: %s", forced)
+		}
+		candidates = []Importer{importer}
+	}
+
+	var best Importer
+	bestScore, bestHeader := 0, 0
+
+	for i := 0; i < len(rows) && i < searchRows; i++ {
+		sample := make([][]string, 0, detectSampleRows)
+		for _, row := range rows[i+1:] {
+			if len(sample) == detectSampleRows {
+				break
+			}
+			sample = append(sample, row.Record)
+		}
+
+		for _, importer := range candidates {
+			if score := importer.Detect(rows[i].Record, sample); score > bestScore {
+				best, bestScore, bestHeader = importer, score, i
+			}
+		}
+	}
+
+	if best == nil {
+		if forced != "" {
+			return nil, 0, fmt.Errorf("o arquivo não corresponde ao perfil %s", forced)
+		}
+		return nil, 0, fmt.Errorf("colunas obrigatórias não encontradas (date, amount)")
+	}
+
+	return best, bestHeader, nil
+}
+
+// columnLayout describes a statement by the header names of its columns.
+// Every entry is a list of aliases; headers are compared after removing
+// accents, case and punctuation, so "Valor (R$)" matches "valor r$".
+type columnLayout struct {
+	profile ImportProfile
+
+	// signature lists the columns that must all be present for the layout
+	// to be detected. Each signature column is worth more than any optional
+	// one, so the most specific layout wins.
+	signature [][]string
+
+	date        []string
+	description []string
+	details     []string
+	category    []string
+	amount      []string
+	credit      []string
+	debit       []string
+	externalID  []string
+	installment []string
+
+	// Foreign purchases: the currency, the amount in that currency and the
+	// exchange rate. defaultCurrency is used when the layout has no currency
+	// column, as in C6's "Valor (em US$)".
+	currency        []string
+	originalAmount  []string
+	exchangeRate    []string
+	defaultCurrency string
+
+	// Exports of other finance apps also carry the account, the subcategory
+	// (saved as "Categoria:Subcategoria", as QIF writes it) and tags. Rows
+	// whose status is one of skipStatuses, such as scheduled entries not yet
+	// paid, are left out.
+	account      []string
+	subcategory  []string
+	tags         []string
+	status       []string
+	skipStatuses []string
+
+	dateFormats     []string
+	skipBalanceRows bool
+}
+
+type layoutColumns struct {
+	date, description, details, category, amount, credit, debit, externalID, installment int
+	currency, originalAmount, exchangeRate                                               int
+	account, subcategory, tags, status                                                   int
+}
+
+func (l *columnLayout) Profile() ImportProfile {
+	return l.profile
+}
+
+func (l *columnLayout) columns(header []string) layoutColumns {
+	return layoutColumns{
+		date:        findColumn(header, l.date...),
+		description: findColumn(header, l.description...),
+		details:     findColumn(header, l.details...),
+		category:    findColumn(header, l.category...),
+		amount:      findColumn(header, l.amount...),
+		credit:      findColumn(header, l.credit...),
+		debit:       findColumn(header, l.debit...),
+		externalID:  findColumn(header, l.externalID...),
+		installment: findColumn(header, l.installment...),
+
+		currency:       findColumn(header, l.currency...),
+		originalAmount: findColumn(header, l.originalAmount...),
+		exchangeRate:   findColumn(header, l.exchangeRate...),
+
+		account:     findColumn(header, l.account...),
+		subcategory: findColumn(header, l.subcategory...),
+		tags:        findColumn(header, l.tags...),
+		status:      findColumn(header, l.status...),
+	}
+}
+
+func (l *columnLayout) Detect(header []string, sample [][]string) int {
+	for _, aliases := range l.signature {
+		if findColumn(header, aliases...) == -1 {
+			return 0
+		}
+	}
+
+	cols := l.columns(header)
+	if cols.date == -1 || (cols.amount == -1 && cols.credit == -1 && cols.debit == -1) {
+		return 0
+	}
+
+	score := 10 * len(l.signature)
+	for _, idx := range []int{cols.description, cols.details, cols.category, cols.externalID, cols.installment} {
+		if idx >= 0 {
+			score++
+		}
+	}
+
+	if len(sample) == 0 {
+		return score
+	}
+
+	valid := 0
+	for _, record := range sample {
+		if cols.date < len(record) {
+			if _, err := parseDateWith(record[cols.date], l.dateFormats); err == nil {
+				valid++
+			}
+		}
+	}
+
+	if valid == 0 {
+		return 0
+	}
+
+	return score + min(valid, 5)
+}
+
+func (l *columnLayout) Parse(table *Table, opts CSVOptions) *ParseResult {
+	cols := l.columns(table.Header)
+	result := &ParseResult{}
+
+	locale := opts.Locale
+	if locale == LocaleAuto {
+		var samples []string
+		for _, row := range table.Rows {
+			for _, idx := range []int{cols.amount, cols.credit, cols.debit} {
+				if idx >= 0 && idx < len(row.Record) && strings.TrimSpace(row.Record[idx]) != "" {
+					samples = append(samples, row.Record[idx])
+				}
+			}
+		}
+		locale = detectLocale(samples, table.Delimiter)
+	}
+	result.Locale = locale
+
+	field := func(record []string, idx int) string {
+		if idx >= 0 && idx < len(record) {
+			return strings.TrimSpace(record[idx])
+		}
+		return ""
+	}
+
+	for _, row := range table.Rows {
+		record := row.Record
+		reject := func(reason string) {
+			result.Rejected = append(result.Rejected, RejectedRow{
+				Line:   row.Line,
+				Raw:    row.Raw,
+				Reason: reason,
+			})
+		}
+
+		description := field(record, cols.description)
+		if details := field(record, cols.details); details != "" {
+			if description != "" {
+				description += " - " + details
+			} else {
+				description = details
+			}
+		}
+
+		if l.skipBalanceRows && isBalanceRow(description, field(record, cols.date)) {
+			continue
+		}
+
+		if l.skipsStatus(field(record, cols.status)) {
+			continue
+		}
+
+		if len(record) <= cols.date || (cols.amount >= 0 && len(record) <= cols.amount) {
+			reject("colunas obrigatórias ausentes (date, amount)")
+			continue
+		}
+
+		date, err := parseDateWith(record[cols.date], l.dateFormats)
+		if err != nil {
+			reject(err.Error())
+			continue
+		}
+
+		amount, err := l.signedAmount(record, cols, locale)
+		if err != nil {
+			reject(err.Error())
+			continue
+		}
+
+		if match := installmentColumn.FindStringSubmatch(field(record, cols.installment)); match != nil {
+			description = fmt.Sprintf("%s - Parcela %s/%s", description, match[1], match[2])
+		}
+
+		category := field(record, cols.category)
+		if subcategory := field(record, cols.subcategory); subcategory != "" {
+			if category != "" {
+				category += ":" + subcategory
+			} else {
+				category = subcategory
+			}
+		}
+
+		if description == "" {
+			description = category
+		}
+
+		t := Transaction{
+			Date:        date,
+			Category:    category,
+			Description: description,
+			Amount:      amount,
+			ExternalID:  field(record, cols.externalID),
+			Account:     field(record, cols.account),
+			Tags:        splitTags(field(record, cols.tags)),
+		}
+		l.readOriginal(&t, record, cols, locale)
+
+		result.Transactions = append(result.Transactions, t)
+	}
+
+	return result
+}
+
+func (l *columnLayout) skipsStatus(status string) bool {
+	if status == "" {
+		return false
+	}
+	normalized := expense.NormalizeDescription(status)
+	for _, skip := range l.skipStatuses {
+		if normalized == expense.NormalizeDescription(skip) {
+			return true
+		}
+	}
+	return false
+}
+
+// readOriginal fills the foreign currency columns. A blank or unreadable
+// original amount only means the purchase was made in reais.
+func (l *columnLayout) readOriginal(t *Transaction, record []string, cols layoutColumns, locale Locale) {
+	if cols.originalAmount < 0 || cols.originalAmount >= len(record) {
+		return
+	}
+
+	original, err := parseAmount(record[cols.originalAmount], locale)
+	if err != nil || original == 0 {
+		return
+	}
+
+	currency := l.defaultCurrency
+	if cols.currency >= 0 && cols.currency < len(record) && strings.TrimSpace(record[cols.currency]) != "" {
+		currency = record[cols.currency]
+	}
+
+	var rate float64
+	if cols.exchangeRate >= 0 && cols.exchangeRate < len(record) {
+		rate, _ = parseAmount(record[cols.exchangeRate], locale)
+	}
+
+	t.setOriginal(currency, original, rate)
+}
+
+// signedAmount reads the single amount column or, for layouts with separate credit
+// and debit columns, combines them into one signed value (debits negative).
+func (l *columnLayout) signedAmount(record []string, cols layoutColumns, locale Locale) (float64, error) {
+	if cols.amount >= 0 {
+		return parseAmount(record[cols.amount], locale)
+	}
+
+	var credit, debit float64
+	var found bool
+
+	if cols.credit >= 0 && cols.credit < len(record) && strings.TrimSpace(record[cols.credit]) != "" {
+		value, err := parseAmount(record[cols.credit], locale)
+		if err != nil {
+			return 0, err
+		}
+		credit, found = value, true
+	}
+
+	if cols.debit >= 0 && cols.debit < len(record) && strings.TrimSpace(record[cols.debit]) != "" {
+		value, err := parseAmount(record[cols.debit], locale)
+		if err != nil {
+			return 0, err
+		}
+		if value < 0 {
+			value = -value
+		}
+		debit, found = value, true
+	}
+
+	if !found {
+		return 0, fmt.Errorf("valor ausente (crédito e débito vazios)")
+	}
+
+	return credit - debit, nil
+}
+
+// isBalanceRow reports informational rows bank statements mix with the
+// entries, such as "SALDO ANTERIOR" or a closing "Total".
+func isBalanceRow(description, date string) bool {
+	normalized := expense.NormalizeDescription(description)
+	if strings.HasPrefix(normalized, "saldo") || strings.HasPrefix(normalized, "s a l d o") || normalized == "total" {
+		return true
+	}
+	return description == "" && date == ""
+}
+
+// parseDateWith tries the layout's own formats and then ISO dates, which is
+// how date cells of spreadsheets are read.
+func parseDateWith(value string, formats []string) (time.Time, error) {
+	if len(formats) == 0 {
+		return parseDate(value)
+	}
+
+	value = strings.TrimSpace(value)
+	for _, format := range formats {
+		if date, err := time.Parse(format, value); err == nil {
+			return date, nil
+		}
+	}
+
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+
+	return time.Time{}, fmt.Errorf("formato de data inválido: %s", value)
+}
+
+func findColumn(header []string, names ...string) int {
+	for i, col := range header {
+		normalized := expense.NormalizeDescription(col)
+		if normalized == "" {
+			continue
+		}
+		for _, name := range names {
+			if normalized == expense.NormalizeDescription(name) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func sortRejected(rows []RejectedRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].Line < rows[j].Line
+	})
+}
@@ -1,37 +1,85 @@
 package parser
 
 import (
+	"context"
 	"fmt"
 	"gastei-quanto/src/internal/analysis"
+	"gastei-quanto/src/internal/categorizer"
 	"gastei-quanto/src/internal/expense"
+	"gastei-quanto/src/internal/rule"
 	"io"
 	"log"
+	"path/filepath"
 	"strings"
+	"sync"
 )
 
 type IntegrationService interface {
-	ProcessAndSaveCSV(userID string, file io.Reader) (*ImportAndSaveResponse, error)
+	ProcessAndSaveCSV(userID string, file io.Reader, csvOpts CSVOptions, opts ImportOptions) (*ImportAndSaveResponse, error)
+	ProcessAndSaveOFX(userID string, file io.Reader, opts ImportOptions) (*ImportAndSaveResponse, error)
+	ProcessAndSaveCAMT053(userID string, file io.Reader, opts ImportOptions) (*ImportAndSaveResponse, error)
+	ProcessAndSavePDF(userID string, file io.Reader, opts ImportOptions) (*ImportAndSaveResponse, error)
+	ProcessAndSaveXLSX(userID string, file io.Reader, xlsxOpts XLSXOptions, opts ImportOptions) (*ImportAndSaveResponse, error)
+	ProcessAndSaveMigration(userID string, app MigrationApp, file io.Reader, opts ImportOptions) (*ImportAndSaveResponse, error)
+	ProcessAndSaveEmail(userID string, file io.Reader, opts ImportOptions) (*ImportAndSaveResponse, error)
+	StageCSV(userID string, file io.Reader, csvOpts CSVOptions, opts ImportOptions) (*StagedImport, error)
+	StageOFX(userID string, file io.Reader, opts ImportOptions) (*StagedImport, error)
+	StageCAMT053(userID string, file io.Reader, opts ImportOptions) (*StagedImport, error)
+	StagePDF(userID string, file io.Reader, opts ImportOptions) (*StagedImport, error)
+	StageXLSX(userID string, file io.Reader, xlsxOpts XLSXOptions, opts ImportOptions) (*StagedImport, error)
+	GetStagedImport(userID, id string) (*StagedImport, error)
+	UpdateStagedRow(userID, id string, index int, req UpdateStagedRowRequest) (*StagedImport, error)
+	CommitStagedImport(userID, id string) (*ImportAndSaveResponse, error)
+	DiscardStagedImport(userID, id string) error
+	StartCSVJob(userID string, file io.Reader, csvOpts CSVOptions, opts ImportOptions) (*ImportJob, error)
+	GetImportJob(userID, id string) (*ImportJob, error)
+	ListImportJobs(userID string) ([]*ImportJob, error)
+	CancelImportJob(userID, id string) (*ImportJob, error)
+}
+
+type RejectedRowsError struct {
+	Message string
+	Rows    []RejectedRow
+}
+
+func (e *RejectedRowsError) Error() string {
+	return e.Message
 }
 
 type integrationService struct {
 	parserService   Service
 	analysisService analysis.Service
 	expenseService  expense.Service
+	categorizer     categorizer.Pipeline
+	stagingRepo     StagingRepository
+	jobRepo         JobRepository
+
+	jobSlots   chan struct{}
+	jobCancels map[string]context.CancelFunc
+	jobsMu     sync.Mutex
 }
 
 func NewIntegrationService(
 	parserService Service,
 	analysisService analysis.Service,
 	expenseService expense.Service,
+	categorizer categorizer.Pipeline,
+	stagingRepo StagingRepository,
+	jobRepo JobRepository,
 ) IntegrationService {
 	return &integrationService{
 		parserService:   parserService,
 		analysisService: analysisService,
 		expenseService:  expenseService,
+		categorizer:     categorizer,
+		stagingRepo:     stagingRepo,
+		jobRepo:         jobRepo,
+		jobSlots:        make(chan struct{}, maxConcurrentJobs),
+		jobCancels:      make(map[string]context.CancelFunc),
 	}
 }
 
-func (s *integrationService) ProcessAndSaveCSV(userID string, file io.Reader) (*ImportAndSaveResponse, error) {
+func (s *integrationService) ProcessAndSaveCSV(userID string, file io.Reader, csvOpts CSVOptions, opts ImportOptions) (*ImportAndSaveResponse, error) {
 	if userID == "" {
 		return nil, fmt.Errorf("userID não pode ser vazio")
 	}
@@ -40,125 +88,278 @@ func (s *integrationService) ProcessAndSaveCSV(userID string, file io.Reader) (*
 		return nil, fmt.Errorf("arquivo não pode ser nulo")
 	}
 
-	transactions, err := s.parserService.ParseCSV(file)
+	parsed, err := s.parserService.ParseCSV(file, csvOpts)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao processar CSV: %w", err)
 	}
 
+	return s.saveTransactions(userID, "CSV", parsed, opts)
+}
+
+func (s *integrationService) ProcessAndSaveOFX(userID string, file io.Reader, opts ImportOptions) (*ImportAndSaveResponse, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID não pode ser vazio")
+	}
+
+	if file == nil {
+		return nil, fmt.Errorf("arquivo não pode ser nulo")
+	}
+
+	parsed, err := s.parserService.ParseOFX(file)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao processar OFX: %w", err)
+	}
+
+	return s.saveTransactions(userID, "OFX", parsed, opts)
+}
+
+func (s *integrationService) ProcessAndSaveEmail(userID string, file io.Reader, opts ImportOptions) (*ImportAndSaveResponse, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID não pode ser vazio")
+	}
+
+	if file == nil {
+		return nil, fmt.Errorf("arquivo não pode ser nulo")
+	}
+
+	parsed, err := s.parserService.ParseEmail(file)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao processar e-mail: %w", err)
+	}
+
+	return s.saveTransactions(userID, "e-mail", parsed, opts)
+}
+
+func (s *integrationService) ProcessAndSaveCAMT053(userID string, file io.Reader, opts ImportOptions) (*ImportAndSaveResponse, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID não pode ser vazio")
+	}
+
+	if file == nil {
+		return nil, fmt.Errorf("arquivo não pode ser nulo")
+	}
+
+	parsed, err := s.parserService.ParseCAMT053(file)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao processar CAMT.053: %w", err)
+	}
+
+	return s.saveTransactions(userID, "CAMT053", parsed, opts)
+}
+
+func (s *integrationService) ProcessAndSavePDF(userID string, file io.Reader, opts ImportOptions) (*ImportAndSaveResponse, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID não pode ser vazio")
+	}
+
+	if file == nil {
+		return nil, fmt.Errorf("arquivo não pode ser nulo")
+	}
+
+	parsed, err := s.parserService.ParsePDF(file)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao processar PDF: %w", err)
+	}
+
+	return s.saveTransactions(userID, "PDF", parsed, opts)
+}
+
+func (s *integrationService) ProcessAndSaveXLSX(userID string, file io.Reader, xlsxOpts XLSXOptions, opts ImportOptions) (*ImportAndSaveResponse, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID não pode ser vazio")
+	}
+
+	if file == nil {
+		return nil, fmt.Errorf("arquivo não pode ser nulo")
+	}
+
+	parsed, err := s.parserService.ParseXLSX(file, xlsxOpts)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao processar XLSX: %w", err)
+	}
+
+	return s.saveTransactions(userID, "XLSX", parsed, opts)
+}
+
+// ProcessAndSaveMigration imports the export of another finance app. The
+// file extension picks the parser: QIF files are read as such, tabular ones
+// with the app's own layout. Categories, accounts and tags come from the
+// file; only rows without a category are categorized here.
+func (s *integrationService) ProcessAndSaveMigration(userID string, app MigrationApp, file io.Reader, opts ImportOptions) (*ImportAndSaveResponse, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID não pode ser vazio")
+	}
+
+	if file == nil {
+		return nil, fmt.Errorf("arquivo não pode ser nulo")
+	}
+
+	extension := strings.ToLower(filepath.Ext(opts.Filename))
+	if !app.Accepts(extension) {
+		return nil, fmt.Errorf("%s não exporta arquivos %s", app.Label, extension)
+	}
+
+	var parsed *ParseResult
+	var err error
+	switch extension {
+	case ".qif":
+		if parsed, err = s.parserService.ParseQIF(file); err == nil && app.Name != "qif" {
+			parsed.Profile.Bank = app.Label
+		}
+	case ".xlsx":
+		parsed, err = s.parserService.ParseXLSX(file, XLSXOptions{CSVOptions: CSVOptions{Profile: app.Name}})
+	default:
+		parsed, err = s.parserService.ParseCSV(file, CSVOptions{Profile: app.Name})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao processar arquivo do %s: %w", app.Label, err)
+	}
+
+	return s.saveTransactions(userID, app.Label, parsed, opts)
+}
+
+func (s *integrationService) saveTransactions(userID, format string, parsed *ParseResult, opts ImportOptions) (*ImportAndSaveResponse, error) {
+	transactions := parsed.Transactions
+	profile := opts.importProfile(parsed.Profile, transactions)
+	rejected := parsed.Rejected
+	if rejected == nil {
+		rejected = []RejectedRow{}
+	}
+
+	if len(rejected) > 0 && opts.Strict {
+		return nil, &RejectedRowsError{
+			Message: fmt.Sprintf("%d linha(s) inválida(s) no arquivo %s; nenhuma transação foi salva (strict=true)", len(rejected), format),
+			Rows:    rejected,
+		}
+	}
+
 	if len(transactions) == 0 {
-		return nil, fmt.Errorf("nenhuma transação encontrada no arquivo CSV")
+		if len(rejected) > 0 {
+			return nil, &RejectedRowsError{
+				Message: fmt.Sprintf("nenhuma transação válida encontrada no arquivo %s", format),
+				Rows:    rejected,
+			}
+		}
+		return nil, fmt.Errorf("nenhuma transação encontrada no arquivo %s", format)
 	}
 
-	log.Printf("Parsed %d transactions from CSV for user %s", len(transactions), userID)
+	log.Printf("Parsed %d transactions from %s for user %s (%d rejected rows)", len(transactions), format, userID, len(rejected))
 
-	categorizedTransactions := s.categorizeTransactions(transactions)
+	categorizedTransactions, err := s.categorizeTransactions(userID, transactions, profile.SignConvention)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao categorizar transações: %w", err)
+	}
 
-	expenseTransactions := s.convertToExpenseTransactions(categorizedTransactions)
+	expenseTransactions := s.convertToExpenseTransactions(categorizedTransactions, profile.SignConvention)
 
-	savedCount, err := s.expenseService.ImportTransactions(userID, expenseTransactions)
+	result, err := s.expenseService.ImportTransactions(userID, expense.ImportSource{
+		Filename:     opts.Filename,
+		Source:       strings.ToLower(format),
+		TotalRows:    len(transactions) + len(rejected),
+		RejectedRows: len(rejected),
+	}, expenseTransactions)
 	if err != nil {
 		log.Printf("Error saving transactions for user %s: %v", userID, err)
 		return nil, fmt.Errorf("erro ao salvar transações: %w", err)
 	}
 
-	log.Printf("Successfully saved %d/%d transactions for user %s", savedCount, len(transactions), userID)
+	log.Printf("Successfully saved %d/%d transactions for user %s (batch %s, %d duplicates skipped)", result.Saved, len(transactions), userID, result.BatchID, result.SkippedDuplicates)
 
 	return &ImportAndSaveResponse{
-		Message:      "CSV processado e salvo com sucesso",
-		Processed:    len(transactions),
-		Saved:        savedCount,
-		Transactions: categorizedTransactions,
+		Message:           format + " processado e salvo com sucesso",
+		BatchID:           result.BatchID,
+		Processed:         len(transactions) + len(rejected),
+		Saved:             result.Saved,
+		SkippedDuplicates: result.SkippedDuplicates,
+		Rejected:          len(rejected),
+		Profile:           profile,
+		Invoice:           parsed.Invoice,
+		Transactions:      categorizedTransactions,
+		RejectedRows:      rejected,
 	}, nil
 }
 
-func (s *integrationService) categorizeTransactions(transactions []Transaction) []Transaction {
+// categorizeTransactions fills in the category of the transactions that have
+// none with the categorization pipeline.
+func (s *integrationService) categorizeTransactions(userID string, transactions []Transaction, sign SignConvention) ([]Transaction, error) {
 	log.Printf("Starting categorization of %d transactions", len(transactions))
-	
+
 	analysisTransactions := make([]analysis.Transaction, len(transactions))
 	for i, t := range transactions {
 		analysisTransactions[i] = analysis.Transaction{
 			Date:        t.Date.Format("2006-01-02"),
 			Description: t.Description,
 			Category:    t.Category,
-			Amount:      t.Amount,
+			Amount:      sign.Spending(t.Amount),
 		}
 	}
 
 	result := s.analysisService.AnalyzeTransactions(analysisTransactions)
 	log.Printf("Analysis completed: Total spent: %.2f, Total income: %.2f", result.TotalSpent, result.TotalIncome)
 
-	categoryMap := make(map[string]string)
-	for _, cat := range result.ByCategory {
-		categoryMap[cat.Category] = cat.Category
+	pipeline, err := s.categorizer.ForUser(userID)
+	if err != nil {
+		return nil, err
 	}
 
 	categorizedCount := 0
 	for i := range transactions {
-		if transactions[i].Category == "" {
-			category := s.suggestCategory(transactions[i].Description)
-			transactions[i].Category = category
-			categorizedCount++
-			log.Printf("Auto-categorized [%d/%d] '%s' as '%s'", i+1, len(transactions), transactions[i].Description, category)
-		}
-	}
-
-	log.Printf("Categorization complete: %d transactions auto-categorized", categorizedCount)
-
-	return transactions
-}
-
-func (s *integrationService) suggestCategory(description string) string {
-	descLower := strings.ToLower(description)
-
-	if strings.Contains(descLower, "estorno") || strings.Contains(descLower, "crédito de") || strings.Contains(descLower, "credito de") || strings.Contains(descLower, "pagamento recebido") {
-		return "Credito"
-	}
-
-	transportKeywords := []string{"uber", "99", "taxi", "ride", "dl*", "pg *", "dl *", "transporte", "estacionamento"}
-	for _, keyword := range transportKeywords {
-		if strings.Contains(descLower, keyword) {
-			return "Transporte"
+		if transactions[i].Category != "" {
+			continue
 		}
-	}
 
-	foodKeywords := []string{"ifood", "restaurante", "padaria", "panif", "pizza", "lanche", "acai", "açai", "food", "bar", "cafe", "café", "tempero"}
-	for _, keyword := range foodKeywords {
-		if strings.Contains(descLower, keyword) {
-			return "Alimentacao"
+		transactionType := "income"
+		if sign.IsDebit(transactions[i].Amount) {
+			transactionType = "expense"
 		}
-	}
 
-	shoppingKeywords := []string{"amazon", "mercado", "compra", "loja", "mercadolivre"}
-	for _, keyword := range shoppingKeywords {
-		if strings.Contains(descLower, keyword) {
-			return "Compras"
+		categorized, err := pipeline.Categorize(rule.Candidate{
+			Description: transactions[i].Description,
+			Amount:      transactions[i].Amount,
+			Date:        transactions[i].Date,
+			Type:        transactionType,
+		})
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	subscriptionKeywords := []string{"spotify", "netflix", "prime", "assinatura", "dm *"}
-	for _, keyword := range subscriptionKeywords {
-		if strings.Contains(descLower, keyword) {
-			return "Assinaturas"
-		}
+		transactions[i].Category = categorized.Category
+		transactions[i].CategorySource = categorized.Source
+		transactions[i].CategoryConfidence = categorized.Confidence
+		log.Printf("Auto-categorized [%d/%d] '%s' as '%s' (%s)", i+1, len(transactions), transactions[i].Description, categorized.Category, categorized.Reason)
+		categorizedCount++
 	}
 
-	if strings.Contains(descLower, "iof") {
-		return "Taxas"
-	}
+	log.Printf("Categorization complete: %d transactions auto-categorized", categorizedCount)
 
-	return "Outros"
+	return transactions, nil
 }
 
-func (s *integrationService) convertToExpenseTransactions(transactions []Transaction) []expense.Transaction {
+func (s *integrationService) convertToExpenseTransactions(transactions []Transaction, sign SignConvention) []expense.Transaction {
 	result := make([]expense.Transaction, len(transactions))
 	for i, t := range transactions {
+		expenseType := "income"
+		if sign.IsDebit(t.Amount) {
+			expenseType = "expense"
+		}
+
 		result[i] = expense.Transaction{
 			Date:        t.Date,
 			Description: t.Description,
 			Category:    t.Category,
 			Amount:      t.Amount,
+			Type:        expenseType,
+			ExternalID:  t.ExternalID,
+
+			OriginalCurrency: t.OriginalCurrency,
+			OriginalAmount:   t.OriginalAmount,
+			ExchangeRate:     t.ExchangeRate,
+
+			Account: t.Account,
+			Tags:    t.Tags,
+
+			CategorySource: t.CategorySource,
 		}
 	}
 	return result
 }
-
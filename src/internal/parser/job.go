@@ -0,0 +1,287 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"gastei-quanto/src/internal/expense"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ImportJobTTL = 24 * time.Hour
+
+	importJobChunkSize   = 500
+	maxConcurrentJobs    = 2
+	maxJobRejectedRows   = 100
+	importJobTempPattern = "gastei-quanto-import-*.csv"
+)
+
+// StartCSVJob copies the upload to a temporary file and imports it in the
+// background. The returned job is queued; its progress is read with
+// GetImportJob.
+func (s *integrationService) StartCSVJob(userID string, file io.Reader, csvOpts CSVOptions, opts ImportOptions) (*ImportJob, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID não pode ser vazio")
+	}
+
+	if file == nil {
+		return nil, fmt.Errorf("arquivo não pode ser nulo")
+	}
+
+	now := time.Now()
+	if expired := s.jobRepo.DeleteFinishedBefore(now.Add(-ImportJobTTL)); expired > 0 {
+		log.Printf("Discarded %d finished import jobs", expired)
+	}
+
+	temp, err := os.CreateTemp("", importJobTempPattern)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar arquivo temporário: %w", err)
+	}
+
+	if _, err := io.Copy(temp, file); err != nil {
+		temp.Close()
+		os.Remove(temp.Name())
+		return nil, fmt.Errorf("erro ao gravar arquivo temporário: %w", err)
+	}
+
+	if err := temp.Close(); err != nil {
+		os.Remove(temp.Name())
+		return nil, fmt.Errorf("erro ao gravar arquivo temporário: %w", err)
+	}
+
+	job := &ImportJob{
+		ID:           uuid.New().String(),
+		UserID:       userID,
+		Filename:     opts.Filename,
+		Format:       "CSV",
+		Status:       ImportJobQueued,
+		RejectedRows: []RejectedRow{},
+		CreatedAt:    now,
+	}
+
+	if err := s.jobRepo.Create(job); err != nil {
+		os.Remove(temp.Name())
+		return nil, fmt.Errorf("erro ao criar job de importação: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.jobsMu.Lock()
+	s.jobCancels[job.ID] = cancel
+	s.jobsMu.Unlock()
+
+	go s.runCSVJob(ctx, copyJob(job), temp.Name(), csvOpts, opts)
+
+	log.Printf("Queued import job %s (%s) for user %s", job.ID, opts.Filename, userID)
+
+	return job, nil
+}
+
+func (s *integrationService) GetImportJob(userID, id string) (*ImportJob, error) {
+	return s.jobRepo.FindByID(id, userID)
+}
+
+func (s *integrationService) ListImportJobs(userID string) ([]*ImportJob, error) {
+	return s.jobRepo.FindByUserID(userID)
+}
+
+// CancelImportJob asks a queued or running job to stop. The job stops after
+// the chunk it is saving; what was saved stays in its import batch, which
+// can be rolled back.
+func (s *integrationService) CancelImportJob(userID, id string) (*ImportJob, error) {
+	job, err := s.jobRepo.FindByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Finished() {
+		return nil, fmt.Errorf("o job já terminou com status %s", job.Status)
+	}
+
+	s.jobsMu.Lock()
+	cancel, exists := s.jobCancels[id]
+	s.jobsMu.Unlock()
+
+	if exists {
+		cancel()
+	}
+
+	return job, nil
+}
+
+func (s *integrationService) runCSVJob(ctx context.Context, job *ImportJob, path string, csvOpts CSVOptions, opts ImportOptions) {
+	defer os.Remove(path)
+	defer func() {
+		s.jobsMu.Lock()
+		delete(s.jobCancels, job.ID)
+		s.jobsMu.Unlock()
+	}()
+
+	select {
+	case s.jobSlots <- struct{}{}:
+		defer func() { <-s.jobSlots }()
+	case <-ctx.Done():
+		s.finishJob(job, nil, ctx.Err())
+		return
+	}
+
+	startedAt := time.Now()
+	job.Status = ImportJobRunning
+	job.StartedAt = &startedAt
+	s.updateJob(job)
+
+	file, err := os.Open(path)
+	if err != nil {
+		s.finishJob(job, nil, fmt.Errorf("erro ao abrir arquivo temporário: %w", err))
+		return
+	}
+	defer file.Close()
+
+	if opts.Strict {
+		if err := s.validateCSVJob(ctx, job, file, csvOpts); err != nil {
+			s.finishJob(job, nil, err)
+			return
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			s.finishJob(job, nil, fmt.Errorf("erro ao ler arquivo temporário: %w", err))
+			return
+		}
+	}
+
+	var writer *expense.ImportWriter
+	pendingRejected := 0
+
+	err = s.parserService.StreamCSV(file, csvOpts, importJobChunkSize, func(chunk *ParseResult) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		profile := opts.importProfile(chunk.Profile, chunk.Transactions)
+		job.Profile = profile
+		job.Parsed += len(chunk.Transactions) + len(chunk.Rejected)
+		job.Failed += len(chunk.Rejected)
+		for _, rejected := range chunk.Rejected {
+			if len(job.RejectedRows) < maxJobRejectedRows {
+				job.RejectedRows = append(job.RejectedRows, rejected)
+			}
+		}
+
+		// The batch is only opened for a chunk with transactions, so a file
+		// without any valid row leaves no empty batch behind.
+		if writer == nil && len(chunk.Transactions) == 0 {
+			pendingRejected += len(chunk.Rejected)
+			s.updateJob(job)
+			return nil
+		}
+
+		if writer == nil {
+			var err error
+			writer, err = s.expenseService.BeginImport(job.UserID, expense.ImportSource{
+				Filename: opts.Filename,
+				Source:   "csv",
+			})
+			if err != nil {
+				return fmt.Errorf("erro ao salvar transações: %w", err)
+			}
+			job.BatchID = writer.BatchID()
+		}
+
+		categorized, err := s.categorizeTransactions(job.UserID, chunk.Transactions, profile.SignConvention)
+		if err != nil {
+			return fmt.Errorf("erro ao categorizar transações: %w", err)
+		}
+		result, err := writer.Write(s.convertToExpenseTransactions(categorized, profile.SignConvention), pendingRejected+len(chunk.Rejected))
+		if err != nil {
+			return fmt.Errorf("erro ao salvar transações: %w", err)
+		}
+		pendingRejected = 0
+
+		job.Saved += result.Saved
+		job.SkippedDuplicates += result.SkippedDuplicates
+		s.updateJob(job)
+
+		return nil
+	})
+
+	if err == nil && job.Parsed == job.Failed {
+		if job.Parsed == 0 {
+			err = fmt.Errorf("nenhuma transação encontrada no arquivo CSV")
+		} else {
+			err = fmt.Errorf("nenhuma transação válida encontrada no arquivo CSV")
+		}
+	}
+
+	s.finishJob(job, writer, err)
+}
+
+// validateCSVJob reads the whole file before anything is saved, so a strict
+// job with invalid rows fails without opening an import batch. The rejected
+// rows are recorded in the job.
+func (s *integrationService) validateCSVJob(ctx context.Context, job *ImportJob, file io.Reader, csvOpts CSVOptions) error {
+	parsed, failed := 0, 0
+	var rejectedRows []RejectedRow
+
+	err := s.parserService.StreamCSV(file, csvOpts, importJobChunkSize, func(chunk *ParseResult) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		parsed += len(chunk.Transactions) + len(chunk.Rejected)
+		failed += len(chunk.Rejected)
+		for _, rejected := range chunk.Rejected {
+			if len(rejectedRows) < maxJobRejectedRows {
+				rejectedRows = append(rejectedRows, rejected)
+			}
+		}
+		return nil
+	})
+	if err != nil || failed == 0 {
+		return err
+	}
+
+	job.Parsed, job.Failed = parsed, failed
+	job.RejectedRows = rejectedRows
+	return &RejectedRowsError{
+		Message: fmt.Sprintf("%d linha(s) inválida(s) no arquivo CSV; nenhuma transação foi salva (strict=true)", failed),
+		Rows:    rejectedRows,
+	}
+}
+
+// finishJob records the outcome of the job and of its import batch.
+func (s *integrationService) finishJob(job *ImportJob, writer *expense.ImportWriter, err error) {
+	status, batchStatus := ImportJobCompleted, expense.ImportBatchCompleted
+
+	switch {
+	case err == nil:
+	case errors.Is(err, context.Canceled):
+		status, batchStatus = ImportJobCancelled, expense.ImportBatchCancelled
+	default:
+		status, batchStatus = ImportJobFailed, expense.ImportBatchFailed
+		job.Error = err.Error()
+	}
+
+	if writer != nil {
+		if _, closeErr := writer.Close(batchStatus); closeErr != nil {
+			log.Printf("Error closing import batch %s of job %s: %v", writer.BatchID(), job.ID, closeErr)
+		}
+	}
+
+	finishedAt := time.Now()
+	job.Status = status
+	job.FinishedAt = &finishedAt
+	s.updateJob(job)
+
+	log.Printf("Import job %s finished as %s: %d parsed, %d saved, %d duplicates skipped, %d failed", job.ID, status, job.Parsed, job.Saved, job.SkippedDuplicates, job.Failed)
+}
+
+func (s *integrationService) updateJob(job *ImportJob) {
+	if err := s.jobRepo.Update(job); err != nil {
+		log.Printf("Error updating import job %s: %v", job.ID, err)
+	}
+}
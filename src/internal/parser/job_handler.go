@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StartCSVJob godoc
+// @Summary Importar CSV em segundo plano
+// @Description Recebe um arquivo CSV e o importa em segundo plano, lendo e salvando as linhas em lotes. Indicado para arquivos grandes; acompanhe o progresso em /parser/jobs/{id}
+// @Tags parser
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV file"
+// @Param profile formData string false "Perfil do banco (ex.: nubank_conta, inter_conta); detectado automaticamente se vazio"
+// @Param mapping_id formData string false "ID de um mapeamento de colunas salvo (substitui a detecção automática)"
+// @Param delimiter formData string false "Delimitador (auto, ',', ';', tab, '|')"
+// @Param encoding formData string false "Encoding (auto, utf-8, windows-1252, iso-8859-1)"
+// @Param locale formData string false "Formato dos valores (auto, pt-BR, en-US)"
+// @Param strict formData bool false "Falha o job e desfaz o lote se alguma linha for inválida"
+// @Param statement_kind formData string false "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos"
+// @Param sign_convention formData string false "Convenção de sinal (auto, debit_positive, debit_negative)"
+// @Success 202 {object} parser.ImportJob
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /parser/jobs/csv [post]
+func (h *Handler) StartCSVJob(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	f, filename, ok := h.openUpload(c, "CSV", []string{"text/csv"}, ".csv")
+	if !ok {
+		return
+	}
+	defer f.Close()
+
+	opts, err := h.csvOptionsFromForm(c)
+	if err != nil {
+		respondOptionsError(c, err)
+		return
+	}
+
+	importOpts, err := importOptionsFromForm(c, filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	job, err := h.integrationService.StartCSVJob(c.GetString("user_id"), f, opts, importOpts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erro ao iniciar importação: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// ListImportJobs godoc
+// @Summary Listar jobs de importação
+// @Description Lista os jobs de importação do usuário, do mais recente ao mais antigo. Jobs finalizados são mantidos por 24 horas
+// @Tags parser
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /parser/jobs [get]
+func (h *Handler) ListImportJobs(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	jobs, err := h.integrationService.ListImportJobs(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"jobs":  jobs,
+		"count": len(jobs),
+	})
+}
+
+// GetImportJob godoc
+// @Summary Consultar job de importação
+// @Description Retorna o status e o progresso do job: linhas lidas, salvas, duplicadas e rejeitadas
+// @Tags parser
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID"
+// @Success 200 {object} parser.ImportJob
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /parser/jobs/{id} [get]
+func (h *Handler) GetImportJob(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	job, err := h.integrationService.GetImportJob(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		respondJobError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// CancelImportJob godoc
+// @Summary Cancelar job de importação
+// @Description Interrompe um job na fila ou em andamento. As transações já salvas ficam no lote de importação, que pode ser desfeito
+// @Tags parser
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID"
+// @Success 202 {object} parser.ImportJob
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /parser/jobs/{id}/cancel [post]
+func (h *Handler) CancelImportJob(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	job, err := h.integrationService.CancelImportJob(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		respondJobError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+func respondJobError(c *gin.Context, err error) {
+	if errors.Is(err, ErrImportJobNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusConflict, gin.H{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ErrImportJobNotFound = errors.New("job de importação não encontrado")
+
+type JobRepository interface {
+	Create(job *ImportJob) error
+	FindByID(id, userID string) (*ImportJob, error)
+	FindByUserID(userID string) ([]*ImportJob, error)
+	Update(job *ImportJob) error
+	DeleteFinishedBefore(cutoff time.Time) int
+}
+
+type memoryJobRepository struct {
+	jobs map[string]*ImportJob
+	mu   sync.RWMutex
+}
+
+func NewJobRepository() JobRepository {
+	return &memoryJobRepository{
+		jobs: make(map[string]*ImportJob),
+	}
+}
+
+func (r *memoryJobRepository) Create(job *ImportJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.jobs[job.ID] = copyJob(job)
+	return nil
+}
+
+func (r *memoryJobRepository) FindByID(id, userID string) (*ImportJob, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	job, exists := r.jobs[id]
+	if !exists || job.UserID != userID {
+		return nil, ErrImportJobNotFound
+	}
+
+	return copyJob(job), nil
+}
+
+func (r *memoryJobRepository) FindByUserID(userID string) ([]*ImportJob, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []*ImportJob{}
+	for _, job := range r.jobs {
+		if job.UserID == userID {
+			result = append(result, copyJob(job))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (r *memoryJobRepository) Update(job *ImportJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.jobs[job.ID]
+	if !exists || existing.UserID != job.UserID {
+		return ErrImportJobNotFound
+	}
+
+	r.jobs[job.ID] = copyJob(job)
+	return nil
+}
+
+func (r *memoryJobRepository) DeleteFinishedBefore(cutoff time.Time) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for id, job := range r.jobs {
+		if job.Finished() && job.FinishedAt != nil && job.FinishedAt.Before(cutoff) {
+			delete(r.jobs, id)
+			count++
+		}
+	}
+	return count
+}
+
+// copyJob keeps the stored job apart from the one the running import keeps
+// updating.
+func copyJob(job *ImportJob) *ImportJob {
+	copied := *job
+	copied.RejectedRows = append([]RejectedRow{}, job.RejectedRows...)
+	return &copied
+}
@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gastei-quanto/src/internal/analysis"
+	"gastei-quanto/src/internal/categorizer"
+	"gastei-quanto/src/internal/category"
+	"gastei-quanto/src/internal/expense"
+	"gastei-quanto/src/internal/rule"
+)
+
+// newTestIntegrationService wires the integration service to the in-memory
+// repositories, as main does with the SQL ones.
+func newTestIntegrationService() (*integrationService, expense.Service) {
+	expenseRepo := expense.NewRepository()
+	classifier := categorizer.NewClassifier(expenseRepo)
+	categoryService := category.NewService(category.NewRepository(), classifier)
+	expenseService := expense.NewService(expenseRepo, categoryService, classifier)
+	keywords := categorizer.NewKeywordCategorizer()
+	pipeline := categorizer.NewPipeline(rule.NewService(rule.NewRepository()), classifier, keywords)
+
+	s := NewIntegrationService(NewService(), analysis.NewService(keywords, categoryService), expenseService, pipeline, NewStagingRepository(), NewJobRepository())
+	return s.(*integrationService), expenseService
+}
+
+func runTestJob(t *testing.T, s *integrationService, csv string, opts ImportOptions) *ImportJob {
+	t.Helper()
+
+	job, err := s.StartCSVJob("user", strings.NewReader(csv), CSVOptions{}, opts)
+	if err != nil {
+		t.Fatalf("StartCSVJob: %v", err)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		current, err := s.GetImportJob("user", job.ID)
+		if err != nil {
+			t.Fatalf("GetImportJob: %v", err)
+		}
+		if current.Finished() {
+			return current
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatal("import job did not finish within 10s")
+	return nil
+}
+
+func csvWithRows(good, bad int) string {
+	var b strings.Builder
+	b.WriteString("Data,Descrição,Valor\n")
+	for i := 0; i < bad; i++ {
+		b.WriteString("01/01/2026,Loja,sem valor\n")
+	}
+	for i := 0; i < good; i++ {
+		b.WriteString("01/01/2026,Padaria,\"10,00\"\n")
+	}
+	return b.String()
+}
+
+func TestCSVJobStrictSavesNothing(t *testing.T) {
+	s, expenses := newTestIntegrationService()
+
+	// The invalid row comes after a full chunk of valid ones.
+	csv := csvWithRows(importJobChunkSize+1, 0) + "01/01/2026,Loja,sem valor\n"
+	job := runTestJob(t, s, csv, ImportOptions{Filename: "extrato.csv", Strict: true})
+
+	if job.Status != ImportJobFailed || job.Saved != 0 || job.Failed != 1 || job.BatchID != "" {
+		t.Errorf("job = %s, saved %d, failed %d, batch %q; want failed with nothing saved and no batch", job.Status, job.Saved, job.Failed, job.BatchID)
+	}
+	if len(job.RejectedRows) != 1 || job.RejectedRows[0].Line != importJobChunkSize+3 {
+		t.Errorf("rejected rows = %+v, want line %d", job.RejectedRows, importJobChunkSize+3)
+	}
+
+	batches, _ := expenses.ListImportBatches("user")
+	saved, _ := expenses.List("user", expense.ListExpensesQuery{})
+	if len(batches) != 0 || len(saved) != 0 {
+		t.Errorf("got %d batches and %d expenses, want none", len(batches), len(saved))
+	}
+}
+
+func TestCSVJobStrictValid(t *testing.T) {
+	s, _ := newTestIntegrationService()
+
+	job := runTestJob(t, s, csvWithRows(3, 0), ImportOptions{Filename: "extrato.csv", Strict: true})
+	if job.Status != ImportJobCompleted || job.Saved != 3 || job.Parsed != 3 {
+		t.Errorf("job = %s, parsed %d, saved %d; want completed with 3 saved", job.Status, job.Parsed, job.Saved)
+	}
+}
+
+func TestCSVJobBatchOpensWithFirstTransaction(t *testing.T) {
+	tests := []struct {
+		name       string
+		good, bad  int
+		wantStatus string
+		wantBatch  bool
+	}{
+		{"only invalid rows", 0, importJobChunkSize + 10, ImportJobFailed, false},
+		{"invalid first chunk", 2, importJobChunkSize + 10, ImportJobCompleted, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, expenses := newTestIntegrationService()
+
+			job := runTestJob(t, s, csvWithRows(tt.good, tt.bad), ImportOptions{Filename: "extrato.csv"})
+			if job.Status != tt.wantStatus || job.Saved != tt.good || job.Failed != tt.bad {
+				t.Errorf("job = %s (%s), saved %d, failed %d; want %s, %d, %d", job.Status, job.Error, job.Saved, job.Failed, tt.wantStatus, tt.good, tt.bad)
+			}
+
+			batches, _ := expenses.ListImportBatches("user")
+			if !tt.wantBatch {
+				if len(batches) != 0 {
+					t.Errorf("got %d batches, want none", len(batches))
+				}
+				return
+			}
+			if len(batches) != 1 || batches[0].SavedRows != tt.good || batches[0].RejectedRows != tt.bad {
+				t.Errorf("batches = %+v, want one with %d saved and %d rejected", batches, tt.good, tt.bad)
+			}
+		})
+	}
+}
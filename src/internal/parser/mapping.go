@@ -0,0 +1,191 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MappingService interface {
+	Create(userID string, req ColumnMappingRequest) (*ColumnMapping, error)
+	Get(userID, id string) (*ColumnMapping, error)
+	List(userID string) ([]*ColumnMapping, error)
+	Update(userID, id string, req ColumnMappingRequest) (*ColumnMapping, error)
+	Delete(userID, id string) error
+}
+
+type mappingService struct {
+	repo MappingRepository
+}
+
+func NewMappingService(repo MappingRepository) MappingService {
+	return &mappingService{repo: repo}
+}
+
+func (s *mappingService) Create(userID string, req ColumnMappingRequest) (*ColumnMapping, error) {
+	if err := validateMapping(req); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	mapping := &ColumnMapping{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	applyMappingRequest(mapping, req)
+
+	if err := s.repo.Create(mapping); err != nil {
+		return nil, err
+	}
+
+	return mapping, nil
+}
+
+func (s *mappingService) Get(userID, id string) (*ColumnMapping, error) {
+	return s.repo.FindByID(id, userID)
+}
+
+func (s *mappingService) List(userID string) ([]*ColumnMapping, error) {
+	return s.repo.FindByUserID(userID)
+}
+
+func (s *mappingService) Update(userID, id string, req ColumnMappingRequest) (*ColumnMapping, error) {
+	if err := validateMapping(req); err != nil {
+		return nil, err
+	}
+
+	mapping, err := s.repo.FindByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	applyMappingRequest(mapping, req)
+	mapping.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(mapping); err != nil {
+		return nil, err
+	}
+
+	return mapping, nil
+}
+
+func (s *mappingService) Delete(userID, id string) error {
+	return s.repo.Delete(id, userID)
+}
+
+func applyMappingRequest(mapping *ColumnMapping, req ColumnMappingRequest) {
+	mapping.Name = strings.TrimSpace(req.Name)
+	mapping.DateColumn = strings.TrimSpace(req.DateColumn)
+	mapping.DateFormat = strings.TrimSpace(req.DateFormat)
+	mapping.AmountColumn = strings.TrimSpace(req.AmountColumn)
+	mapping.DebitColumn = strings.TrimSpace(req.DebitColumn)
+	mapping.CreditColumn = strings.TrimSpace(req.CreditColumn)
+	mapping.DescriptionColumn = strings.TrimSpace(req.DescriptionColumn)
+	mapping.CategoryColumn = strings.TrimSpace(req.CategoryColumn)
+	mapping.SkipRows = req.SkipRows
+	mapping.SignConvention = req.SignConvention
+}
+
+func validateMapping(req ColumnMappingRequest) error {
+	if strings.TrimSpace(req.AmountColumn) == "" && strings.TrimSpace(req.DebitColumn) == "" && strings.TrimSpace(req.CreditColumn) == "" {
+		return fmt.Errorf("informe amount_column ou debit_column/credit_column")
+	}
+
+	if strings.TrimSpace(req.AmountColumn) != "" && (strings.TrimSpace(req.DebitColumn) != "" || strings.TrimSpace(req.CreditColumn) != "") {
+		return fmt.Errorf("use amount_column ou debit_column/credit_column, não ambos")
+	}
+
+	if req.DateFormat != "" {
+		if _, err := dateLayout(req.DateFormat); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dateLayout converts a user-facing date pattern such as "DD/MM/YYYY" into
+// a Go time layout.
+func dateLayout(format string) (string, error) {
+	replacer := strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02")
+	layout := replacer.Replace(strings.ToUpper(strings.TrimSpace(format)))
+
+	if !strings.Contains(layout, "01") || !strings.Contains(layout, "02") || !strings.Contains(layout, "06") {
+		return "", fmt.Errorf("formato de data inválido: %s (use DD, MM e YYYY, ex.: DD/MM/YYYY)", format)
+	}
+
+	for _, r := range strings.NewReplacer("2006", "", "06", "", "01", "", "02", "").Replace(layout) {
+		if !strings.ContainsRune("/-. ", r) {
+			return "", fmt.Errorf("formato de data inválido: %s (use DD, MM e YYYY, ex.: DD/MM/YYYY)", format)
+		}
+	}
+
+	return layout, nil
+}
+
+// layout turns the saved mapping into a column layout with a single alias per
+// column, so the import uses exactly the columns the user picked.
+func (m *ColumnMapping) layout() *columnLayout {
+	column := func(name string) []string {
+		if name == "" {
+			return nil
+		}
+		return []string{name}
+	}
+
+	var dateFormats []string
+	if layout, err := dateLayout(m.DateFormat); err == nil && m.DateFormat != "" {
+		dateFormats = []string{layout}
+	}
+
+	return &columnLayout{
+		profile: ImportProfile{
+			Name:           "mapping",
+			MappingID:      m.ID,
+			Description:    m.Name,
+			StatementKind:  m.SignConvention.statementKind(),
+			SignConvention: m.SignConvention,
+		},
+		date:        column(m.DateColumn),
+		description: column(m.DescriptionColumn),
+		category:    column(m.CategoryColumn),
+		amount:      column(m.AmountColumn),
+		credit:      column(m.CreditColumn),
+		debit:       column(m.DebitColumn),
+		dateFormats: dateFormats,
+	}
+}
+
+// selectHeader uses the first row after the skipped lines as the header and
+// checks that every column of the mapping is present.
+func (m *ColumnMapping) selectHeader(rows []TableRow) (Importer, int, error) {
+	headerIdx := -1
+	for i, row := range rows {
+		if row.Line > m.SkipRows {
+			headerIdx = i
+			break
+		}
+	}
+
+	if headerIdx == -1 {
+		return nil, 0, fmt.Errorf("cabeçalho não encontrado após %d linha(s) ignorada(s)", m.SkipRows)
+	}
+
+	header := rows[headerIdx].Record
+	var missing []string
+	for _, name := range []string{m.DateColumn, m.AmountColumn, m.DebitColumn, m.CreditColumn, m.DescriptionColumn, m.CategoryColumn} {
+		if name != "" && findColumn(header, name) == -1 {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, 0, fmt.Errorf("colunas do mapeamento %q não encontradas no cabeçalho: %s", m.Name, strings.Join(missing, ", "))
+	}
+
+	return m.layout(), headerIdx, nil
+}
@@ -0,0 +1,181 @@
+package parser
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateMapping godoc
+// @Summary Cria um mapeamento de colunas
+// @Description Salva um mapeamento de colunas para importar CSVs de layouts não reconhecidos automaticamente
+// @Tags parser
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ColumnMappingRequest true "Mapeamento de colunas"
+// @Success 201 {object} parser.ColumnMapping
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /parser/mappings [post]
+func (h *Handler) CreateMapping(c *gin.Context) {
+	if !h.requireMappings(c) {
+		return
+	}
+
+	var req ColumnMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Formato de requisição inválido: " + err.Error(),
+		})
+		return
+	}
+
+	mapping, err := h.mappingService.Create(c.GetString("user_id"), req)
+	if err != nil {
+		respondMappingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapping)
+}
+
+// ListMappings godoc
+// @Summary Lista os mapeamentos de colunas
+// @Description Retorna os mapeamentos de colunas salvos pelo usuário autenticado
+// @Tags parser
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /parser/mappings [get]
+func (h *Handler) ListMappings(c *gin.Context) {
+	if !h.requireMappings(c) {
+		return
+	}
+
+	mappings, err := h.mappingService.List(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"mappings": mappings,
+		"count":    len(mappings),
+	})
+}
+
+// GetMapping godoc
+// @Summary Busca um mapeamento de colunas
+// @Description Retorna um mapeamento de colunas do usuário autenticado
+// @Tags parser
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do mapeamento"
+// @Success 200 {object} parser.ColumnMapping
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /parser/mappings/{id} [get]
+func (h *Handler) GetMapping(c *gin.Context) {
+	if !h.requireMappings(c) {
+		return
+	}
+
+	mapping, err := h.mappingService.Get(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		respondMappingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapping)
+}
+
+// UpdateMapping godoc
+// @Summary Atualiza um mapeamento de colunas
+// @Description Substitui as colunas e opções de um mapeamento salvo
+// @Tags parser
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do mapeamento"
+// @Param request body ColumnMappingRequest true "Mapeamento de colunas"
+// @Success 200 {object} parser.ColumnMapping
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /parser/mappings/{id} [put]
+func (h *Handler) UpdateMapping(c *gin.Context) {
+	if !h.requireMappings(c) {
+		return
+	}
+
+	var req ColumnMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Formato de requisição inválido: " + err.Error(),
+		})
+		return
+	}
+
+	mapping, err := h.mappingService.Update(c.GetString("user_id"), c.Param("id"), req)
+	if err != nil {
+		respondMappingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapping)
+}
+
+// DeleteMapping godoc
+// @Summary Remove um mapeamento de colunas
+// @Description Remove um mapeamento de colunas do usuário autenticado
+// @Tags parser
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do mapeamento"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /parser/mappings/{id} [delete]
+func (h *Handler) DeleteMapping(c *gin.Context) {
+	if !h.requireMappings(c) {
+		return
+	}
+
+	if err := h.mappingService.Delete(c.GetString("user_id"), c.Param("id")); err != nil {
+		respondMappingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Mapeamento de colunas removido",
+	})
+}
+
+func (h *Handler) requireMappings(c *gin.Context) bool {
+	if h.mappingService == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Serviço de mapeamentos não disponível",
+		})
+		return false
+	}
+	return true
+}
+
+func respondMappingError(c *gin.Context, err error) {
+	if errors.Is(err, ErrColumnMappingNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+var ErrColumnMappingNotFound = errors.New("mapeamento de colunas não encontrado")
+
+type MappingRepository interface {
+	Create(mapping *ColumnMapping) error
+	FindByID(id, userID string) (*ColumnMapping, error)
+	FindByUserID(userID string) ([]*ColumnMapping, error)
+	Update(mapping *ColumnMapping) error
+	Delete(id, userID string) error
+}
+
+type memoryMappingRepository struct {
+	mappings map[string]*ColumnMapping
+	mu       sync.RWMutex
+}
+
+func NewMappingRepository() MappingRepository {
+	return &memoryMappingRepository{
+		mappings: make(map[string]*ColumnMapping),
+	}
+}
+
+func (r *memoryMappingRepository) Create(mapping *ColumnMapping) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.mappings[mapping.ID] = mapping
+	return nil
+}
+
+func (r *memoryMappingRepository) FindByID(id, userID string) (*ColumnMapping, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	mapping, exists := r.mappings[id]
+	if !exists || mapping.UserID != userID {
+		return nil, ErrColumnMappingNotFound
+	}
+
+	copied := *mapping
+	return &copied, nil
+}
+
+func (r *memoryMappingRepository) FindByUserID(userID string) ([]*ColumnMapping, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	mappings := []*ColumnMapping{}
+	for _, mapping := range r.mappings {
+		if mapping.UserID == userID {
+			copied := *mapping
+			mappings = append(mappings, &copied)
+		}
+	}
+
+	sort.Slice(mappings, func(i, j int) bool {
+		return mappings[i].Name < mappings[j].Name
+	})
+
+	return mappings, nil
+}
+
+func (r *memoryMappingRepository) Update(mapping *ColumnMapping) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.mappings[mapping.ID]
+	if !exists || existing.UserID != mapping.UserID {
+		return ErrColumnMappingNotFound
+	}
+
+	r.mappings[mapping.ID] = mapping
+	return nil
+}
+
+func (r *memoryMappingRepository) Delete(id, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mapping, exists := r.mappings[id]
+	if !exists || mapping.UserID != userID {
+		return ErrColumnMappingNotFound
+	}
+
+	delete(r.mappings, id)
+	return nil
+}
@@ -0,0 +1,174 @@
+package parser
+
+import (
+	"database/sql"
+)
+
+type sqlMappingRepository struct {
+	db *sql.DB
+}
+
+func NewSQLMappingRepository(db *sql.DB) MappingRepository {
+	return &sqlMappingRepository{
+		db: db,
+	}
+}
+
+const columnMappingColumns = `id, user_id, name, date_column, date_format, amount_column, debit_column, credit_column, 
+	description_column, category_column, skip_rows, sign_convention, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanColumnMapping(row rowScanner) (*ColumnMapping, error) {
+	mapping := &ColumnMapping{}
+	var signConvention string
+
+	err := row.Scan(
+		&mapping.ID,
+		&mapping.UserID,
+		&mapping.Name,
+		&mapping.DateColumn,
+		&mapping.DateFormat,
+		&mapping.AmountColumn,
+		&mapping.DebitColumn,
+		&mapping.CreditColumn,
+		&mapping.DescriptionColumn,
+		&mapping.CategoryColumn,
+		&mapping.SkipRows,
+		&signConvention,
+		&mapping.CreatedAt,
+		&mapping.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping.SignConvention = SignConvention(signConvention)
+	return mapping, nil
+}
+
+func (r *sqlMappingRepository) Create(mapping *ColumnMapping) error {
+	query := `INSERT INTO column_mappings (` + columnMappingColumns + `) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.Exec(
+		query,
+		mapping.ID,
+		mapping.UserID,
+		mapping.Name,
+		mapping.DateColumn,
+		mapping.DateFormat,
+		mapping.AmountColumn,
+		mapping.DebitColumn,
+		mapping.CreditColumn,
+		mapping.DescriptionColumn,
+		mapping.CategoryColumn,
+		mapping.SkipRows,
+		string(mapping.SignConvention),
+		mapping.CreatedAt,
+		mapping.UpdatedAt,
+	)
+
+	return err
+}
+
+func (r *sqlMappingRepository) FindByID(id, userID string) (*ColumnMapping, error) {
+	query := `SELECT ` + columnMappingColumns + ` 
+		FROM column_mappings WHERE id = ? AND user_id = ?`
+
+	mapping, err := scanColumnMapping(r.db.QueryRow(query, id, userID))
+	if err == sql.ErrNoRows {
+		return nil, ErrColumnMappingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping, nil
+}
+
+func (r *sqlMappingRepository) FindByUserID(userID string) ([]*ColumnMapping, error) {
+	query := `SELECT ` + columnMappingColumns + ` 
+		FROM column_mappings WHERE user_id = ? ORDER BY name`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mappings := []*ColumnMapping{}
+	for rows.Next() {
+		mapping, err := scanColumnMapping(rows)
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return mappings, nil
+}
+
+func (r *sqlMappingRepository) Update(mapping *ColumnMapping) error {
+	query := `UPDATE column_mappings 
+		SET name = ?, date_column = ?, date_format = ?, amount_column = ?, debit_column = ?, credit_column = ?, 
+			description_column = ?, category_column = ?, skip_rows = ?, sign_convention = ?, updated_at = ? 
+		WHERE id = ? AND user_id = ?`
+
+	result, err := r.db.Exec(
+		query,
+		mapping.Name,
+		mapping.DateColumn,
+		mapping.DateFormat,
+		mapping.AmountColumn,
+		mapping.DebitColumn,
+		mapping.CreditColumn,
+		mapping.DescriptionColumn,
+		mapping.CategoryColumn,
+		mapping.SkipRows,
+		string(mapping.SignConvention),
+		mapping.UpdatedAt,
+		mapping.ID,
+		mapping.UserID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrColumnMappingNotFound
+	}
+
+	return nil
+}
+
+func (r *sqlMappingRepository) Delete(id, userID string) error {
+	query := `DELETE FROM column_mappings WHERE id = ? AND user_id = ?`
+
+	result, err := r.db.Exec(query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrColumnMappingNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,344 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"gastei-quanto/src/internal/expense"
+)
+
+// MigrationApp is a finance app whose exports can be imported with their
+// categories, accounts and tags. Formats are the file extensions accepted;
+// tabular files are read with the importer of the same name.
+type MigrationApp struct {
+	Name        string   `json:"name"`
+	Label       string   `json:"label"`
+	Description string   `json:"description"`
+	Formats     []string `json:"formats"`
+}
+
+func MigrationApps() []MigrationApp {
+	return []MigrationApp{
+		{Name: "qif", Label: "QIF", Description: "Arquivo QIF exportado por qualquer app (Quicken, GnuCash, Mobills, Organizze...)", Formats: []string{"qif"}},
+		{Name: "mobills", Label: "Mobills", Description: "Exportação de transações do Mobills", Formats: []string{"csv", "xlsx", "qif"}},
+		{Name: "organizze", Label: "Organizze", Description: "Exportação de lançamentos do Organizze", Formats: []string{"csv", "xlsx", "qif"}},
+		{Name: "gnucash", Label: "GnuCash", Description: "Transações exportadas do GnuCash (Exportar transações para CSV)", Formats: []string{"csv", "qif"}},
+		{Name: "moneylover", Label: "Money Lover", Description: "Exportação de transações do Money Lover", Formats: []string{"csv", "xlsx"}},
+	}
+}
+
+func FindMigrationApp(name string) (MigrationApp, bool) {
+	for _, app := range MigrationApps() {
+		if app.Name == strings.ToLower(strings.TrimSpace(name)) {
+			return app, true
+		}
+	}
+	return MigrationApp{}, false
+}
+
+// Accepts reports whether the app exports files with the given extension
+// (".csv", "xlsx").
+func (a MigrationApp) Accepts(extension string) bool {
+	extension = strings.TrimPrefix(strings.ToLower(extension), ".")
+	for _, format := range a.Formats {
+		if format == extension {
+			return true
+		}
+	}
+	return false
+}
+
+// migrationImporters are the layouts of the CSV and XLSX exports of other
+// finance apps. All of them write expenses as negative amounts.
+func migrationImporters() []Importer {
+	return []Importer{
+		&columnLayout{
+			profile: ImportProfile{
+				Name:           "organizze",
+				Bank:           "Organizze",
+				Description:    "Exportação de lançamentos do Organizze",
+				StatementKind:  StatementChecking,
+				SignConvention: SignDebitNegative,
+			},
+			signature:    [][]string{{"Data"}, {"Descrição"}, {"Categoria"}, {"Valor"}, {"Situação"}},
+			date:         []string{"Data"},
+			description:  []string{"Descrição"},
+			details:      []string{"Observações", "Notas"},
+			category:     []string{"Categoria"},
+			amount:       []string{"Valor"},
+			account:      []string{"Conta", "Cartão", "Conta/Cartão"},
+			tags:         []string{"Tags", "Etiquetas"},
+			status:       []string{"Situação"},
+			skipStatuses: []string{"Não pago", "Não paga", "Pendente", "Agendado"},
+			dateFormats:  []string{"02/01/2006", "02/01/06"},
+		},
+		&columnLayout{
+			profile: ImportProfile{
+				Name:           "mobills",
+				Bank:           "Mobills",
+				Description:    "Exportação de transações do Mobills",
+				StatementKind:  StatementChecking,
+				SignConvention: SignDebitNegative,
+			},
+			signature:    [][]string{{"Data"}, {"Descrição"}, {"Valor"}, {"Conta", "Conta/Cartão"}, {"Categoria"}},
+			date:         []string{"Data"},
+			description:  []string{"Descrição"},
+			details:      []string{"Observação", "Observações"},
+			category:     []string{"Categoria"},
+			subcategory:  []string{"Subcategoria"},
+			amount:       []string{"Valor"},
+			account:      []string{"Conta", "Conta/Cartão"},
+			tags:         []string{"Tags", "Etiquetas"},
+			status:       []string{"Situação", "Status"},
+			skipStatuses: []string{"Não pago", "Não paga", "Pendente"},
+			dateFormats:  []string{"02/01/2006", "02/01/06"},
+		},
+		&columnLayout{
+			profile: ImportProfile{
+				Name:           "moneylover",
+				Bank:           "Money Lover",
+				Description:    "Exportação de transações do Money Lover",
+				StatementKind:  StatementChecking,
+				SignConvention: SignDebitNegative,
+			},
+			signature:   [][]string{{"Date", "Data"}, {"Category", "Categoria"}, {"Amount", "Valor"}, {"Wallet", "Account", "Carteira", "Conta"}, {"Note", "Nota"}},
+			date:        []string{"Date", "Data"},
+			description: []string{"Note", "Nota"},
+			category:    []string{"Category", "Categoria"},
+			amount:      []string{"Amount", "Valor"},
+			externalID:  []string{"Id"},
+			account:     []string{"Wallet", "Account", "Carteira", "Conta"},
+			tags:        []string{"Event", "Evento"},
+			dateFormats: []string{"02/01/2006", "2006-01-02"},
+		},
+		&gnucashImporter{},
+	}
+}
+
+// splitTags reads a tag cell, where apps separate tags with commas,
+// semicolons or pipes.
+func splitTags(value string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, tag := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ';' || r == '|'
+	}) {
+		tag = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		key := strings.ToLower(tag)
+		if tag == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+var (
+	gnucashCategoryRoots = []string{"expenses", "expense", "despesas", "despesa", "income", "receitas", "receita", "rendimentos"}
+	gnucashEquityRoots   = []string{"equity", "patrimonio liquido", "patrimonio", "capital proprio"}
+)
+
+// gnucashImporter reads GnuCash's "Export Transactions to CSV", which writes
+// one row per split. Rows are grouped by transaction ID; in the default
+// layout only the first row of a transaction has its date and description.
+// Each split to an expense or income account becomes a transaction, with
+// the account path below the root as its category and the asset or
+// liability account on the other side as its account. Transactions with no
+// such split (transfers between accounts, opening balances) are left out.
+type gnucashImporter struct{}
+
+type gnucashSplit struct {
+	row         TableRow
+	fullAccount string
+	accountName string
+	memo        string
+	amount      string
+}
+
+type gnucashTransaction struct {
+	row         TableRow
+	id          string
+	date        string
+	description string
+	notes       string
+	splits      []gnucashSplit
+}
+
+type gnucashColumns struct {
+	date, id, description, notes, memo, fullAccount, accountName, amount, value int
+}
+
+func (g *gnucashImporter) Profile() ImportProfile {
+	return ImportProfile{
+		Name:           "gnucash",
+		Bank:           "GnuCash",
+		Description:    "Transações exportadas do GnuCash (CSV)",
+		StatementKind:  StatementChecking,
+		SignConvention: SignDebitNegative,
+	}
+}
+
+func (g *gnucashImporter) columns(header []string) gnucashColumns {
+	return gnucashColumns{
+		date:        findColumn(header, "Date", "Data"),
+		id:          findColumn(header, "Transaction ID", "ID da transação"),
+		description: findColumn(header, "Description", "Descrição"),
+		notes:       findColumn(header, "Notes", "Notas"),
+		memo:        findColumn(header, "Memo", "Lembrete"),
+		fullAccount: findColumn(header, "Full Account Name", "Nome completo da conta"),
+		accountName: findColumn(header, "Account Name", "Nome da conta"),
+		amount:      findColumn(header, "Amount Num.", "Amount Num", "Valor Num."),
+		value:       findColumn(header, "Value Num.", "Value Num"),
+	}
+}
+
+func (g *gnucashImporter) Detect(header []string, sample [][]string) int {
+	cols := g.columns(header)
+	if cols.date == -1 || cols.id == -1 || cols.fullAccount == -1 || (cols.amount == -1 && cols.value == -1) {
+		return 0
+	}
+	return 40
+}
+
+func (g *gnucashImporter) Parse(table *Table, opts CSVOptions) *ParseResult {
+	cols := g.columns(table.Header)
+	amountCol := cols.amount
+	if amountCol == -1 {
+		amountCol = cols.value
+	}
+
+	field := func(record []string, idx int) string {
+		if idx >= 0 && idx < len(record) {
+			return strings.TrimSpace(record[idx])
+		}
+		return ""
+	}
+
+	var transactions []*gnucashTransaction
+	var current *gnucashTransaction
+	var amounts []string
+
+	for _, row := range table.Rows {
+		record := row.Record
+		if id := field(record, cols.id); id != "" && (current == nil || current.id != id) {
+			current = &gnucashTransaction{
+				row:         row,
+				id:          id,
+				date:        field(record, cols.date),
+				description: field(record, cols.description),
+				notes:       field(record, cols.notes),
+			}
+			transactions = append(transactions, current)
+		}
+		if current == nil || field(record, cols.fullAccount) == "" {
+			continue
+		}
+
+		split := gnucashSplit{
+			row:         row,
+			fullAccount: field(record, cols.fullAccount),
+			accountName: field(record, cols.accountName),
+			memo:        field(record, cols.memo),
+			amount:      field(record, amountCol),
+		}
+		current.splits = append(current.splits, split)
+		amounts = append(amounts, split.amount)
+	}
+
+	result := &ParseResult{}
+	locale := opts.Locale
+	if locale == LocaleAuto {
+		locale = detectLocale(amounts, table.Delimiter)
+	}
+	result.Locale = locale
+
+	for _, tx := range transactions {
+		g.appendTransactions(result, tx, locale)
+	}
+
+	return result
+}
+
+func (g *gnucashImporter) appendTransactions(result *ParseResult, tx *gnucashTransaction, locale Locale) {
+	reject := func(row TableRow, reason string) {
+		result.Rejected = append(result.Rejected, RejectedRow{Line: row.Line, Raw: row.Raw, Reason: reason})
+	}
+
+	var categories []gnucashSplit
+	var account string
+	for _, split := range tx.splits {
+		root, _ := gnucashRoot(split.fullAccount)
+		switch {
+		case containsNormalized(gnucashCategoryRoots, root):
+			categories = append(categories, split)
+		case containsNormalized(gnucashEquityRoots, root):
+		case account == "":
+			account = firstNonEmpty(split.accountName, gnucashLeaf(split.fullAccount))
+		}
+	}
+
+	if len(categories) == 0 {
+		return
+	}
+
+	date, err := parseDateWith(tx.date, []string{"02/01/2006", "2006-01-02", "02/01/06", "02.01.2006", "01/02/2006"})
+	if err != nil {
+		reject(tx.row, err.Error())
+		return
+	}
+
+	for i, split := range categories {
+		amount, err := parseAmount(split.amount, locale)
+		if err != nil {
+			reject(split.row, err.Error())
+			continue
+		}
+
+		_, category := gnucashRoot(split.fullAccount)
+		description := firstNonEmpty(tx.description, split.memo, tx.notes, category)
+		if split.memo != "" && split.memo != description {
+			description += " - " + split.memo
+		}
+
+		externalID := tx.id
+		if len(categories) > 1 {
+			externalID = fmt.Sprintf("%s/%d", tx.id, i+1)
+		}
+
+		// The category split is the money going into the expense account;
+		// the account it came from moved the opposite amount.
+		result.Transactions = append(result.Transactions, Transaction{
+			Date:        date,
+			Description: description,
+			Category:    category,
+			Amount:      -amount,
+			ExternalID:  externalID,
+			Account:     account,
+		})
+	}
+}
+
+// gnucashRoot splits "Expenses:Food:Groceries" into its root and the path
+// below it, "Food:Groceries".
+func gnucashRoot(fullAccount string) (string, string) {
+	root, rest, _ := strings.Cut(fullAccount, ":")
+	if rest == "" {
+		rest = root
+	}
+	return root, rest
+}
+
+func gnucashLeaf(fullAccount string) string {
+	return fullAccount[strings.LastIndex(fullAccount, ":")+1:]
+}
+
+func containsNormalized(values []string, value string) bool {
+	value = expense.NormalizeDescription(value)
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -2,11 +2,28 @@ package parser
 
 import "time"
 
+// Transaction amounts are in reais. Purchases made in another currency
+// also carry the amount charged in that currency and the exchange rate, when
+// the statement prints them. ValueDate is set by statements that tell it
+// apart from the booking date (CAMT.053). Account and Tags are carried over
+// from the finance app the user migrated from. Rows categorized on import
+// tell how: by a rule, by the classifier, with its confidence, or by the
+// keywords.
 type Transaction struct {
-	Date        time.Time `json:"date"`
-	Description string    `json:"description"`
-	Category    string    `json:"category"`
-	Amount      float64   `json:"amount"`
+	Date             time.Time  `json:"date"`
+	Description      string     `json:"description"`
+	Category         string     `json:"category"`
+	Amount           float64    `json:"amount"`
+	ExternalID       string     `json:"external_id,omitempty"`
+	ValueDate        *time.Time `json:"value_date,omitempty"`
+	OriginalCurrency string     `json:"original_currency,omitempty"`
+	OriginalAmount   float64    `json:"original_amount,omitempty"`
+	ExchangeRate     float64    `json:"exchange_rate,omitempty"`
+	Account          string     `json:"account,omitempty"`
+	Tags             []string   `json:"tags,omitempty"`
+
+	CategorySource     string  `json:"category_source,omitempty"`
+	CategoryConfidence float64 `json:"category_confidence,omitempty"`
 }
 
 type UploadResponse struct {
@@ -16,8 +33,178 @@ type UploadResponse struct {
 }
 
 type ImportAndSaveResponse struct {
-	Message      string        `json:"message"`
-	Processed    int           `json:"processed"`
-	Saved        int           `json:"saved"`
-	Transactions []Transaction `json:"transactions"`
-}
\ No newline at end of file
+	Message           string          `json:"message"`
+	BatchID           string          `json:"batch_id"`
+	Processed         int             `json:"processed"`
+	Saved             int             `json:"saved"`
+	SkippedDuplicates int             `json:"skipped_duplicates"`
+	Rejected          int             `json:"rejected"`
+	Excluded          int             `json:"excluded,omitempty"`
+	Profile           *ImportProfile  `json:"profile,omitempty"`
+	Invoice           *InvoiceSummary `json:"invoice,omitempty"`
+	Transactions      []Transaction   `json:"transactions"`
+	RejectedRows      []RejectedRow   `json:"rejected_rows"`
+}
+
+type RejectedRow struct {
+	Line   int    `json:"line"`
+	Raw    string `json:"raw"`
+	Reason string `json:"reason"`
+}
+
+type ParseResult struct {
+	Transactions []Transaction
+	Rejected     []RejectedRow
+	Invoice      *InvoiceSummary
+	Profile      *ImportProfile
+	Locale       Locale
+}
+
+type SignConvention string
+
+const (
+	// SignDebitPositive is used by card statements: purchases are positive
+	// and payments or refunds negative.
+	SignDebitPositive SignConvention = "debit_positive"
+	// SignDebitNegative is used by account statements: money leaving the
+	// account is negative.
+	SignDebitNegative SignConvention = "debit_negative"
+)
+
+type StatementKind string
+
+const (
+	StatementCreditCard StatementKind = "credit_card"
+	StatementChecking   StatementKind = "checking"
+	StatementSavings    StatementKind = "savings"
+)
+
+// ImportProfile describes the layout a file was read with. SignDetected is
+// set when the sign convention was guessed from the amounts, because neither
+// the layout nor the upload declared it.
+type ImportProfile struct {
+	Name           string         `json:"name"`
+	MappingID      string         `json:"mapping_id,omitempty"`
+	Bank           string         `json:"bank"`
+	Description    string         `json:"description"`
+	StatementKind  StatementKind  `json:"statement_kind,omitempty"`
+	SignConvention SignConvention `json:"sign_convention,omitempty"`
+	SignDetected   bool           `json:"sign_detected,omitempty"`
+}
+
+type InvoiceSummary struct {
+	Issuer            string     `json:"issuer"`
+	ClosingDate       *time.Time `json:"closing_date,omitempty"`
+	DueDate           *time.Time `json:"due_date,omitempty"`
+	Total             *float64   `json:"total,omitempty"`
+	TransactionsTotal float64    `json:"transactions_total"`
+}
+
+type ImportOptions struct {
+	Filename       string
+	Strict         bool
+	StatementKind  StatementKind
+	SignConvention SignConvention
+}
+
+type CSVOptions struct {
+	Profile   string
+	Mapping   *ColumnMapping
+	Delimiter rune
+	Encoding  string
+	Locale    Locale
+}
+
+type StagedImport struct {
+	ID           string          `json:"id"`
+	UserID       string          `json:"user_id"`
+	Filename     string          `json:"filename"`
+	Format       string          `json:"format"`
+	Rows         []StagedRow     `json:"rows"`
+	RejectedRows []RejectedRow   `json:"rejected_rows"`
+	Profile      *ImportProfile  `json:"profile,omitempty"`
+	Invoice      *InvoiceSummary `json:"invoice,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+	ExpiresAt    time.Time       `json:"expires_at"`
+}
+
+const (
+	ImportJobQueued    = "queued"
+	ImportJobRunning   = "running"
+	ImportJobCompleted = "completed"
+	ImportJobFailed    = "failed"
+	ImportJobCancelled = "cancelled"
+)
+
+// ImportJob is a CSV import running in the background. Parsed counts every
+// data row read so far: Saved + SkippedDuplicates + Failed.
+type ImportJob struct {
+	ID                string         `json:"id"`
+	UserID            string         `json:"user_id"`
+	Filename          string         `json:"filename"`
+	Format            string         `json:"format"`
+	Status            string         `json:"status"`
+	BatchID           string         `json:"batch_id,omitempty"`
+	Parsed            int            `json:"parsed"`
+	Saved             int            `json:"saved"`
+	SkippedDuplicates int            `json:"skipped_duplicates"`
+	Failed            int            `json:"failed"`
+	Profile           *ImportProfile `json:"profile,omitempty"`
+	RejectedRows      []RejectedRow  `json:"rejected_rows"`
+	Error             string         `json:"error,omitempty"`
+	CreatedAt         time.Time      `json:"created_at"`
+	StartedAt         *time.Time     `json:"started_at,omitempty"`
+	FinishedAt        *time.Time     `json:"finished_at,omitempty"`
+}
+
+func (j *ImportJob) Finished() bool {
+	return j.Status == ImportJobCompleted || j.Status == ImportJobFailed || j.Status == ImportJobCancelled
+}
+
+type StagedRow struct {
+	Index int `json:"index"`
+	Transaction
+	Excluded bool `json:"excluded"`
+}
+
+type UpdateStagedRowRequest struct {
+	Category    *string `json:"category"`
+	Description *string `json:"description"`
+	Excluded    *bool   `json:"excluded"`
+}
+
+type XLSXOptions struct {
+	CSVOptions
+	Sheet     string
+	HeaderRow int
+}
+
+type ColumnMapping struct {
+	ID                string         `json:"id"`
+	UserID            string         `json:"user_id"`
+	Name              string         `json:"name"`
+	DateColumn        string         `json:"date_column"`
+	DateFormat        string         `json:"date_format,omitempty"`
+	AmountColumn      string         `json:"amount_column,omitempty"`
+	DebitColumn       string         `json:"debit_column,omitempty"`
+	CreditColumn      string         `json:"credit_column,omitempty"`
+	DescriptionColumn string         `json:"description_column,omitempty"`
+	CategoryColumn    string         `json:"category_column,omitempty"`
+	SkipRows          int            `json:"skip_rows"`
+	SignConvention    SignConvention `json:"sign_convention,omitempty"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+}
+
+type ColumnMappingRequest struct {
+	Name              string         `json:"name" binding:"required"`
+	DateColumn        string         `json:"date_column" binding:"required"`
+	DateFormat        string         `json:"date_format"`
+	AmountColumn      string         `json:"amount_column"`
+	DebitColumn       string         `json:"debit_column"`
+	CreditColumn      string         `json:"credit_column"`
+	DescriptionColumn string         `json:"description_column"`
+	CategoryColumn    string         `json:"category_column"`
+	SkipRows          int            `json:"skip_rows" binding:"min=0"`
+	SignConvention    SignConvention `json:"sign_convention" binding:"omitempty,oneof=debit_positive debit_negative"`
+}
@@ -0,0 +1,325 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxPDFSize = 20 << 20
+
+var invoiceMonths = map[string]time.Month{
+	"JAN": time.January, "FEV": time.February, "MAR": time.March, "ABR": time.April,
+	"MAI": time.May, "JUN": time.June, "JUL": time.July, "AGO": time.August,
+	"SET": time.September, "OUT": time.October, "NOV": time.November, "DEZ": time.December,
+}
+
+const invoiceMonthPattern = `(JAN|FEV|MAR|ABR|MAI|JUN|JUL|AGO|SET|OUT|NOV|DEZ)`
+
+var (
+	invoiceAmountPattern  = `([-−–]?)\s*(?:R\$\s*)?([-−–]?)\s*(\d{1,3}(?:\.\d{3})*,\d{2})`
+	invoiceLine           = regexp.MustCompile(`(?i)^(\d{1,2}) ` + invoiceMonthPattern + `\s+(.+?)\s+` + invoiceAmountPattern + `$`)
+	invoiceLinePrefix     = regexp.MustCompile(`(?i)^(\d{1,2}) ` + invoiceMonthPattern + `\s+\S`)
+	invoiceIOFLine        = regexp.MustCompile(`(?i)^(IOF\b.*?)\s+` + invoiceAmountPattern + `$`)
+	invoiceForeignAmount  = regexp.MustCompile(`(?i)^(USD|EUR|GBP|US\$|€|£)\s*(\d{1,3}(?:[.,]\d{3})*[.,]\d{2})$`)
+	invoiceConversion     = regexp.MustCompile(`(?i)^convers[ãa]o:?\s*(?:USD|EUR|GBP|US\$|€|£)?\s*1\s*=\s*R\$\s*(\d+[.,]\d{2,4})$`)
+	invoiceAmount         = regexp.MustCompile(invoiceAmountPattern)
+	invoiceTextDate       = regexp.MustCompile(`(?i)(\d{1,2})\s+(?:de\s+)?` + invoiceMonthPattern + `[a-zç]*\.?(?:\s+(?:de\s+)?(\d{4}))?`)
+	invoiceNumericDate    = regexp.MustCompile(`(\d{2})/(\d{2})/(\d{4})`)
+	invoicePeriod         = regexp.MustCompile(`(?i)transa[çc][õo]es\s+de\s+\d{1,2}\s+` + invoiceMonthPattern + `\s+a\s+(\d{1,2})\s+` + invoiceMonthPattern)
+	invoiceTotal          = regexp.MustCompile(`(?i)total\s+(?:a\s+pagar|da\s+(?:sua\s+)?fatura)`)
+	invoiceCardMask       = regexp.MustCompile(`^(?:[•*·.]{2,}\s*\d{4}\s+)`)
+	invoiceInstallment    = regexp.MustCompile(`(?i)parcela\s+\d{1,2}\s*/\s*\d{1,2}`)
+	invoiceBareInstalment = regexp.MustCompile(`\s+(\d{1,2})/(\d{1,2})$`)
+)
+
+// ParsePDF reads a credit card invoice PDF. Only Nubank layouts are
+// recognized for now.
+func (s *service) ParsePDF(file io.Reader) (*ParseResult, error) {
+	data, err := io.ReadAll(io.LimitReader(file, maxPDFSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler PDF: %w", err)
+	}
+
+	if len(data) > maxPDFSize {
+		return nil, fmt.Errorf("arquivo PDF excede o limite de %d MB", maxPDFSize>>20)
+	}
+
+	lines, err := extractPDFLines(data)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao extrair texto do PDF: %w", err)
+	}
+
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("nenhum texto encontrado no PDF (arquivos escaneados não são suportados)")
+	}
+
+	return parseNubankInvoice(lines)
+}
+
+func isNubankInvoice(lines []string) bool {
+	for _, line := range lines {
+		lower := strings.ToLower(line)
+		if strings.Contains(lower, "nubank") || strings.Contains(lower, "nu pagamentos") {
+			return true
+		}
+	}
+	return false
+}
+
+func parseNubankInvoice(lines []string) (*ParseResult, error) {
+	if !isNubankInvoice(lines) {
+		return nil, fmt.Errorf("layout de fatura não reconhecido; apenas faturas Nubank são suportadas")
+	}
+
+	invoice := &InvoiceSummary{Issuer: "Nubank"}
+	readInvoiceHeader(lines, invoice)
+
+	reference := time.Now()
+	switch {
+	case invoice.ClosingDate != nil:
+		reference = *invoice.ClosingDate
+	case invoice.DueDate != nil:
+		reference = *invoice.DueDate
+	}
+
+	result := &ParseResult{
+		Invoice: invoice,
+		Profile: &ImportProfile{
+			Name:           "nubank_fatura",
+			Bank:           "Nubank",
+			Description:    "Fatura do cartão Nubank (PDF)",
+			StatementKind:  StatementCreditCard,
+			SignConvention: SignDebitPositive,
+		},
+	}
+	var lastDate time.Time
+
+	for i, line := range lines {
+		if match := invoiceLine.FindStringSubmatch(line); match != nil {
+			date, err := invoiceTransactionDate(match[1], match[2], reference)
+			if err != nil {
+				result.Rejected = append(result.Rejected, RejectedRow{Line: i + 1, Raw: line, Reason: err.Error()})
+				continue
+			}
+
+			amount, err := invoiceValue(match[4]+match[5], match[6])
+			if err != nil {
+				result.Rejected = append(result.Rejected, RejectedRow{Line: i + 1, Raw: line, Reason: err.Error()})
+				continue
+			}
+
+			result.Transactions = append(result.Transactions, Transaction{
+				Date:        date,
+				Description: invoiceDescription(match[3]),
+				Amount:      amount,
+			})
+			lastDate = date
+			continue
+		}
+
+		// International purchases print the amount in the original currency
+		// and the conversion rate on the lines below the purchase.
+		if last := len(result.Transactions) - 1; last >= 0 {
+			t := &result.Transactions[last]
+
+			if match := invoiceForeignAmount.FindStringSubmatch(line); match != nil {
+				if original, err := parseAmount(match[2], LocaleAuto); err == nil {
+					t.setOriginal(match[1], original, t.ExchangeRate)
+				}
+				continue
+			}
+
+			if match := invoiceConversion.FindStringSubmatch(line); match != nil {
+				if rate, err := parseAmount(match[1], LocalePTBR); err == nil && t.OriginalCurrency != "" {
+					t.ExchangeRate = rate
+				}
+				continue
+			}
+		}
+
+		// IOF on international purchases is sometimes printed right below the
+		// purchase without its own date.
+		if match := invoiceIOFLine.FindStringSubmatch(line); match != nil && !lastDate.IsZero() {
+			amount, err := invoiceValue(match[2]+match[3], match[4])
+			if err != nil {
+				result.Rejected = append(result.Rejected, RejectedRow{Line: i + 1, Raw: line, Reason: err.Error()})
+				continue
+			}
+
+			result.Transactions = append(result.Transactions, Transaction{
+				Date:        lastDate,
+				Description: strings.TrimSpace(match[1]),
+				Amount:      amount,
+			})
+			continue
+		}
+
+		if invoiceLinePrefix.MatchString(line) && strings.Contains(line, "R$") {
+			result.Rejected = append(result.Rejected, RejectedRow{Line: i + 1, Raw: line, Reason: "valor da transação não encontrado"})
+		}
+	}
+
+	for _, t := range result.Transactions {
+		invoice.TransactionsTotal += t.Amount
+	}
+	invoice.TransactionsTotal = math.Round(invoice.TransactionsTotal*100) / 100
+
+	return result, nil
+}
+
+func readInvoiceHeader(lines []string, invoice *InvoiceSummary) {
+	for i, line := range lines {
+		lower := strings.ToLower(line)
+
+		if invoice.DueDate == nil && strings.Contains(lower, "vencimento") {
+			invoice.DueDate = invoiceHeaderDate(lines, i, "vencimento")
+		}
+
+		if invoice.ClosingDate == nil && strings.Contains(lower, "fechamento") {
+			invoice.ClosingDate = invoiceHeaderDate(lines, i, "fechamento")
+		}
+
+		if invoice.Total == nil && invoiceTotal.MatchString(line) {
+			loc := invoiceTotal.FindStringIndex(line)
+			text := line[loc[1]:]
+			if !invoiceAmount.MatchString(text) && i+1 < len(lines) {
+				text = lines[i+1]
+			}
+			if match := invoiceAmount.FindStringSubmatch(text); match != nil {
+				if total, err := invoiceValue(match[1]+match[2], match[3]); err == nil {
+					invoice.Total = &total
+				}
+			}
+		}
+	}
+
+	if invoice.ClosingDate == nil {
+		for _, line := range lines {
+			match := invoicePeriod.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			year := time.Now().Year()
+			if invoice.DueDate != nil {
+				year = invoice.DueDate.Year()
+			}
+			day, _ := strconv.Atoi(match[2])
+			closing := time.Date(year, invoiceMonths[strings.ToUpper(match[3])], day, 0, 0, 0, 0, time.UTC)
+			if invoice.DueDate != nil && closing.After(*invoice.DueDate) {
+				closing = closing.AddDate(-1, 0, 0)
+			}
+			invoice.ClosingDate = &closing
+			break
+		}
+	}
+
+	if invoice.DueDate != nil && invoice.DueDate.Year() == 0 {
+		due := invoice.DueDate.AddDate(time.Now().Year(), 0, 0)
+		invoice.DueDate = &due
+	}
+
+	if invoice.ClosingDate != nil && invoice.ClosingDate.Year() == 0 {
+		year := time.Now().Year()
+		if invoice.DueDate != nil {
+			year = invoice.DueDate.Year()
+		}
+		closing := invoice.ClosingDate.AddDate(year, 0, 0)
+		if invoice.DueDate != nil && closing.After(*invoice.DueDate) {
+			closing = closing.AddDate(-1, 0, 0)
+		}
+		invoice.ClosingDate = &closing
+	}
+}
+
+// invoiceHeaderDate finds the date that follows keyword on the line, or on
+// the next line when the layout puts the label and the value in separate
+// rows. Dates printed without a year get year 0 and are fixed up later.
+func invoiceHeaderDate(lines []string, index int, keyword string) *time.Time {
+	text := lines[index]
+	if pos := strings.Index(strings.ToLower(text), keyword); pos >= 0 {
+		text = text[pos:]
+	}
+
+	for _, candidate := range []string{text, nextLine(lines, index)} {
+		if match := invoiceNumericDate.FindStringSubmatch(candidate); match != nil {
+			date, err := time.Parse("02/01/2006", match[0])
+			if err == nil {
+				return &date
+			}
+		}
+
+		if match := invoiceTextDate.FindStringSubmatch(candidate); match != nil {
+			day, _ := strconv.Atoi(match[1])
+			year, _ := strconv.Atoi(match[3])
+			date := time.Date(year, invoiceMonths[strings.ToUpper(match[2])], day, 0, 0, 0, 0, time.UTC)
+			return &date
+		}
+	}
+
+	return nil
+}
+
+func nextLine(lines []string, index int) string {
+	if index+1 < len(lines) {
+		return lines[index+1]
+	}
+	return ""
+}
+
+// invoiceTransactionDate adds the year to a "DD MMM" purchase date: the
+// purchase happened on or before the invoice closing date.
+func invoiceTransactionDate(dayText, monthText string, reference time.Time) (time.Time, error) {
+	day, err := strconv.Atoi(dayText)
+	month, ok := invoiceMonths[strings.ToUpper(monthText)]
+	if err != nil || !ok || day < 1 || day > 31 {
+		return time.Time{}, fmt.Errorf("data inválida: %s %s", dayText, monthText)
+	}
+
+	date := time.Date(reference.Year(), month, day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day {
+		return time.Time{}, fmt.Errorf("data inválida: %s %s", dayText, monthText)
+	}
+
+	if date.After(reference.AddDate(0, 0, 7)) {
+		date = date.AddDate(-1, 0, 0)
+	}
+
+	return date, nil
+}
+
+func invoiceValue(signs, digits string) (float64, error) {
+	value, err := parseAmount(digits, LocalePTBR)
+	if err != nil {
+		return 0, err
+	}
+
+	if strings.ContainsAny(signs, "-−–") {
+		value = -value
+	}
+
+	return value, nil
+}
+
+func invoiceDescription(text string) string {
+	description := strings.TrimSpace(invoiceCardMask.ReplaceAllString(text, ""))
+
+	if !invoiceInstallment.MatchString(description) {
+		match := invoiceBareInstalment.FindStringSubmatch(description)
+		if match != nil && atoi(match[1]) >= 1 && atoi(match[1]) <= atoi(match[2]) {
+			base := strings.TrimSpace(strings.TrimSuffix(description, match[0]))
+			description = fmt.Sprintf("%s - Parcela %s/%s", base, match[1], match[2])
+		}
+	}
+
+	return description
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
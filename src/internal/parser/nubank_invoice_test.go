@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+var nubankInvoiceLines = []string{
+	"Nu Pagamentos S.A.",
+	"Data de fechamento: 03/01/2026",
+	"Data de vencimento: 10/01/2026",
+	"Total a pagar R$ 1.410,46",
+	"28 DEZ Padaria Central R$ 12,50",
+	"30 DEZ Amazon US R$ 530,00",
+	"USD 100,00",
+	"Conversão: USD 1 = R$ 5,30",
+	"IOF de compra internacional R$ 18,55",
+	"01 JAN Pagamento recebido -R$ 800,00",
+	"02 JAN •••• 1234 Loja Tal 3/10 R$ 100,00",
+	"15 JAN Compra sem valor R$",
+}
+
+func TestParseNubankInvoice(t *testing.T) {
+	result, err := parseNubankInvoice(nubankInvoiceLines)
+	if err != nil {
+		t.Fatalf("parseNubankInvoice: %v", err)
+	}
+
+	type want struct {
+		date        string
+		description string
+		amount      float64
+	}
+	wants := []want{
+		{"2025-12-28", "Padaria Central", 12.50},
+		{"2025-12-30", "Amazon US", 530},
+		{"2025-12-30", "IOF de compra internacional", 18.55},
+		{"2026-01-01", "Pagamento recebido", -800},
+		{"2026-01-02", "Loja Tal - Parcela 3/10", 100},
+	}
+
+	if len(result.Transactions) != len(wants) {
+		t.Fatalf("got %d transactions %+v, want %d", len(result.Transactions), result.Transactions, len(wants))
+	}
+	for i, w := range wants {
+		got := result.Transactions[i]
+		if got.Date.Format("2006-01-02") != w.date || got.Description != w.description || got.Amount != w.amount {
+			t.Errorf("transaction %d = %+v, want %+v", i, got, w)
+		}
+	}
+
+	amazon := result.Transactions[1]
+	if amazon.OriginalCurrency != "USD" || amazon.OriginalAmount != 100 || amazon.ExchangeRate != 5.30 {
+		t.Errorf("amazon original = %v %s at %v, want 100 USD at 5.30", amazon.OriginalAmount, amazon.OriginalCurrency, amazon.ExchangeRate)
+	}
+
+	if len(result.Rejected) != 1 || result.Rejected[0].Line != 12 {
+		t.Errorf("rejected = %+v, want line 12", result.Rejected)
+	}
+
+	invoice := result.Invoice
+	if invoice.ClosingDate == nil || !invoice.ClosingDate.Equal(time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("closing date = %v", invoice.ClosingDate)
+	}
+	if invoice.DueDate == nil || !invoice.DueDate.Equal(time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("due date = %v", invoice.DueDate)
+	}
+	if invoice.Total == nil || *invoice.Total != 1410.46 {
+		t.Errorf("total = %v, want 1410.46", invoice.Total)
+	}
+	if invoice.TransactionsTotal != -138.95 {
+		t.Errorf("transactions total = %v, want -138.95", invoice.TransactionsTotal)
+	}
+}
+
+func TestParseNubankInvoiceOtherIssuer(t *testing.T) {
+	if _, err := parseNubankInvoice([]string{"Banco Qualquer", "28 DEZ Padaria R$ 12,50"}); err == nil {
+		t.Error("expected an error for an invoice that is not from Nubank")
+	}
+}
+
+func TestInvoiceTransactionDate(t *testing.T) {
+	closing := time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		day, month string
+		want       string
+	}{
+		{"28", "DEZ", "2025-12-28"},
+		{"2", "jan", "2026-01-02"},
+		{"9", "JAN", "2026-01-09"},
+		{"11", "JAN", "2025-01-11"},
+	}
+
+	for _, tt := range tests {
+		got, err := invoiceTransactionDate(tt.day, tt.month, closing)
+		if err != nil {
+			t.Errorf("invoiceTransactionDate(%s %s): %v", tt.day, tt.month, err)
+			continue
+		}
+		if got.Format("2006-01-02") != tt.want {
+			t.Errorf("invoiceTransactionDate(%s %s) = %s, want %s", tt.day, tt.month, got.Format("2006-01-02"), tt.want)
+		}
+	}
+
+	if _, err := invoiceTransactionDate("31", "FEV", closing); err == nil {
+		t.Error("31 FEV should be rejected")
+	}
+}
+
+func TestParsePDFNubankInvoice(t *testing.T) {
+	data := buildPDF(
+		"Nubank",
+		"Data de fechamento: 03/01/2026",
+		"28 DEZ Padaria Central R$ 12,50",
+		"02 JAN Mercado R$ 100,00",
+	)
+
+	result, err := NewService().ParsePDF(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParsePDF: %v", err)
+	}
+	if len(result.Transactions) != 2 || result.Transactions[0].Description != "Padaria Central" || result.Transactions[1].Amount != 100 {
+		t.Errorf("transactions = %+v", result.Transactions)
+	}
+}
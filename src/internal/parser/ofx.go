@@ -0,0 +1,254 @@
+package parser
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+type ofxToken struct {
+	name    string
+	closing bool
+	value   string
+	offset  int
+	end     int
+}
+
+type ofxTransaction struct {
+	line    int
+	start   int
+	trnType string
+	posted  string
+	amount  string
+	fitID   string
+	name    string
+	memo    string
+
+	// currency is set by a CURRENCY aggregate (TRNAMT is in that currency)
+	// or an ORIGCURRENCY one (TRNAMT was converted from it).
+	currency     string
+	currencyRate string
+	converted    bool
+}
+
+func (s *service) ParseOFX(file io.Reader) (*ParseResult, error) {
+	text, err := newTextReader(file, EncodingAuto)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(text)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo OFX: %w", err)
+	}
+
+	body := string(data)
+	start := indexFold(body, "<OFX>")
+	if start == -1 {
+		return nil, fmt.Errorf("arquivo OFX inválido: tag <OFX> não encontrada")
+	}
+
+	result := &ParseResult{}
+	kind := StatementChecking
+	var current *ofxTransaction
+
+	line, counted := 1, 0
+
+	for _, token := range scanOFX(body[start:]) {
+		if token.name == "STMTTRN" {
+			if !token.closing {
+				line += strings.Count(body[counted:start+token.offset], "\n")
+				counted = start + token.offset
+				current = &ofxTransaction{
+					line:  line,
+					start: start + token.offset,
+				}
+				continue
+			}
+
+			if current != nil {
+				if t, err := current.toTransaction(); err == nil {
+					result.Transactions = append(result.Transactions, t)
+				} else {
+					result.Rejected = append(result.Rejected, RejectedRow{
+						Line:   current.line,
+						Raw:    strings.Join(strings.Fields(body[current.start:start+token.end]), " "),
+						Reason: err.Error(),
+					})
+				}
+			}
+			current = nil
+			continue
+		}
+
+		if !token.closing {
+			switch {
+			case token.name == "CCSTMTRS":
+				kind = StatementCreditCard
+			case token.name == "ACCTTYPE" && strings.EqualFold(strings.TrimSpace(token.value), "SAVINGS"):
+				kind = StatementSavings
+			}
+		}
+
+		if current == nil || token.closing {
+			continue
+		}
+
+		switch token.name {
+		case "TRNTYPE":
+			current.trnType = token.value
+		case "DTPOSTED":
+			current.posted = token.value
+		case "TRNAMT":
+			current.amount = token.value
+		case "FITID":
+			current.fitID = token.value
+		case "NAME":
+			current.name = token.value
+		case "MEMO":
+			current.memo = token.value
+		case "ORIGCURRENCY":
+			current.converted = true
+		case "CURSYM":
+			current.currency = strings.TrimSpace(token.value)
+		case "CURRATE":
+			current.currencyRate = strings.TrimSpace(token.value)
+		}
+	}
+
+	// OFX amounts are always from the account holder's side: card charges
+	// and account debits are both negative.
+	result.Profile = &ImportProfile{
+		Name:           "ofx",
+		Description:    "Extrato OFX",
+		StatementKind:  kind,
+		SignConvention: SignDebitNegative,
+	}
+
+	return result, nil
+}
+
+// scanOFX tokenizes both OFX 1.x (SGML, leaf tags without closing tags) and
+// OFX 2.x (XML) bodies. Each opening tag carries the text up to the next tag.
+func scanOFX(body string) []ofxToken {
+	var tokens []ofxToken
+
+	pos := 0
+	for {
+		open := strings.IndexByte(body[pos:], '<')
+		if open == -1 {
+			break
+		}
+		offset := pos + open
+
+		end := strings.IndexByte(body[offset:], '>')
+		if end == -1 {
+			break
+		}
+		tag := strings.TrimSpace(body[offset+1 : offset+end])
+		pos = offset + end + 1
+
+		if tag == "" || strings.HasPrefix(tag, "?") || strings.HasPrefix(tag, "!") {
+			continue
+		}
+
+		token := ofxToken{offset: offset, end: pos}
+		if strings.HasPrefix(tag, "/") {
+			token.closing = true
+			tag = tag[1:]
+		}
+		tag = strings.TrimSuffix(tag, "/")
+		if fields := strings.Fields(tag); len(fields) > 0 {
+			token.name = strings.ToUpper(fields[0])
+		}
+
+		if !token.closing {
+			next := strings.IndexByte(body[pos:], '<')
+			if next == -1 {
+				next = len(body) - pos
+			}
+			token.value = html.UnescapeString(strings.TrimSpace(body[pos : pos+next]))
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens
+}
+
+func (t *ofxTransaction) toTransaction() (Transaction, error) {
+	date, err := parseOFXDate(t.posted)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	amount, err := parseAmount(t.amount, LocaleAuto)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	description := t.name
+	if t.memo != "" && !strings.EqualFold(t.memo, t.name) {
+		if description != "" {
+			description += " - "
+		}
+		description += t.memo
+	}
+	if description == "" {
+		description = t.trnType
+	}
+
+	transaction := Transaction{
+		Date:        date,
+		Description: description,
+		Amount:      amount,
+		ExternalID:  t.fitID,
+	}
+
+	if t.currency != "" {
+		rate, err := parseAmount(t.currencyRate, LocaleENUS)
+		if err != nil || rate <= 0 {
+			return Transaction{}, fmt.Errorf("cotação inválida para %s: %s", t.currency, t.currencyRate)
+		}
+
+		// CURRATE is the value of one unit of the foreign currency in the
+		// statement's default currency.
+		original := amount / rate
+		if !t.converted {
+			original = amount
+			transaction.Amount = math.Round(amount*rate*100) / 100
+		}
+		transaction.setOriginal(t.currency, original, rate)
+	}
+
+	return transaction, nil
+}
+
+func parseOFXDate(dateStr string) (time.Time, error) {
+	dateStr = strings.TrimSpace(dateStr)
+	if len(dateStr) < 8 {
+		return time.Time{}, fmt.Errorf("formato de data inválido: %s", dateStr)
+	}
+
+	date, err := time.Parse("20060102", dateStr[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("formato de data inválido: %s", dateStr)
+	}
+
+	return date, nil
+}
+
+// indexFold is a case-insensitive strings.Index for an ASCII substr. It
+// returns a byte offset into s itself, which strings.ToUpper would not
+// preserve for text with non-ASCII characters.
+func indexFold(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+	return -1
+}
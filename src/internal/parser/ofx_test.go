@@ -0,0 +1,147 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const ofxStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+CHARSET:1252
+
+%s
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>BRL
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20260105120000[-3:BRT]
+<TRNAMT>-42.50
+<FITID>abc1
+<MEMO>Padaria São João
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20260106
+<TRNAMT>1000.00
+<FITID>abc2
+<MEMO>Salário
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+%s`
+
+func TestParseOFXTagCase(t *testing.T) {
+	tests := []struct {
+		name  string
+		open  string
+		close string
+	}{
+		{"upper", "<OFX>", "</OFX>"},
+		{"lower", "<ofx>", "</ofx>"},
+		{"mixed", "<Ofx>", "</Ofx>"},
+		{"non-ascii before the tag", "<!-- Extrato São Paulo --><OFX>", "</OFX>"},
+	}
+
+	s := NewService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := fmt.Sprintf(ofxStatement, tt.open, tt.close)
+			result, err := s.ParseOFX(strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("ParseOFX: %v", err)
+			}
+			if len(result.Transactions) != 2 {
+				t.Fatalf("got %d transactions, want 2", len(result.Transactions))
+			}
+			if got := result.Transactions[0].Description; !strings.Contains(got, "Padaria São João") {
+				t.Errorf("description = %q", got)
+			}
+		})
+	}
+}
+
+func TestParseOFXWithoutTag(t *testing.T) {
+	if _, err := NewService().ParseOFX(strings.NewReader("OFXHEADER:100\n<BANKMSGSRSV1>")); err == nil {
+		t.Error("expected an error for a file without <OFX>")
+	}
+}
+
+const ofxXML = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX>
+  <CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS>
+    <CURDEF>BRL</CURDEF>
+    <BANKTRANLIST>
+      <STMTTRN>
+        <TRNTYPE>DEBIT</TRNTYPE>
+        <DTPOSTED>20260110</DTPOSTED>
+        <TRNAMT>-530.00</TRNAMT>
+        <FITID>xml1</FITID>
+        <NAME>AMAZON</NAME>
+        <MEMO>Livros &amp; revistas</MEMO>
+        <ORIGCURRENCY><CURRATE>5.30</CURRATE><CURSYM>USD</CURSYM></ORIGCURRENCY>
+      </STMTTRN>
+      <STMTTRN>
+        <TRNTYPE>DEBIT</TRNTYPE>
+        <DTPOSTED>não é data</DTPOSTED>
+        <TRNAMT>-1.00</TRNAMT>
+        <FITID>xml2</FITID>
+      </STMTTRN>
+    </BANKTRANLIST>
+  </CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1>
+</OFX>`
+
+func TestParseOFXXML(t *testing.T) {
+	result, err := NewService().ParseOFX(strings.NewReader(ofxXML))
+	if err != nil {
+		t.Fatalf("ParseOFX: %v", err)
+	}
+
+	if len(result.Transactions) != 1 {
+		t.Fatalf("got %d transactions, want 1", len(result.Transactions))
+	}
+	got := result.Transactions[0]
+	if got.Description != "AMAZON - Livros & revistas" || got.Amount != -530 || got.ExternalID != "xml1" || got.Date.Format("2006-01-02") != "2026-01-10" {
+		t.Errorf("transaction = %+v", got)
+	}
+	if got.OriginalCurrency != "USD" || got.OriginalAmount != 100 {
+		t.Errorf("original amount = %v %s, want 100 USD", got.OriginalAmount, got.OriginalCurrency)
+	}
+
+	if len(result.Rejected) != 1 || !strings.Contains(result.Rejected[0].Raw, "xml2") {
+		t.Errorf("rejected = %+v, want the transaction with an invalid date", result.Rejected)
+	}
+	if result.Profile.StatementKind != StatementCreditCard {
+		t.Errorf("statement kind = %q, want %q", result.Profile.StatementKind, StatementCreditCard)
+	}
+}
+
+func TestParseOFXDate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"20260105", "2026-01-05"},
+		{"20260105120000", "2026-01-05"},
+		{"20260105233000[-3:BRT]", "2026-01-05"},
+		{"20260105120000.000", "2026-01-05"},
+	}
+
+	for _, tt := range tests {
+		got, err := parseOFXDate(tt.value)
+		if err != nil {
+			t.Errorf("parseOFXDate(%q): %v", tt.value, err)
+			continue
+		}
+		if got.Format("2006-01-02") != tt.want {
+			t.Errorf("parseOFXDate(%q) = %s, want %s", tt.value, got.Format("2006-01-02"), tt.want)
+		}
+	}
+
+	if _, err := parseOFXDate("2026"); err == nil {
+		t.Error("parseOFXDate(2026) should fail")
+	}
+}
@@ -0,0 +1,1209 @@
+package parser
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// This file implements a small PDF text extractor: enough of the object
+// model to walk the page tree, decode Flate streams and object streams,
+// map glyphs through ToUnicode CMaps and rebuild text lines from glyph
+// positions. It does not aim to render PDFs, only to read statements.
+
+type pdfName string
+
+type pdfRef struct {
+	num int
+	gen int
+}
+
+type pdfKeyword string
+
+type pdfDict map[pdfName]interface{}
+
+type pdfStream struct {
+	dict pdfDict
+	raw  []byte
+}
+
+type pdfDocument struct {
+	objects map[int]interface{}
+	fonts   map[int]*pdfFont
+
+	decoded int
+	forms   int
+	glyphs  int
+}
+
+// Limits on the work one document may cause, so a small crafted file cannot
+// hang the import or exhaust memory. A single decoded stream may not exceed
+// maxPDFStreamSize and all decoded streams together maxPDFDecodedSize; form
+// XObject invocations and extracted glyphs are budgeted per document too.
+const (
+	maxPDFStreamSize  = 32 << 20
+	maxPDFDecodedSize = 128 << 20
+	maxPDFFormCalls   = 1000
+	maxPDFGlyphs      = 1 << 20
+)
+
+var errPDFStreamSize = errors.New("stream PDF descompactado excede o limite")
+
+var pdfObjectHeader = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+
+func readPDF(data []byte) (*pdfDocument, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, "\x00\t\r\n "), []byte("%PDF")) {
+		return nil, fmt.Errorf("arquivo não é um PDF")
+	}
+
+	doc := &pdfDocument{
+		objects: make(map[int]interface{}),
+		fonts:   make(map[int]*pdfFont),
+	}
+
+	for _, match := range pdfObjectHeader.FindAllSubmatchIndex(data, -1) {
+		num, _ := strconv.Atoi(string(data[match[2]:match[3]]))
+
+		lex := &pdfLexer{data: data, pos: match[1]}
+		value, err := lex.parseValue()
+		if err != nil {
+			continue
+		}
+
+		if dict, ok := value.(pdfDict); ok {
+			if raw, ok := lex.readStream(dict); ok {
+				value = &pdfStream{dict: dict, raw: raw}
+			}
+		}
+
+		doc.objects[num] = value
+	}
+
+	for _, object := range doc.objects {
+		stream, ok := object.(*pdfStream)
+		if !ok || stream.dict["Type"] != pdfName("ObjStm") {
+			continue
+		}
+		doc.loadObjectStream(stream)
+	}
+
+	if len(doc.objects) == 0 {
+		return nil, fmt.Errorf("nenhum objeto PDF encontrado")
+	}
+
+	return doc, nil
+}
+
+func (d *pdfDocument) loadObjectStream(stream *pdfStream) {
+	data, err := d.decodeStream(stream)
+	if err != nil {
+		return
+	}
+
+	count := int(d.number(stream.dict["N"]))
+	first := int(d.number(stream.dict["First"]))
+	if first <= 0 || first > len(data) {
+		return
+	}
+
+	header := &pdfLexer{data: data[:first]}
+	for i := 0; i < count; i++ {
+		numTok, err1 := header.next()
+		offTok, err2 := header.next()
+		if err1 != nil || err2 != nil {
+			return
+		}
+
+		num, ok1 := numTok.(float64)
+		offset, ok2 := offTok.(float64)
+		if !ok1 || !ok2 || offset < 0 || first+int(offset) >= len(data) {
+			return
+		}
+
+		if _, exists := d.objects[int(num)]; exists {
+			continue
+		}
+
+		lex := &pdfLexer{data: data, pos: first + int(offset)}
+		if value, err := lex.parseValue(); err == nil {
+			d.objects[int(num)] = value
+		}
+	}
+}
+
+func (d *pdfDocument) resolve(value interface{}) interface{} {
+	for i := 0; i < 32; i++ {
+		ref, ok := value.(pdfRef)
+		if !ok {
+			return value
+		}
+		value = d.objects[ref.num]
+	}
+	return nil
+}
+
+func (d *pdfDocument) dict(value interface{}) pdfDict {
+	switch v := d.resolve(value).(type) {
+	case pdfDict:
+		return v
+	case *pdfStream:
+		return v.dict
+	}
+	return nil
+}
+
+func (d *pdfDocument) array(value interface{}) []interface{} {
+	if arr, ok := d.resolve(value).([]interface{}); ok {
+		return arr
+	}
+	return nil
+}
+
+func (d *pdfDocument) number(value interface{}) float64 {
+	if n, ok := d.resolve(value).(float64); ok {
+		return n
+	}
+	return 0
+}
+
+func (d *pdfDocument) decodeStream(stream *pdfStream) ([]byte, error) {
+	var filters []interface{}
+	switch f := d.resolve(stream.dict["Filter"]).(type) {
+	case pdfName:
+		filters = []interface{}{f}
+	case []interface{}:
+		filters = f
+	}
+
+	data := stream.raw
+	for _, filter := range filters {
+		switch d.resolve(filter) {
+		case pdfName("FlateDecode"), pdfName("Fl"):
+			decoded, err := inflate(data, min(maxPDFStreamSize, maxPDFDecodedSize-d.decoded))
+			if err != nil {
+				return nil, err
+			}
+			data = decoded
+		default:
+			return nil, fmt.Errorf("filtro PDF não suportado: %v", filter)
+		}
+	}
+
+	// Streams are decoded again each time a page or form uses them, so the
+	// budget counts every decoding, compressed or not.
+	if len(data) > maxPDFDecodedSize-d.decoded {
+		return nil, errPDFStreamSize
+	}
+	d.decoded += len(data)
+
+	return data, nil
+}
+
+// inflate decompresses a zlib or raw deflate stream, refusing to produce
+// more than limit bytes.
+func inflate(data []byte, limit int) ([]byte, error) {
+	if r, err := zlib.NewReader(bytes.NewReader(data)); err == nil {
+		decoded, err := readLimited(r, limit)
+		if errors.Is(err, errPDFStreamSize) {
+			return nil, err
+		}
+		if err == nil || len(decoded) > 0 {
+			return decoded, nil
+		}
+	}
+
+	decoded, err := readLimited(flate.NewReader(bytes.NewReader(data)), limit)
+	if errors.Is(err, errPDFStreamSize) {
+		return nil, err
+	}
+	if err != nil && len(decoded) == 0 {
+		return nil, fmt.Errorf("erro ao descompactar stream PDF: %w", err)
+	}
+	return decoded, nil
+}
+
+func readLimited(r io.Reader, limit int) ([]byte, error) {
+	decoded, err := io.ReadAll(io.LimitReader(r, int64(limit)+1))
+	if len(decoded) > limit {
+		return nil, errPDFStreamSize
+	}
+	return decoded, err
+}
+
+// pages returns the page dictionaries in reading order together with the
+// resources each one inherits from the page tree.
+func (d *pdfDocument) pages() []pdfPage {
+	var root pdfDict
+	for _, object := range d.objects {
+		if dict, ok := object.(pdfDict); ok && dict["Type"] == pdfName("Catalog") {
+			root = dict
+			break
+		}
+	}
+
+	var pages []pdfPage
+	if root != nil {
+		d.walkPages(d.dict(root["Pages"]), nil, &pages, make(map[int]bool), 0)
+	}
+
+	if len(pages) > 0 {
+		return pages
+	}
+
+	var nums []int
+	for num, object := range d.objects {
+		if dict := d.dict(object); dict != nil && dict["Type"] == pdfName("Page") {
+			nums = append(nums, num)
+		}
+	}
+	sort.Ints(nums)
+
+	for _, num := range nums {
+		page := d.dict(d.objects[num])
+		pages = append(pages, pdfPage{dict: page, resources: d.dict(page["Resources"])})
+	}
+	return pages
+}
+
+type pdfPage struct {
+	dict      pdfDict
+	resources pdfDict
+}
+
+// walkPages visits each referenced node once, since a Kids array that lists
+// the same object more than once would otherwise multiply at every level.
+func (d *pdfDocument) walkPages(node pdfDict, inherited pdfDict, pages *[]pdfPage, visited map[int]bool, depth int) {
+	if node == nil || depth > 64 {
+		return
+	}
+
+	resources := inherited
+	if own := d.dict(node["Resources"]); own != nil {
+		resources = own
+	}
+
+	if node["Type"] == pdfName("Page") {
+		*pages = append(*pages, pdfPage{dict: node, resources: resources})
+		return
+	}
+
+	for _, kid := range d.array(node["Kids"]) {
+		if ref, ok := kid.(pdfRef); ok {
+			if visited[ref.num] {
+				continue
+			}
+			visited[ref.num] = true
+		}
+		d.walkPages(d.dict(kid), resources, pages, visited, depth+1)
+	}
+}
+
+func (d *pdfDocument) pageContent(page pdfDict) []byte {
+	var parts []interface{}
+	switch contents := page["Contents"].(type) {
+	case []interface{}:
+		parts = contents
+	default:
+		if arr := d.array(contents); arr != nil {
+			parts = arr
+		} else {
+			parts = []interface{}{contents}
+		}
+	}
+
+	var content bytes.Buffer
+	for _, part := range parts {
+		stream, ok := d.resolve(part).(*pdfStream)
+		if !ok {
+			continue
+		}
+		if data, err := d.decodeStream(stream); err == nil {
+			content.Write(data)
+			content.WriteByte('\n')
+		}
+	}
+	return content.Bytes()
+}
+
+// extractPDFLines returns the text of every page, one string per visual line.
+func extractPDFLines(data []byte) ([]string, error) {
+	doc, err := readPDF(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, page := range doc.pages() {
+		extractor := &pdfTextExtractor{doc: doc}
+		extractor.run(doc.pageContent(page.dict), page.resources, pdfIdentity, 0)
+		lines = append(lines, extractor.lines()...)
+	}
+
+	return lines, nil
+}
+
+type pdfMatrix [6]float64
+
+var pdfIdentity = pdfMatrix{1, 0, 0, 1, 0, 0}
+
+func (m pdfMatrix) multiply(n pdfMatrix) pdfMatrix {
+	return pdfMatrix{
+		m[0]*n[0] + m[1]*n[2],
+		m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2],
+		m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4],
+		m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+func (m pdfMatrix) translate(tx, ty float64) pdfMatrix {
+	return pdfMatrix{1, 0, 0, 1, tx, ty}.multiply(m)
+}
+
+type pdfGlyph struct {
+	x, y, end, size float64
+	text            string
+}
+
+type pdfTextState struct {
+	font      *pdfFont
+	fontSize  float64
+	charSpace float64
+	wordSpace float64
+	scale     float64
+	leading   float64
+	rise      float64
+}
+
+type pdfTextExtractor struct {
+	doc    *pdfDocument
+	glyphs []pdfGlyph
+}
+
+func (e *pdfTextExtractor) run(content []byte, resources pdfDict, ctm pdfMatrix, depth int) {
+	if depth > 8 {
+		return
+	}
+
+	state := pdfTextState{scale: 1}
+	var stack []pdfMatrix
+	var stateStack []pdfTextState
+	tm, tlm := pdfIdentity, pdfIdentity
+
+	lex := &pdfLexer{data: content}
+	var operands []interface{}
+
+	for {
+		token, err := lex.parseValue()
+		if err != nil {
+			break
+		}
+
+		op, ok := token.(pdfKeyword)
+		if !ok {
+			operands = append(operands, token)
+			continue
+		}
+
+		num := func(i int) float64 {
+			if i < len(operands) {
+				if n, ok := operands[i].(float64); ok {
+					return n
+				}
+			}
+			return 0
+		}
+
+		switch op {
+		case "q":
+			stack = append(stack, ctm)
+			stateStack = append(stateStack, state)
+		case "Q":
+			if len(stack) > 0 {
+				ctm = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				state = stateStack[len(stateStack)-1]
+				stateStack = stateStack[:len(stateStack)-1]
+			}
+		case "cm":
+			ctm = pdfMatrix{num(0), num(1), num(2), num(3), num(4), num(5)}.multiply(ctm)
+		case "BT":
+			tm, tlm = pdfIdentity, pdfIdentity
+		case "Tf":
+			if len(operands) >= 2 {
+				if name, ok := operands[0].(pdfName); ok {
+					state.font = e.font(resources, name)
+				}
+				state.fontSize = num(1)
+			}
+		case "Tc":
+			state.charSpace = num(0)
+		case "Tw":
+			state.wordSpace = num(0)
+		case "Tz":
+			state.scale = num(0) / 100
+		case "TL":
+			state.leading = num(0)
+		case "Ts":
+			state.rise = num(0)
+		case "Td":
+			tlm = tlm.translate(num(0), num(1))
+			tm = tlm
+		case "TD":
+			state.leading = -num(1)
+			tlm = tlm.translate(num(0), num(1))
+			tm = tlm
+		case "Tm":
+			tlm = pdfMatrix{num(0), num(1), num(2), num(3), num(4), num(5)}
+			tm = tlm
+		case "T*":
+			tlm = tlm.translate(0, -state.leading)
+			tm = tlm
+		case "Tj":
+			if len(operands) > 0 {
+				tm = e.show(operands[0], state, tm, ctm)
+			}
+		case "'":
+			tlm = tlm.translate(0, -state.leading)
+			tm = tlm
+			if len(operands) > 0 {
+				tm = e.show(operands[0], state, tm, ctm)
+			}
+		case "\"":
+			state.wordSpace = num(0)
+			state.charSpace = num(1)
+			tlm = tlm.translate(0, -state.leading)
+			tm = tlm
+			if len(operands) > 2 {
+				tm = e.show(operands[2], state, tm, ctm)
+			}
+		case "TJ":
+			if len(operands) == 0 {
+				break
+			}
+			items, _ := operands[0].([]interface{})
+			for _, item := range items {
+				switch v := item.(type) {
+				case float64:
+					tm = tm.translate(-v/1000*state.fontSize*state.scale, 0)
+				default:
+					tm = e.show(v, state, tm, ctm)
+				}
+			}
+		case "Do":
+			if len(operands) > 0 {
+				if name, ok := operands[0].(pdfName); ok {
+					e.drawForm(resources, name, ctm, depth)
+				}
+			}
+		case "BI":
+			lex.skipInlineImage()
+		}
+
+		operands = operands[:0]
+	}
+}
+
+func (e *pdfTextExtractor) drawForm(resources pdfDict, name pdfName, ctm pdfMatrix, depth int) {
+	if e.doc.forms >= maxPDFFormCalls {
+		return
+	}
+	e.doc.forms++
+
+	xobjects := e.doc.dict(resources["XObject"])
+	if xobjects == nil {
+		return
+	}
+
+	form, ok := e.doc.resolve(xobjects[name]).(*pdfStream)
+	if !ok || form.dict["Subtype"] != pdfName("Form") {
+		return
+	}
+
+	content, err := e.doc.decodeStream(form)
+	if err != nil {
+		return
+	}
+
+	matrix := pdfIdentity
+	if values := e.doc.array(form.dict["Matrix"]); len(values) == 6 {
+		for i, v := range values {
+			matrix[i] = e.doc.number(v)
+		}
+	}
+
+	formResources := e.doc.dict(form.dict["Resources"])
+	if formResources == nil {
+		formResources = resources
+	}
+
+	e.run(content, formResources, matrix.multiply(ctm), depth+1)
+}
+
+func (e *pdfTextExtractor) show(value interface{}, state pdfTextState, tm, ctm pdfMatrix) pdfMatrix {
+	str, ok := value.([]byte)
+	if !ok || state.font == nil {
+		if ok {
+			state.font = defaultPDFFont
+		} else {
+			return tm
+		}
+	}
+
+	for _, code := range state.font.split(str) {
+		if e.doc.glyphs >= maxPDFGlyphs {
+			return tm
+		}
+		e.doc.glyphs++
+
+		trm := pdfMatrix{state.fontSize * state.scale, 0, 0, state.fontSize, 0, state.rise}.multiply(tm).multiply(ctm)
+
+		advance := state.font.width(code)/1000*state.fontSize + state.charSpace
+		if code.value == 32 && len(code.raw) == 1 {
+			advance += state.wordSpace
+		}
+		next := tm.translate(advance*state.scale, 0)
+		end := next.multiply(ctm)
+
+		size := math.Hypot(trm[2], trm[3])
+		e.glyphs = append(e.glyphs, pdfGlyph{
+			x:    trm[4],
+			y:    trm[5],
+			end:  end[4],
+			size: size,
+			text: state.font.decode(code),
+		})
+
+		tm = next
+	}
+
+	return tm
+}
+
+func (e *pdfTextExtractor) font(resources pdfDict, name pdfName) *pdfFont {
+	fonts := e.doc.dict(resources["Font"])
+	if fonts == nil {
+		return defaultPDFFont
+	}
+
+	ref, isRef := fonts[name].(pdfRef)
+	if isRef {
+		if cached, ok := e.doc.fonts[ref.num]; ok {
+			return cached
+		}
+	}
+
+	font := e.doc.loadFont(e.doc.dict(fonts[name]))
+	if isRef {
+		e.doc.fonts[ref.num] = font
+	}
+	return font
+}
+
+// lines groups glyphs into visual lines (top to bottom) and words, adding a
+// space wherever the gap between two glyphs is wider than a fraction of the
+// font size.
+func (e *pdfTextExtractor) lines() []string {
+	glyphs := e.glyphs
+	sort.SliceStable(glyphs, func(i, j int) bool {
+		return glyphs[i].y > glyphs[j].y
+	})
+
+	var rows [][]pdfGlyph
+	for _, glyph := range glyphs {
+		if glyph.text == "" {
+			continue
+		}
+		last := len(rows) - 1
+		if last >= 0 {
+			tolerance := math.Max(rows[last][0].size, glyph.size) * 0.4
+			if math.Abs(rows[last][0].y-glyph.y) <= tolerance {
+				rows[last] = append(rows[last], glyph)
+				continue
+			}
+		}
+		rows = append(rows, []pdfGlyph{glyph})
+	}
+
+	var result []string
+	for _, row := range rows {
+		sort.SliceStable(row, func(i, j int) bool {
+			return row[i].x < row[j].x
+		})
+
+		var b strings.Builder
+		for i, glyph := range row {
+			if i > 0 {
+				gap := glyph.x - row[i-1].end
+				if gap > glyph.size*0.2 && !strings.HasSuffix(b.String(), " ") && !strings.HasPrefix(glyph.text, " ") {
+					b.WriteByte(' ')
+				}
+			}
+			b.WriteString(glyph.text)
+		}
+
+		line := strings.Join(strings.Fields(b.String()), " ")
+		if line != "" {
+			result = append(result, line)
+		}
+	}
+
+	return result
+}
+
+type pdfCode struct {
+	raw   []byte
+	value int
+}
+
+type pdfFont struct {
+	codeLength   int
+	toUnicode    map[int]string
+	firstChar    int
+	widths       []float64
+	cidWidths    map[int]float64
+	defaultWidth float64
+}
+
+var defaultPDFFont = &pdfFont{codeLength: 1, defaultWidth: 500}
+
+func (d *pdfDocument) loadFont(dict pdfDict) *pdfFont {
+	if dict == nil {
+		return defaultPDFFont
+	}
+
+	font := &pdfFont{codeLength: 1, defaultWidth: 500}
+
+	if dict["Subtype"] == pdfName("Type0") {
+		font.codeLength = 2
+		font.defaultWidth = 1000
+		if descendants := d.array(dict["DescendantFonts"]); len(descendants) > 0 {
+			descendant := d.dict(descendants[0])
+			if dw := d.number(descendant["DW"]); dw > 0 {
+				font.defaultWidth = dw
+			}
+			font.cidWidths = d.cidWidths(d.array(descendant["W"]))
+		}
+	} else {
+		font.firstChar = int(d.number(dict["FirstChar"]))
+		for _, w := range d.array(dict["Widths"]) {
+			font.widths = append(font.widths, d.number(w))
+		}
+	}
+
+	if stream, ok := d.resolve(dict["ToUnicode"]).(*pdfStream); ok {
+		if data, err := d.decodeStream(stream); err == nil {
+			font.toUnicode, font.codeLength = parseToUnicode(data, font.codeLength)
+		}
+	}
+
+	return font
+}
+
+func (d *pdfDocument) cidWidths(w []interface{}) map[int]float64 {
+	widths := make(map[int]float64)
+	for i := 0; i+1 < len(w); {
+		first := int(d.number(w[i]))
+		if list := d.array(w[i+1]); list != nil {
+			for j, width := range list {
+				widths[first+j] = d.number(width)
+			}
+			i += 2
+			continue
+		}
+		if i+2 >= len(w) {
+			break
+		}
+		last := int(d.number(w[i+1]))
+		width := d.number(w[i+2])
+		for cid := first; cid <= last && cid-first < 65536; cid++ {
+			widths[cid] = width
+		}
+		i += 3
+	}
+	return widths
+}
+
+func (f *pdfFont) split(str []byte) []pdfCode {
+	var codes []pdfCode
+	for i := 0; i < len(str); i += f.codeLength {
+		end := i + f.codeLength
+		if end > len(str) {
+			end = len(str)
+		}
+		value := 0
+		for _, b := range str[i:end] {
+			value = value<<8 | int(b)
+		}
+		codes = append(codes, pdfCode{raw: str[i:end], value: value})
+	}
+	return codes
+}
+
+func (f *pdfFont) width(code pdfCode) float64 {
+	if f.cidWidths != nil {
+		if w, ok := f.cidWidths[code.value]; ok {
+			return w
+		}
+		return f.defaultWidth
+	}
+
+	index := code.value - f.firstChar
+	if index >= 0 && index < len(f.widths) && f.widths[index] > 0 {
+		return f.widths[index]
+	}
+	return f.defaultWidth
+}
+
+func (f *pdfFont) decode(code pdfCode) string {
+	if f.toUnicode != nil {
+		if text, ok := f.toUnicode[code.value]; ok {
+			return text
+		}
+	}
+
+	if f.codeLength == 1 {
+		text, err := charmap.Windows1252.NewDecoder().Bytes(code.raw)
+		if err == nil {
+			return string(text)
+		}
+	}
+
+	return ""
+}
+
+func parseToUnicode(data []byte, codeLength int) (map[int]string, int) {
+	mapping := make(map[int]string)
+	lex := &pdfLexer{data: data}
+
+	var operands []interface{}
+	mode := ""
+
+	for {
+		token, err := lex.parseValue()
+		if err != nil {
+			break
+		}
+
+		keyword, ok := token.(pdfKeyword)
+		if !ok {
+			operands = append(operands, token)
+			continue
+		}
+
+		switch keyword {
+		case "begincodespacerange", "beginbfchar", "beginbfrange":
+			mode = string(keyword)
+			operands = operands[:0]
+			continue
+		case "endcodespacerange":
+			if len(operands) > 0 {
+				if low, ok := operands[0].([]byte); ok && len(low) > 0 {
+					codeLength = len(low)
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].([]byte)
+				dst, ok2 := operands[i+1].([]byte)
+				if ok1 && ok2 {
+					mapping[bytesToCode(src)] = utf16BytesToString(dst)
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				low, ok1 := operands[i].([]byte)
+				high, ok2 := operands[i+1].([]byte)
+				if !ok1 || !ok2 {
+					continue
+				}
+				start, end := bytesToCode(low), bytesToCode(high)
+				if end-start > 65535 {
+					continue
+				}
+				switch dst := operands[i+2].(type) {
+				case []byte:
+					base := []rune(utf16BytesToString(dst))
+					if len(base) == 0 {
+						continue
+					}
+					for code := start; code <= end; code++ {
+						runes := append([]rune(nil), base...)
+						runes[len(runes)-1] += rune(code - start)
+						mapping[code] = string(runes)
+					}
+				case []interface{}:
+					for j, item := range dst {
+						if b, ok := item.([]byte); ok && start+j <= end {
+							mapping[start+j] = utf16BytesToString(b)
+						}
+					}
+				}
+			}
+		}
+
+		if mode != "" && strings.HasPrefix(string(keyword), "end") {
+			mode = ""
+		}
+		operands = operands[:0]
+	}
+
+	return mapping, codeLength
+}
+
+func bytesToCode(b []byte) int {
+	value := 0
+	for _, c := range b {
+		value = value<<8 | int(c)
+	}
+	return value
+}
+
+func utf16BytesToString(b []byte) string {
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+func isPDFWhitespace(c byte) bool {
+	return c == 0 || c == '\t' || c == '\n' || c == '\f' || c == '\r' || c == ' '
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if isPDFWhitespace(c) {
+			l.pos++
+			continue
+		}
+		if c == '%' {
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+			continue
+		}
+		break
+	}
+}
+
+// next returns the next raw token: float64, pdfName, []byte (strings),
+// pdfKeyword, or one of the delimiter keywords "[", "]", "<<", ">>".
+// Stray ">" and ")" bytes are skipped.
+func (l *pdfLexer) next() (interface{}, error) {
+	for {
+		l.skipSpace()
+		if l.pos >= len(l.data) {
+			return nil, io.EOF
+		}
+
+		c := l.data[l.pos]
+		switch {
+		case c == '(':
+			return l.literalString(), nil
+		case c == '<':
+			if l.pos+1 < len(l.data) && l.data[l.pos+1] == '<' {
+				l.pos += 2
+				return pdfKeyword("<<"), nil
+			}
+			return l.hexString(), nil
+		case c == '>':
+			if l.pos+1 < len(l.data) && l.data[l.pos+1] == '>' {
+				l.pos += 2
+				return pdfKeyword(">>"), nil
+			}
+			l.pos++
+			continue
+		case c == '[' || c == ']' || c == '{' || c == '}':
+			l.pos++
+			return pdfKeyword(string(c)), nil
+		case c == '/':
+			l.pos++
+			return pdfName(l.regular(true)), nil
+		case c == ')':
+			l.pos++
+			continue
+		}
+
+		word := l.regular(false)
+		if word == "" {
+			l.pos++
+			continue
+		}
+
+		if n, err := strconv.ParseFloat(word, 64); err == nil && (word[0] == '-' || word[0] == '+' || word[0] == '.' || (word[0] >= '0' && word[0] <= '9')) {
+			return n, nil
+		}
+
+		return pdfKeyword(word), nil
+	}
+}
+
+func (l *pdfLexer) regular(name bool) string {
+	var b strings.Builder
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if isPDFWhitespace(c) || isPDFDelimiter(c) {
+			break
+		}
+		if name && c == '#' && l.pos+2 < len(l.data) {
+			if v, err := strconv.ParseUint(string(l.data[l.pos+1:l.pos+3]), 16, 8); err == nil {
+				b.WriteByte(byte(v))
+				l.pos += 3
+				continue
+			}
+		}
+		b.WriteByte(c)
+		l.pos++
+	}
+	return b.String()
+}
+
+func (l *pdfLexer) literalString() []byte {
+	l.pos++
+	var out []byte
+	depth := 1
+
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return out
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return out
+			}
+			esc := l.data[l.pos]
+			l.pos++
+			switch esc {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b':
+				out = append(out, '\b')
+			case 'f':
+				out = append(out, '\f')
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+			case '\n':
+			default:
+				if esc >= '0' && esc <= '7' {
+					value := int(esc - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						value = value*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					out = append(out, byte(value))
+				} else {
+					out = append(out, esc)
+				}
+			}
+			continue
+		}
+
+		out = append(out, c)
+	}
+
+	return out
+}
+
+func (l *pdfLexer) hexString() []byte {
+	l.pos++
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		c := l.data[l.pos]
+		if (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	if l.pos < len(l.data) {
+		l.pos++
+	}
+
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	out := make([]byte, len(digits)/2)
+	for i := range out {
+		v, _ := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		out[i] = byte(v)
+	}
+	return out
+}
+
+// maxPDFNesting limits how deep arrays and dictionaries may nest, so a
+// crafted file cannot exhaust the stack.
+const maxPDFNesting = 64
+
+var errPDFNesting = errors.New("objeto PDF aninhado demais")
+
+// parseValue reads one complete value, assembling arrays, dictionaries and
+// indirect references ("12 0 R").
+func (l *pdfLexer) parseValue() (interface{}, error) {
+	return l.value(0)
+}
+
+func (l *pdfLexer) value(depth int) (interface{}, error) {
+	token, err := l.next()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case pdfKeyword("["):
+		if depth >= maxPDFNesting {
+			return nil, errPDFNesting
+		}
+		var arr []interface{}
+		for {
+			l.skipSpace()
+			if l.pos < len(l.data) && l.data[l.pos] == ']' {
+				l.pos++
+				return arr, nil
+			}
+			value, err := l.value(depth + 1)
+			if errors.Is(err, errPDFNesting) {
+				return nil, err
+			}
+			if err != nil {
+				return arr, nil
+			}
+			arr = append(arr, value)
+		}
+	case pdfKeyword("<<"):
+		if depth >= maxPDFNesting {
+			return nil, errPDFNesting
+		}
+		dict := make(pdfDict)
+		for {
+			key, err := l.value(depth + 1)
+			if errors.Is(err, errPDFNesting) {
+				return nil, err
+			}
+			if err != nil || key == pdfKeyword(">>") {
+				return dict, nil
+			}
+			name, ok := key.(pdfName)
+			if !ok {
+				continue
+			}
+			value, err := l.value(depth + 1)
+			if errors.Is(err, errPDFNesting) {
+				return nil, err
+			}
+			if err != nil {
+				return dict, nil
+			}
+			dict[name] = value
+		}
+	case pdfKeyword("true"):
+		return true, nil
+	case pdfKeyword("false"):
+		return false, nil
+	case pdfKeyword("null"):
+		return nil, nil
+	}
+
+	if n, ok := token.(float64); ok && n == math.Trunc(n) && n >= 0 {
+		saved := l.pos
+		gen, err1 := l.next()
+		keyword, err2 := l.next()
+		if g, ok := gen.(float64); ok && err1 == nil && err2 == nil && keyword == pdfKeyword("R") {
+			return pdfRef{num: int(n), gen: int(g)}, nil
+		}
+		l.pos = saved
+	}
+
+	return token, nil
+}
+
+func (l *pdfLexer) readStream(dict pdfDict) ([]byte, bool) {
+	l.skipSpace()
+	if l.pos >= len(l.data) || !bytes.HasPrefix(l.data[l.pos:], []byte("stream")) {
+		return nil, false
+	}
+
+	start := l.pos + len("stream")
+	if start < len(l.data) && l.data[start] == '\r' {
+		start++
+	}
+	if start < len(l.data) && l.data[start] == '\n' {
+		start++
+	}
+
+	if length, ok := dict["Length"].(float64); ok && length >= 0 && length <= float64(len(l.data)) {
+		end := start + int(length)
+		if end >= start && end <= len(l.data) {
+			rest := bytes.TrimLeft(l.data[end:], "\r\n ")
+			if bytes.HasPrefix(rest, []byte("endstream")) {
+				l.pos = end
+				return l.data[start:end], true
+			}
+		}
+	}
+
+	end := bytes.Index(l.data[start:], []byte("endstream"))
+	if end == -1 {
+		return nil, false
+	}
+
+	raw := bytes.TrimRight(l.data[start:start+end], "\r\n")
+	l.pos = start + end
+	return raw, true
+}
+
+func (l *pdfLexer) skipInlineImage() {
+	for l.pos+1 < len(l.data) {
+		if l.data[l.pos] == 'I' && l.data[l.pos+1] == 'D' && (l.pos == 0 || isPDFWhitespace(l.data[l.pos-1])) {
+			l.pos += 2
+			break
+		}
+		l.pos++
+	}
+
+	for l.pos+2 < len(l.data) {
+		if isPDFWhitespace(l.data[l.pos]) && l.data[l.pos+1] == 'E' && l.data[l.pos+2] == 'I' &&
+			(l.pos+3 >= len(l.data) || isPDFWhitespace(l.data[l.pos+3])) {
+			l.pos += 3
+			return
+		}
+		l.pos++
+	}
+	l.pos = len(l.data)
+}
@@ -0,0 +1,225 @@
+package parser
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// buildPDF returns a one-page, uncompressed PDF whose page draws the given
+// lines top to bottom with a standard font.
+func buildPDF(lines ...string) []byte {
+	var content strings.Builder
+	content.WriteString("BT /F1 10 Tf\n")
+	for i, line := range lines {
+		fmt.Fprintf(&content, "1 0 0 1 50 %d Tm (%s) Tj\n", 800-20*i, line)
+	}
+	content.WriteString("ET")
+
+	return pdfFromObjects(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Contents 4 0 R /Resources << /Font << /F1 5 0 R >> >> >>",
+		pdfStreamObject("", []byte(content.String())),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+	)
+}
+
+// pdfFromObjects numbers the objects from 1 in the order given.
+func pdfFromObjects(objects ...string) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	for i, object := range objects {
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	b.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+	return b.Bytes()
+}
+
+func pdfStreamObject(entries string, data []byte) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", entries, len(data), data)
+}
+
+// withinDeadline fails the test when fn runs for longer than a real import
+// should ever take.
+func withinDeadline(t *testing.T, fn func()) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("did not finish within 10s")
+	}
+}
+
+func TestExtractPDFLines(t *testing.T) {
+	lines, err := extractPDFLines(buildPDF("Fatura Nubank", "10 JAN Padaria Central 12,50"))
+	if err != nil {
+		t.Fatalf("extractPDFLines: %v", err)
+	}
+
+	want := []string{"Fatura Nubank", "10 JAN Padaria Central 12,50"}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines %q, want %q", len(lines), lines, want)
+	}
+	for i := range want {
+		if strings.TrimSpace(lines[i]) != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestParsePDFMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"stray closing parens", append([]byte("%PDF-1.4\n1 0 obj "), bytes.Repeat([]byte(")"), maxPDFSize-32)...)},
+		{"stray closing brackets", append([]byte("%PDF-1.4\n1 0 obj "), bytes.Repeat([]byte(">"), maxPDFSize-32)...)},
+		{"deep arrays", append([]byte("%PDF-1.4\n1 0 obj "), bytes.Repeat([]byte("["), maxPDFSize-32)...)},
+		{"deep dictionaries", append([]byte("%PDF-1.4\n1 0 obj "), bytes.Repeat([]byte("<<"), maxPDFSize/2-32)...)},
+		{"unterminated hex string", []byte("%PDF0 0 obj <<00000000<")},
+		{"negative stream length", []byte("%PDF-1.4\n1 0 obj << /Length -10 >>\nstream\nabc\nendstream")},
+		{"huge stream length", []byte("%PDF-1.4\n1 0 obj << /Length 1e300 >>\nstream\nabc\nendstream")},
+		{"not a pdf", []byte("hello")},
+	}
+
+	s := NewService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.ParsePDF(bytes.NewReader(tt.data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestExtractPDFLinesSharedKids(t *testing.T) {
+	const levels = 40
+
+	// Every Pages node lists the next one twice, so a walk that does not
+	// remember visited nodes would reach the page 2^40 times.
+	objects := []string{"<< /Type /Catalog /Pages 2 0 R >>"}
+	for i := 0; i < levels; i++ {
+		objects = append(objects, fmt.Sprintf("<< /Type /Pages /Kids [%[1]d 0 R %[1]d 0 R] >>", i+3))
+	}
+	page := levels + 2
+	objects = append(objects,
+		fmt.Sprintf("<< /Type /Page /Contents %d 0 R /Resources << /Font << /F1 %d 0 R >> >> >>", page+1, page+2),
+		pdfStreamObject("", []byte("BT /F1 10 Tf 50 800 Td (Fatura Nubank) Tj ET")),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	)
+
+	withinDeadline(t, func() {
+		lines, err := extractPDFLines(pdfFromObjects(objects...))
+		if err != nil {
+			t.Errorf("extractPDFLines: %v", err)
+			return
+		}
+		if len(lines) != 1 || lines[0] != "Fatura Nubank" {
+			t.Errorf("lines = %q, want the single page once", lines)
+		}
+	})
+}
+
+func TestExtractPDFLinesFormFanOut(t *testing.T) {
+	const levels = 8
+
+	// Each form draws the next one 30 times: 30^8 invocations without a
+	// per-document budget.
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] >>",
+		"<< /Type /Page /Contents 4 0 R /Resources << /XObject << /X 6 0 R >> /Font << /F1 5 0 R >> >> >>",
+		pdfStreamObject("", []byte("/X Do")),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}
+	fanOut := []byte(strings.Repeat("/X Do ", 30))
+	for i := 0; i < levels; i++ {
+		content := fanOut
+		if i == levels-1 {
+			content = []byte("BT /F1 10 Tf 50 800 Td (A) Tj ET")
+		}
+		objects = append(objects, pdfStreamObject(fmt.Sprintf("/Type /XObject /Subtype /Form /Resources << /XObject << /X %d 0 R >> >>", i+7), content))
+	}
+
+	withinDeadline(t, func() {
+		lines, err := extractPDFLines(pdfFromObjects(objects...))
+		if err != nil {
+			t.Errorf("extractPDFLines: %v", err)
+			return
+		}
+		if len(lines) != 1 || strings.Trim(lines[0], "A") != "" {
+			t.Errorf("lines = %q, want one line of the form text", lines)
+		}
+	})
+}
+
+func TestExtractPDFLinesDecompressionBomb(t *testing.T) {
+	var compressed bytes.Buffer
+	w := zlib.NewWriter(&compressed)
+	w.Write(bytes.Repeat([]byte(" "), maxPDFStreamSize+1))
+	w.Close()
+
+	if _, err := inflate(compressed.Bytes(), maxPDFStreamSize); !errors.Is(err, errPDFStreamSize) {
+		t.Fatalf("inflate error = %v, want %v", err, errPDFStreamSize)
+	}
+
+	// Many pages sharing one stream just under the limit must still stop
+	// at the document budget.
+	compressed.Reset()
+	w = zlib.NewWriter(&compressed)
+	w.Write(bytes.Repeat([]byte(" "), maxPDFStreamSize))
+	w.Close()
+
+	var kids strings.Builder
+	for i := 0; i < 64; i++ {
+		fmt.Fprintf(&kids, "%d 0 R ", i+5)
+	}
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] >>", kids.String()),
+		pdfStreamObject("/Filter /FlateDecode", compressed.Bytes()),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}
+	for i := 0; i < 64; i++ {
+		objects = append(objects, "<< /Type /Page /Contents 3 0 R /Resources << /Font << /F1 4 0 R >> >> >>")
+	}
+	data := pdfFromObjects(objects...)
+
+	withinDeadline(t, func() {
+		doc, err := readPDF(data)
+		if err != nil {
+			t.Errorf("readPDF: %v", err)
+			return
+		}
+		for _, page := range doc.pages() {
+			doc.pageContent(page.dict)
+		}
+		if doc.decoded > maxPDFDecodedSize {
+			t.Errorf("decoded %d bytes, want at most %d", doc.decoded, maxPDFDecodedSize)
+		}
+	})
+}
+
+func FuzzParsePDF(f *testing.F) {
+	f.Add(buildPDF("Fatura Nubank", "10 JAN Padaria Central 12,50"))
+	f.Add([]byte("%PDF0 0 obj <<00000000<"))
+	f.Add([]byte("%PDF-1.4\n1 0 obj << /Length -1 >>\nstream\nendstream"))
+	f.Add([]byte("%PDF-1.4\n1 0 obj [[[[(a)]]]] endobj"))
+
+	s := NewService()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s.ParsePDF(bytes.NewReader(data))
+	})
+}
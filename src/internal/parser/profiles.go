@@ -0,0 +1,157 @@
+package parser
+
+var (
+	dateAliases        = []string{"date", "data"}
+	descriptionAliases = []string{"title", "description", "titulo", "título", "descricao", "descrição"}
+	categoryAliases    = []string{"category", "categoria"}
+	amountAliases      = []string{"amount", "value", "valor"}
+
+	currencyAliases       = []string{"currency", "moeda"}
+	originalAmountAliases = []string{"original amount", "valor original", "valor na moeda"}
+	exchangeRateAliases   = []string{"exchange rate", "cotação", "cotacao", "câmbio", "cambio"}
+)
+
+// GenericProfile is the fallback layout: any file with a date and an amount
+// column, using the column names the importer always accepted.
+const GenericProfile = "generic"
+
+// BuiltinImporters returns the bank layouts known out of the box, most
+// specific first, then the exports of other finance apps, with the generic
+// layout last.
+func BuiltinImporters() []Importer {
+	importers := []Importer{
+		&columnLayout{
+			profile: ImportProfile{
+				Name:           "nubank_cartao",
+				Bank:           "Nubank",
+				Description:    "Fatura do cartão Nubank (CSV)",
+				StatementKind:  StatementCreditCard,
+				SignConvention: SignDebitPositive,
+			},
+			signature:   [][]string{{"date"}, {"title"}, {"amount"}},
+			date:        []string{"date"},
+			description: []string{"title"},
+			category:    []string{"category"},
+			amount:      []string{"amount"},
+			dateFormats: []string{"2006-01-02"},
+		},
+		&columnLayout{
+			profile: ImportProfile{
+				Name:           "nubank_conta",
+				Bank:           "Nubank",
+				Description:    "Extrato da conta Nubank (CSV)",
+				StatementKind:  StatementChecking,
+				SignConvention: SignDebitNegative,
+			},
+			signature:   [][]string{{"Data"}, {"Valor"}, {"Identificador"}, {"Descrição"}},
+			date:        []string{"Data"},
+			description: []string{"Descrição"},
+			amount:      []string{"Valor"},
+			externalID:  []string{"Identificador"},
+			dateFormats: []string{"02/01/2006"},
+		},
+		&columnLayout{
+			profile: ImportProfile{
+				Name:           "inter_cartao",
+				Bank:           "Inter",
+				Description:    "Fatura do cartão Inter (CSV)",
+				StatementKind:  StatementCreditCard,
+				SignConvention: SignDebitPositive,
+			},
+			signature:   [][]string{{"Data"}, {"Lançamento"}, {"Categoria"}, {"Tipo"}, {"Valor"}},
+			date:        []string{"Data"},
+			description: []string{"Lançamento"},
+			category:    []string{"Categoria"},
+			amount:      []string{"Valor"},
+			installment: []string{"Tipo"},
+			dateFormats: []string{"02/01/2006"},
+		},
+		&columnLayout{
+			profile: ImportProfile{
+				Name:           "inter_conta",
+				Bank:           "Inter",
+				Description:    "Extrato da conta Inter (CSV)",
+				StatementKind:  StatementChecking,
+				SignConvention: SignDebitNegative,
+			},
+			signature:       [][]string{{"Data Lançamento"}, {"Histórico"}, {"Valor"}},
+			date:            []string{"Data Lançamento"},
+			description:     []string{"Histórico"},
+			details:         []string{"Descrição"},
+			amount:          []string{"Valor"},
+			dateFormats:     []string{"02/01/2006"},
+			skipBalanceRows: true,
+		},
+		&columnLayout{
+			profile: ImportProfile{
+				Name:           "itau_conta",
+				Bank:           "Itaú",
+				Description:    "Extrato da conta Itaú (CSV exportado do Excel)",
+				StatementKind:  StatementChecking,
+				SignConvention: SignDebitNegative,
+			},
+			signature:       [][]string{{"data"}, {"lançamento"}, {"valor (R$)"}},
+			date:            []string{"data"},
+			description:     []string{"lançamento"},
+			details:         []string{"ag./origem"},
+			amount:          []string{"valor (R$)"},
+			dateFormats:     []string{"02/01/2006"},
+			skipBalanceRows: true,
+		},
+		&columnLayout{
+			profile: ImportProfile{
+				Name:           "c6_cartao",
+				Bank:           "C6 Bank",
+				Description:    "Fatura do cartão C6 (CSV)",
+				StatementKind:  StatementCreditCard,
+				SignConvention: SignDebitPositive,
+			},
+			signature:   [][]string{{"Data de compra"}, {"Descrição"}, {"Valor (em R$)"}},
+			date:        []string{"Data de compra"},
+			description: []string{"Descrição"},
+			category:    []string{"Categoria"},
+			amount:      []string{"Valor (em R$)"},
+			installment: []string{"Parcela"},
+			dateFormats: []string{"02/01/2006"},
+
+			originalAmount:  []string{"Valor (em US$)"},
+			exchangeRate:    []string{"Cotação (em R$)"},
+			defaultCurrency: "USD",
+		},
+		&columnLayout{
+			profile: ImportProfile{
+				Name:           "bradesco_conta",
+				Bank:           "Bradesco",
+				Description:    "Extrato da conta Bradesco (CSV)",
+				StatementKind:  StatementChecking,
+				SignConvention: SignDebitNegative,
+			},
+			signature:       [][]string{{"Data"}, {"Histórico", "Lançamento"}, {"Crédito (R$)", "Crédito"}, {"Débito (R$)", "Débito"}},
+			date:            []string{"Data"},
+			description:     []string{"Histórico", "Lançamento"},
+			credit:          []string{"Crédito (R$)", "Crédito"},
+			debit:           []string{"Débito (R$)", "Débito"},
+			dateFormats:     []string{"02/01/2006", "02/01/06"},
+			skipBalanceRows: true,
+		},
+	}
+	importers = append(importers, migrationImporters()...)
+
+	return append(importers,
+		&columnLayout{
+			profile: ImportProfile{
+				Name:        GenericProfile,
+				Description: "CSV genérico com colunas de data e valor",
+			},
+			signature:   [][]string{dateAliases, amountAliases},
+			date:        dateAliases,
+			description: descriptionAliases,
+			category:    categoryAliases,
+			amount:      amountAliases,
+
+			currency:       currencyAliases,
+			originalAmount: originalAmountAliases,
+			exchangeRate:   exchangeRateAliases,
+		},
+	)
+}
@@ -0,0 +1,318 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// qifTransactionTypes are the !Type sections that hold account entries.
+// Investment, category, class and memorized lists are skipped.
+var qifTransactionTypes = map[string]StatementKind{
+	"bank":  StatementChecking,
+	"cash":  StatementChecking,
+	"ccard": StatementCreditCard,
+	"oth a": StatementChecking,
+	"oth l": StatementChecking,
+}
+
+var qifDateParts = regexp.MustCompile(`^(\d{1,4})[/.\-](\d{1,2})[/.\-](\d{2,4})$`)
+
+type qifSplit struct {
+	category string
+	memo     string
+	amount   string
+}
+
+type qifRecord struct {
+	line     int
+	raw      []string
+	account  string
+	date     string
+	amount   string
+	payee    string
+	memo     string
+	category string
+	splits   []qifSplit
+}
+
+// ParseQIF reads a Quicken Interchange Format file, as exported by Quicken,
+// GnuCash and most Brazilian finance apps. The category ("Categoria:Sub"),
+// the class after the slash (read as a tag) and the account of each
+// "!Account" block are kept. Transfers between accounts ("[Conta]") are
+// left out, since both sides are in the file. A transaction with splits
+// becomes one transaction per split.
+func (s *service) ParseQIF(file io.Reader) (*ParseResult, error) {
+	text, err := newTextReader(file, EncodingAuto)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(text)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var records []*qifRecord
+	var current *qifRecord
+	kinds := make(map[StatementKind]bool)
+
+	section, account, pendingAccount := "", "", ""
+	inAccount, found := false, false
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "!") {
+			header := strings.ToLower(strings.TrimSpace(text))
+			switch {
+			case strings.HasPrefix(header, "!account"):
+				inAccount, pendingAccount = true, ""
+			case strings.HasPrefix(header, "!type:"):
+				found, inAccount = true, false
+				section = strings.TrimSpace(strings.TrimPrefix(header, "!type:"))
+				if kind, ok := qifTransactionTypes[section]; ok {
+					kinds[kind] = true
+				}
+			}
+			current = nil
+			continue
+		}
+
+		code, value := text[0], strings.TrimSpace(text[1:])
+
+		if inAccount {
+			switch code {
+			case 'N':
+				pendingAccount = value
+			case '^':
+				account, inAccount = pendingAccount, false
+			}
+			continue
+		}
+
+		if _, ok := qifTransactionTypes[section]; !ok {
+			continue
+		}
+
+		if current == nil {
+			current = &qifRecord{line: line, account: account}
+		}
+		current.raw = append(current.raw, text)
+
+		switch code {
+		case 'D':
+			current.date = value
+		case 'T':
+			current.amount = value
+		case 'U':
+			if current.amount == "" {
+				current.amount = value
+			}
+		case 'P':
+			current.payee = value
+		case 'M':
+			current.memo = value
+		case 'L':
+			current.category = value
+		case 'S':
+			current.splits = append(current.splits, qifSplit{category: value})
+		case 'E':
+			if n := len(current.splits); n > 0 {
+				current.splits[n-1].memo = value
+			}
+		case '$':
+			if n := len(current.splits); n > 0 {
+				current.splits[n-1].amount = value
+			}
+		case '^':
+			records = append(records, current)
+			current = nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo QIF: %w", err)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("arquivo QIF inválido: cabeçalho !Type não encontrado")
+	}
+
+	if current != nil && current.date != "" {
+		// The last record is not always closed with "^".
+		records = append(records, current)
+	}
+
+	kind := StatementChecking
+	if len(kinds) == 1 && kinds[StatementCreditCard] {
+		kind = StatementCreditCard
+	}
+
+	result := &ParseResult{
+		Profile: &ImportProfile{
+			Name:           "qif",
+			Description:    "Arquivo QIF (Quicken Interchange Format)",
+			StatementKind:  kind,
+			SignConvention: SignDebitNegative,
+		},
+	}
+
+	var dates, amounts []string
+	for _, record := range records {
+		dates = append(dates, record.date)
+		amounts = append(amounts, record.amount)
+	}
+	dayFirst := qifDayFirst(dates)
+	result.Locale = detectLocale(amounts, 0)
+
+	for _, record := range records {
+		transactions, err := record.transactions(dayFirst, result.Locale)
+		if err != nil {
+			result.Rejected = append(result.Rejected, RejectedRow{
+				Line:   record.line,
+				Raw:    strings.Join(record.raw, "\n"),
+				Reason: err.Error(),
+			})
+			continue
+		}
+		result.Transactions = append(result.Transactions, transactions...)
+	}
+
+	return result, nil
+}
+
+func (r *qifRecord) transactions(dayFirst bool, locale Locale) ([]Transaction, error) {
+	date, err := parseQIFDate(r.date, dayFirst)
+	if err != nil {
+		return nil, err
+	}
+
+	type entry struct {
+		category, memo, amount string
+	}
+	entries := []entry{{r.category, r.memo, r.amount}}
+
+	split := len(r.splits) > 0
+	for _, s := range r.splits {
+		split = split && s.amount != ""
+	}
+	if split {
+		entries = entries[:0]
+		for _, s := range r.splits {
+			entries = append(entries, entry{s.category, firstNonEmpty(s.memo, r.memo), s.amount})
+		}
+	}
+
+	var transactions []Transaction
+	for _, e := range entries {
+		category, tags, transfer := parseQIFCategory(e.category)
+		if transfer {
+			continue
+		}
+
+		amount, err := parseAmount(e.amount, locale)
+		if err != nil {
+			return nil, err
+		}
+
+		description := r.payee
+		if e.memo != "" && e.memo != description {
+			if description != "" {
+				description += " - " + e.memo
+			} else {
+				description = e.memo
+			}
+		}
+		if description == "" {
+			description = category
+		}
+
+		transactions = append(transactions, Transaction{
+			Date:        date,
+			Description: description,
+			Category:    category,
+			Amount:      amount,
+			Account:     r.account,
+			Tags:        tags,
+		})
+	}
+
+	return transactions, nil
+}
+
+// parseQIFCategory splits an "L" field such as "Alimentação:Mercado/Viagem"
+// into the category and the class, kept as a tag. "[Conta]" is a transfer.
+func parseQIFCategory(value string) (string, []string, bool) {
+	category, class, _ := strings.Cut(value, "/")
+	category = strings.TrimSpace(category)
+	transfer := strings.HasPrefix(category, "[") && strings.HasSuffix(category, "]")
+	return category, splitTags(class), transfer
+}
+
+// qifDayFirst tells the order of day and month from the dates of the file:
+// "25/12/2023" can only be day first and "12/25/2023" only month first.
+// When no date settles it, the Brazilian order is assumed.
+func qifDayFirst(dates []string) bool {
+	for _, value := range dates {
+		match := qifDateParts.FindStringSubmatch(normalizeQIFDate(value))
+		if match == nil || len(match[1]) == 4 {
+			continue
+		}
+		first, _ := strconv.Atoi(match[1])
+		second, _ := strconv.Atoi(match[2])
+		switch {
+		case first > 12:
+			return true
+		case second > 12:
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeQIFDate turns Quicken's "1/ 5'24" into "1/5/24".
+func normalizeQIFDate(value string) string {
+	value = strings.ReplaceAll(value, "'", "/")
+	return strings.ReplaceAll(value, " ", "")
+}
+
+func parseQIFDate(value string, dayFirst bool) (time.Time, error) {
+	match := qifDateParts.FindStringSubmatch(normalizeQIFDate(value))
+	if match == nil {
+		return time.Time{}, fmt.Errorf("formato de data inválido: %s", value)
+	}
+
+	a, _ := strconv.Atoi(match[1])
+	b, _ := strconv.Atoi(match[2])
+	year, _ := strconv.Atoi(match[3])
+
+	day, month := a, b
+	switch {
+	case len(match[1]) == 4:
+		year, month, day = a, b, year
+	case !dayFirst:
+		day, month = b, a
+	}
+
+	if len(match[3]) == 2 && len(match[1]) != 4 {
+		year += 2000
+		if year > time.Now().Year()+1 {
+			year -= 100
+		}
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day || int(date.Month()) != month {
+		return time.Time{}, fmt.Errorf("formato de data inválido: %s", value)
+	}
+
+	return date, nil
+}
@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const qifFile = `!Account
+NNubank
+TCCard
+^
+!Type:CCard
+D25/12/2025
+T-1.234,56
+PMercado Pão de Açúcar
+LAlimentação:Mercado/Viagem
+^
+D26/12/2025
+T-100,00
+PPosto
+MAbastecimento
+SCarro:Combustível
+$-80,00
+SAlimentação
+ELanche
+$-20,00
+^
+D27/12/2025
+T500,00
+L[Conta Corrente]
+^
+D31/02/2025
+T-10,00
+PData inválida
+^
+`
+
+func TestParseQIF(t *testing.T) {
+	result, err := NewService().ParseQIF(strings.NewReader(qifFile))
+	if err != nil {
+		t.Fatalf("ParseQIF: %v", err)
+	}
+
+	date := func(day int) time.Time { return time.Date(2025, 12, day, 0, 0, 0, 0, time.UTC) }
+	want := []Transaction{
+		{Date: date(25), Description: "Mercado Pão de Açúcar", Category: "Alimentação:Mercado", Amount: -1234.56, Account: "Nubank", Tags: []string{"Viagem"}},
+		{Date: date(26), Description: "Posto - Abastecimento", Category: "Carro:Combustível", Amount: -80, Account: "Nubank"},
+		{Date: date(26), Description: "Posto - Lanche", Category: "Alimentação", Amount: -20, Account: "Nubank"},
+	}
+
+	if len(result.Transactions) != len(want) {
+		t.Fatalf("got %d transactions %+v, want %d", len(result.Transactions), result.Transactions, len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(result.Transactions[i], want[i]) {
+			t.Errorf("transaction %d = %+v, want %+v", i, result.Transactions[i], want[i])
+		}
+	}
+
+	if len(result.Rejected) != 1 || result.Rejected[0].Line != 25 {
+		t.Errorf("rejected = %+v, want the invalid date on line 25", result.Rejected)
+	}
+	if result.Profile.StatementKind != StatementCreditCard {
+		t.Errorf("statement kind = %q, want %q", result.Profile.StatementKind, StatementCreditCard)
+	}
+	if result.Locale != LocalePTBR {
+		t.Errorf("locale = %q, want %q", result.Locale, LocalePTBR)
+	}
+}
+
+func TestParseQIFWithoutType(t *testing.T) {
+	if _, err := NewService().ParseQIF(strings.NewReader("D01/01/2025\nT-1.00\n^\n")); err == nil {
+		t.Error("expected an error for a file without !Type")
+	}
+}
+
+func TestParseQIFDate(t *testing.T) {
+	tests := []struct {
+		value    string
+		dayFirst bool
+		want     time.Time
+	}{
+		{"25/12/2023", true, time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)},
+		{"12/25/2023", false, time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)},
+		{"1/ 5'24", false, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"2023-12-25", true, time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)},
+		{"05.01.99", true, time.Date(1999, 1, 5, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := parseQIFDate(tt.value, tt.dayFirst)
+		if err != nil {
+			t.Errorf("parseQIFDate(%q): %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseQIFDate(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"31/02/2023", "ontem", ""} {
+		if _, err := parseQIFDate(value, true); err == nil {
+			t.Errorf("parseQIFDate(%q) should fail", value)
+		}
+	}
+}
+
+func TestQIFDayFirst(t *testing.T) {
+	tests := []struct {
+		dates []string
+		want  bool
+	}{
+		{[]string{"01/02/2024", "25/02/2024"}, true},
+		{[]string{"01/02/2024", "02/25/2024"}, false},
+		{[]string{"01/02/2024"}, true},
+		{[]string{"2024-02-25", "02/25/2024"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := qifDayFirst(tt.dates); got != tt.want {
+			t.Errorf("qifDayFirst(%q) = %v, want %v", tt.dates, got, tt.want)
+		}
+	}
+}
+
+func TestParseQIFCategory(t *testing.T) {
+	tests := []struct {
+		value    string
+		category string
+		tags     []string
+		transfer bool
+	}{
+		{"Alimentação:Mercado/Viagem", "Alimentação:Mercado", []string{"Viagem"}, false},
+		{"Saúde", "Saúde", nil, false},
+		{"[Poupança]", "[Poupança]", nil, true},
+	}
+
+	for _, tt := range tests {
+		category, tags, transfer := parseQIFCategory(tt.value)
+		if category != tt.category || !reflect.DeepEqual(tags, tt.tags) || transfer != tt.transfer {
+			t.Errorf("parseQIFCategory(%q) = %q, %q, %v", tt.value, category, tags, transfer)
+		}
+	}
+}
@@ -5,6 +5,37 @@ import "github.com/gin-gonic/gin"
 func RegisterRoutes(group *gin.RouterGroup, handler *Handler) {
 	parser := group.Group("/parser")
 	{
+		parser.GET("/profiles", handler.ListProfiles)
+
+		parser.GET("/mappings", handler.ListMappings)
+		parser.POST("/mappings", handler.CreateMapping)
+		parser.GET("/mappings/:id", handler.GetMapping)
+		parser.PUT("/mappings/:id", handler.UpdateMapping)
+		parser.DELETE("/mappings/:id", handler.DeleteMapping)
+
 		parser.POST("/upload/csv", handler.UploadCSV)
+		parser.POST("/upload/ofx", handler.UploadOFX)
+		parser.POST("/upload/camt053", handler.UploadCAMT053)
+		parser.POST("/upload/pdf", handler.UploadPDF)
+		parser.POST("/upload/xlsx", handler.UploadXLSX)
+		parser.POST("/upload/email", handler.UploadEmail)
+
+		parser.GET("/migrate", handler.ListMigrationApps)
+		parser.POST("/migrate/:app", handler.MigrateFrom)
+
+		parser.POST("/staged/csv", handler.StageCSV)
+		parser.POST("/staged/ofx", handler.StageOFX)
+		parser.POST("/staged/camt053", handler.StageCAMT053)
+		parser.POST("/staged/pdf", handler.StagePDF)
+		parser.POST("/staged/xlsx", handler.StageXLSX)
+		parser.GET("/staged/:id", handler.GetStagedImport)
+		parser.PATCH("/staged/:id/rows/:index", handler.UpdateStagedRow)
+		parser.POST("/staged/:id/commit", handler.CommitStagedImport)
+		parser.DELETE("/staged/:id", handler.DiscardStagedImport)
+
+		parser.POST("/jobs/csv", handler.StartCSVJob)
+		parser.GET("/jobs", handler.ListImportJobs)
+		parser.GET("/jobs/:id", handler.GetImportJob)
+		parser.POST("/jobs/:id/cancel", handler.CancelImportJob)
 	}
 }
@@ -1,99 +1,119 @@
 package parser
 
 import (
-	"encoding/csv"
+	"bytes"
 	"fmt"
 	"io"
-	"strconv"
 	"strings"
 	"time"
 )
 
 type Service interface {
-	ParseCSV(file io.Reader) ([]Transaction, error)
+	ParseCSV(file io.Reader, opts CSVOptions) (*ParseResult, error)
+	StreamCSV(file io.Reader, opts CSVOptions, chunkSize int, fn func(chunk *ParseResult) error) error
+	ParseOFX(file io.Reader) (*ParseResult, error)
+	ParseCAMT053(file io.Reader) (*ParseResult, error)
+	ParseQIF(file io.Reader) (*ParseResult, error)
+	ParseEmail(file io.Reader) (*ParseResult, error)
+	ParsePDF(file io.Reader) (*ParseResult, error)
+	ParseXLSX(file io.Reader, opts XLSXOptions) (*ParseResult, error)
+	Profiles() []ImportProfile
 }
 
-type service struct{}
+type service struct {
+	importers *ImporterRegistry
+}
 
 func NewService() Service {
-	return &service{}
+	return &service{importers: NewImporterRegistry(BuiltinImporters()...)}
 }
 
-func (s *service) ParseCSV(file io.Reader) ([]Transaction, error) {
-	reader := csv.NewReader(file)
-	reader.Comma = ','
-	reader.TrimLeadingSpace = true
-
-	header, err := reader.Read()
+func (s *service) ParseCSV(file io.Reader, opts CSVOptions) (*ParseResult, error) {
+	reader, err := newCSVRowReader(file, opts)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao ler header: %w", err)
-	}
-
-	dateIdx := findColumn(header, "date", "data")
-	categoryIdx := findColumn(header, "category", "categoria")
-	descIdx := findColumn(header, "title", "description", "titulo", "título", "descricao", "descrição")
-	amountIdx := findColumn(header, "amount", "value", "valor")
-
-	if dateIdx == -1 || amountIdx == -1 {
-		return nil, fmt.Errorf("colunas obrigatórias não encontradas (date, amount)")
+		return nil, err
 	}
 
-	var transactions []Transaction
+	var rows []TableRow
+	var malformed []RejectedRow
 
 	for {
-		record, err := reader.Read()
+		row, rejected, err := reader.next()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			continue
+			return nil, err
 		}
-
-		if len(record) <= dateIdx || len(record) <= amountIdx {
+		if rejected != nil {
+			malformed = append(malformed, *rejected)
 			continue
 		}
+		rows = append(rows, *row)
+	}
 
-		date, err := parseDate(record[dateIdx])
-		if err != nil {
-			continue
-		}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("erro ao ler header: %w", io.EOF)
+	}
 
-		amount, err := parseAmount(record[amountIdx])
-		if err != nil {
-			continue
-		}
+	return s.parseTable(rows, malformed, reader.delimiter, 0, opts)
+}
 
-		category := ""
-		if categoryIdx >= 0 && len(record) > categoryIdx {
-			category = record[categoryIdx]
+// parseTable picks the header row and the importer (a saved mapping, a
+// forced profile or the best detected one) and parses the rows below the
+// header. headerRow, when set, is the line number of the header.
+func (s *service) parseTable(rows []TableRow, malformed []RejectedRow, delimiter rune, headerRow int, opts CSVOptions) (*ParseResult, error) {
+	searchRows := headerSearchRows
+	if headerRow > 0 {
+		for len(rows) > 0 && rows[0].Line < headerRow {
+			rows = rows[1:]
 		}
-
-		description := ""
-		if descIdx >= 0 && len(record) > descIdx {
-			description = record[descIdx]
+		if len(rows) == 0 || rows[0].Line != headerRow {
+			return nil, fmt.Errorf("linha de cabeçalho %d está vazia ou não existe", headerRow)
 		}
+		searchRows = 1
+	}
 
-		transactions = append(transactions, Transaction{
-			Date:        date,
-			Category:    category,
-			Description: description,
-			Amount:      amount,
-		})
+	importer, headerIdx, err := s.selectImporter(rows, searchRows, headerRow, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &Table{
+		Header:    rows[headerIdx].Record,
+		Rows:      rows[headerIdx+1:],
+		Delimiter: delimiter,
 	}
 
-	return transactions, nil
+	result := importer.Parse(table, opts)
+	for _, rejected := range malformed {
+		if rejected.Line == 0 || rejected.Line > rows[headerIdx].Line {
+			result.Rejected = append(result.Rejected, rejected)
+		}
+	}
+	sortRejected(result.Rejected)
+
+	profile := importer.Profile()
+	resolveSign(&profile, result.Transactions)
+	result.Profile = &profile
+
+	return result, nil
 }
 
-func findColumn(header []string, names ...string) int {
-	for i, col := range header {
-		colLower := strings.ToLower(strings.TrimSpace(col))
-		for _, name := range names {
-			if colLower == strings.ToLower(name) {
-				return i
-			}
+func (s *service) selectImporter(rows []TableRow, searchRows, headerRow int, opts CSVOptions) (Importer, int, error) {
+	if opts.Mapping != nil {
+		mapping := *opts.Mapping
+		if headerRow > 0 {
+			mapping.SkipRows = headerRow - 1
 		}
+		return mapping.selectHeader(rows)
 	}
-	return -1
+
+	return s.importers.Select(rows, opts.Profile, searchRows)
+}
+
+func (s *service) Profiles() []ImportProfile {
+	return s.importers.Profiles()
 }
 
 func parseDate(dateStr string) (time.Time, error) {
@@ -115,11 +135,57 @@ func parseDate(dateStr string) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("formato de data inválido: %s", dateStr)
 }
 
-func parseAmount(amountStr string) (float64, error) {
-	amountStr = strings.TrimSpace(amountStr)
-	amountStr = strings.ReplaceAll(amountStr, "R$", "")
-	amountStr = strings.ReplaceAll(amountStr, " ", "")
-	amountStr = strings.ReplaceAll(amountStr, ",", ".")
+// lineRecorder keeps the raw text of the lines the csv.Reader has consumed,
+// so rejected rows can be reported exactly as they appear in the file.
+type lineRecorder struct {
+	r       io.Reader
+	lines   map[int]string
+	next    int
+	partial []byte
+}
+
+func newLineRecorder(r io.Reader) *lineRecorder {
+	return &lineRecorder{r: r, lines: make(map[int]string), next: 1}
+}
+
+func (l *lineRecorder) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+
+	data := p[:n]
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, '\n')
+		if i == -1 {
+			l.partial = append(l.partial, data...)
+			break
+		}
+		l.partial = append(l.partial, data[:i]...)
+		l.lines[l.next] = strings.TrimRight(string(l.partial), "\r")
+		l.partial = l.partial[:0]
+		l.next++
+		data = data[i+1:]
+	}
+
+	if err == io.EOF && len(l.partial) > 0 {
+		l.lines[l.next] = strings.TrimRight(string(l.partial), "\r")
+		l.partial = l.partial[:0]
+		l.next++
+	}
+
+	return n, err
+}
+
+func (l *lineRecorder) text(from, to int) string {
+	parts := make([]string, 0, to-from+1)
+	for line := from; line <= to; line++ {
+		parts = append(parts, l.lines[line])
+	}
+	return strings.Join(parts, "\n")
+}
 
-	return strconv.ParseFloat(amountStr, 64)
+func (l *lineRecorder) forget(upTo int) {
+	for line := range l.lines {
+		if line <= upTo {
+			delete(l.lines, line)
+		}
+	}
 }
@@ -0,0 +1,138 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+func ParseStatementKind(value string) (StatementKind, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "auto":
+		return "", nil
+	case "credit_card", "cartao", "cartão":
+		return StatementCreditCard, nil
+	case "checking", "conta", "conta_corrente":
+		return StatementChecking, nil
+	case "savings", "poupanca", "poupança":
+		return StatementSavings, nil
+	}
+	return "", fmt.Errorf("tipo de extrato inválido: %s (use credit_card, checking ou savings)", value)
+}
+
+func ParseSignConvention(value string) (SignConvention, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "auto":
+		return "", nil
+	case string(SignDebitPositive):
+		return SignDebitPositive, nil
+	case string(SignDebitNegative):
+		return SignDebitNegative, nil
+	}
+	return "", fmt.Errorf("convenção de sinal inválida: %s (use debit_positive ou debit_negative)", value)
+}
+
+// SignConvention is the convention statements of this kind use: card
+// statements list purchases as positive, account statements list money
+// leaving the account as negative.
+func (k StatementKind) SignConvention() SignConvention {
+	switch k {
+	case StatementCreditCard:
+		return SignDebitPositive
+	case StatementChecking, StatementSavings:
+		return SignDebitNegative
+	}
+	return ""
+}
+
+func (c SignConvention) statementKind() StatementKind {
+	switch c {
+	case SignDebitPositive:
+		return StatementCreditCard
+	case SignDebitNegative:
+		return StatementChecking
+	}
+	return ""
+}
+
+// IsDebit reports whether an amount written in this convention is money
+// spent, which is saved as an expense rather than an income.
+func (c SignConvention) IsDebit(amount float64) bool {
+	if c == SignDebitPositive {
+		return amount > 0
+	}
+	return amount <= 0
+}
+
+// Spending returns the amount with purchases positive, the convention used
+// by the analysis package.
+func (c SignConvention) Spending(amount float64) float64 {
+	if c == SignDebitPositive {
+		return amount
+	}
+	return -amount
+}
+
+// detectSignConvention guesses the convention of a statement that does not
+// declare one: card statements are mostly purchases (positive), account
+// statements mostly debits (negative).
+func detectSignConvention(transactions []Transaction) SignConvention {
+	positive, negative := 0, 0
+	for _, t := range transactions {
+		switch {
+		case t.Amount > 0:
+			positive++
+		case t.Amount < 0:
+			negative++
+		}
+	}
+
+	if positive > negative {
+		return SignDebitPositive
+	}
+	return SignDebitNegative
+}
+
+// resolveSign fills in the statement kind and sign convention the profile
+// does not declare, deriving one from the other or guessing from the amounts.
+func resolveSign(profile *ImportProfile, transactions []Transaction) {
+	if profile.SignConvention == "" {
+		profile.SignConvention = profile.StatementKind.SignConvention()
+	}
+
+	if profile.SignConvention == "" {
+		profile.SignConvention = detectSignConvention(transactions)
+		profile.SignDetected = true
+	}
+
+	if profile.StatementKind == "" {
+		profile.StatementKind = profile.SignConvention.statementKind()
+	}
+}
+
+// importProfile applies the statement kind and sign convention sent with the
+// upload, which take precedence over the ones of the detected layout, and
+// resolves whatever is still missing.
+func (o ImportOptions) importProfile(detected *ImportProfile, transactions []Transaction) *ImportProfile {
+	profile := &ImportProfile{}
+	if detected != nil {
+		*profile = *detected
+	}
+
+	if o.StatementKind != "" {
+		profile.StatementKind = o.StatementKind
+		profile.SignConvention = o.StatementKind.SignConvention()
+		profile.SignDetected = false
+	}
+
+	if o.SignConvention != "" {
+		profile.SignConvention = o.SignConvention
+		profile.SignDetected = false
+		if o.StatementKind == "" {
+			profile.StatementKind = o.SignConvention.statementKind()
+		}
+	}
+
+	resolveSign(profile, transactions)
+
+	return profile
+}
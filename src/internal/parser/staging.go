@@ -0,0 +1,241 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"gastei-quanto/src/internal/expense"
+
+	"github.com/google/uuid"
+)
+
+const StagedImportTTL = 30 * time.Minute
+
+func (s *integrationService) StageCSV(userID string, file io.Reader, csvOpts CSVOptions, opts ImportOptions) (*StagedImport, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID não pode ser vazio")
+	}
+
+	if file == nil {
+		return nil, fmt.Errorf("arquivo não pode ser nulo")
+	}
+
+	parsed, err := s.parserService.ParseCSV(file, csvOpts)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao processar CSV: %w", err)
+	}
+
+	return s.stage(userID, "CSV", parsed, opts)
+}
+
+func (s *integrationService) StageOFX(userID string, file io.Reader, opts ImportOptions) (*StagedImport, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID não pode ser vazio")
+	}
+
+	if file == nil {
+		return nil, fmt.Errorf("arquivo não pode ser nulo")
+	}
+
+	parsed, err := s.parserService.ParseOFX(file)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao processar OFX: %w", err)
+	}
+
+	return s.stage(userID, "OFX", parsed, opts)
+}
+
+func (s *integrationService) StageCAMT053(userID string, file io.Reader, opts ImportOptions) (*StagedImport, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID não pode ser vazio")
+	}
+
+	if file == nil {
+		return nil, fmt.Errorf("arquivo não pode ser nulo")
+	}
+
+	parsed, err := s.parserService.ParseCAMT053(file)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao processar CAMT.053: %w", err)
+	}
+
+	return s.stage(userID, "CAMT053", parsed, opts)
+}
+
+func (s *integrationService) StagePDF(userID string, file io.Reader, opts ImportOptions) (*StagedImport, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID não pode ser vazio")
+	}
+
+	if file == nil {
+		return nil, fmt.Errorf("arquivo não pode ser nulo")
+	}
+
+	parsed, err := s.parserService.ParsePDF(file)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao processar PDF: %w", err)
+	}
+
+	return s.stage(userID, "PDF", parsed, opts)
+}
+
+func (s *integrationService) StageXLSX(userID string, file io.Reader, xlsxOpts XLSXOptions, opts ImportOptions) (*StagedImport, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID não pode ser vazio")
+	}
+
+	if file == nil {
+		return nil, fmt.Errorf("arquivo não pode ser nulo")
+	}
+
+	parsed, err := s.parserService.ParseXLSX(file, xlsxOpts)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao processar XLSX: %w", err)
+	}
+
+	return s.stage(userID, "XLSX", parsed, opts)
+}
+
+func (s *integrationService) stage(userID, format string, parsed *ParseResult, opts ImportOptions) (*StagedImport, error) {
+	if len(parsed.Rejected) > 0 && opts.Strict {
+		return nil, &RejectedRowsError{
+			Message: fmt.Sprintf("%d linha(s) inválida(s) no arquivo %s; a importação não foi criada (strict=true)", len(parsed.Rejected), format),
+			Rows:    parsed.Rejected,
+		}
+	}
+
+	if len(parsed.Transactions) == 0 {
+		if len(parsed.Rejected) > 0 {
+			return nil, &RejectedRowsError{
+				Message: fmt.Sprintf("nenhuma transação válida encontrada no arquivo %s", format),
+				Rows:    parsed.Rejected,
+			}
+		}
+		return nil, fmt.Errorf("nenhuma transação encontrada no arquivo %s", format)
+	}
+
+	now := time.Now()
+	if expired := s.stagingRepo.DeleteExpired(now); expired > 0 {
+		log.Printf("Discarded %d expired staged imports", expired)
+	}
+
+	profile := opts.importProfile(parsed.Profile, parsed.Transactions)
+	categorized, err := s.categorizeTransactions(userID, parsed.Transactions, profile.SignConvention)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao categorizar transações: %w", err)
+	}
+
+	rows := make([]StagedRow, len(categorized))
+	for i, t := range categorized {
+		rows[i] = StagedRow{Index: i, Transaction: t}
+	}
+
+	rejected := parsed.Rejected
+	if rejected == nil {
+		rejected = []RejectedRow{}
+	}
+
+	staged := &StagedImport{
+		ID:           uuid.New().String(),
+		UserID:       userID,
+		Filename:     opts.Filename,
+		Format:       format,
+		Rows:         rows,
+		RejectedRows: rejected,
+		Profile:      profile,
+		Invoice:      parsed.Invoice,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(StagedImportTTL),
+	}
+
+	if err := s.stagingRepo.Create(staged); err != nil {
+		return nil, fmt.Errorf("erro ao criar importação pendente: %w", err)
+	}
+
+	log.Printf("Staged %d transactions from %s for user %s (import %s)", len(rows), format, userID, staged.ID)
+
+	return staged, nil
+}
+
+func (s *integrationService) GetStagedImport(userID, id string) (*StagedImport, error) {
+	return s.stagingRepo.FindByID(id, userID)
+}
+
+func (s *integrationService) UpdateStagedRow(userID, id string, index int, req UpdateStagedRowRequest) (*StagedImport, error) {
+	staged, err := s.stagingRepo.FindByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if index < 0 || index >= len(staged.Rows) {
+		return nil, fmt.Errorf("linha %d não existe nesta importação", index)
+	}
+
+	row := &staged.Rows[index]
+
+	if req.Category != nil {
+		row.Category = *req.Category
+		row.CategorySource = expense.CategorySourceManual
+		row.CategoryConfidence = 0
+	}
+
+	if req.Description != nil {
+		row.Description = *req.Description
+	}
+
+	if req.Excluded != nil {
+		row.Excluded = *req.Excluded
+	}
+
+	if err := s.stagingRepo.Update(staged); err != nil {
+		return nil, err
+	}
+
+	return staged, nil
+}
+
+func (s *integrationService) CommitStagedImport(userID, id string) (*ImportAndSaveResponse, error) {
+	staged, err := s.stagingRepo.FindByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []Transaction
+	for _, row := range staged.Rows {
+		if !row.Excluded {
+			transactions = append(transactions, row.Transaction)
+		}
+	}
+
+	if len(transactions) == 0 {
+		return nil, fmt.Errorf("todas as linhas foram excluídas; nada para salvar")
+	}
+
+	if err := s.stagingRepo.Delete(id, userID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.saveTransactions(userID, staged.Format, &ParseResult{
+		Transactions: transactions,
+		Rejected:     staged.RejectedRows,
+		Profile:      staged.Profile,
+		Invoice:      staged.Invoice,
+	}, ImportOptions{Filename: staged.Filename})
+	if err != nil {
+		if restoreErr := s.stagingRepo.Create(staged); restoreErr != nil {
+			log.Printf("Error restoring staged import %s: %v", id, restoreErr)
+		}
+		return nil, err
+	}
+
+	result.Excluded = len(staged.Rows) - len(transactions)
+	result.Processed += result.Excluded
+
+	return result, nil
+}
+
+func (s *integrationService) DiscardStagedImport(userID, id string) error {
+	return s.stagingRepo.Delete(id, userID)
+}
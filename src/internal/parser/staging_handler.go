@@ -0,0 +1,400 @@
+package parser
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StageCSV godoc
+// @Summary Upload CSV para pré-visualização
+// @Description Faz upload de um arquivo CSV, categoriza as transações e guarda o resultado como importação pendente, sem salvar
+// @Tags parser
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV file"
+// @Param profile formData string false "Perfil do banco (ex.: nubank_conta, inter_conta); detectado automaticamente se vazio"
+// @Param mapping_id formData string false "ID de um mapeamento de colunas salvo (substitui a detecção automática)"
+// @Param delimiter formData string false "Delimitador (auto, ',', ';', tab, '|')"
+// @Param encoding formData string false "Encoding (auto, utf-8, windows-1252, iso-8859-1)"
+// @Param locale formData string false "Formato dos valores (auto, pt-BR, en-US)"
+// @Param strict formData bool false "Rejeita o arquivo inteiro se alguma linha for inválida"
+// @Param statement_kind formData string false "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos"
+// @Param sign_convention formData string false "Convenção de sinal (auto, debit_positive, debit_negative)"
+// @Success 201 {object} parser.StagedImport
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /parser/staged/csv [post]
+func (h *Handler) StageCSV(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	f, filename, ok := h.openUpload(c, "CSV", []string{"text/csv"}, ".csv")
+	if !ok {
+		return
+	}
+	defer f.Close()
+
+	opts, err := h.csvOptionsFromForm(c)
+	if err != nil {
+		respondOptionsError(c, err)
+		return
+	}
+
+	importOpts, err := importOptionsFromForm(c, filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	staged, err := h.integrationService.StageCSV(c.GetString("user_id"), f, opts, importOpts)
+	if err != nil {
+		respondImportError(c, "CSV", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, staged)
+}
+
+// StageOFX godoc
+// @Summary Upload OFX para pré-visualização
+// @Description Faz upload de um extrato OFX, categoriza as transações e guarda o resultado como importação pendente, sem salvar
+// @Tags parser
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "OFX file"
+// @Param strict formData bool false "Rejeita o arquivo inteiro se alguma transação for inválida"
+// @Param statement_kind formData string false "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos"
+// @Param sign_convention formData string false "Convenção de sinal (auto, debit_positive, debit_negative)"
+// @Success 201 {object} parser.StagedImport
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /parser/staged/ofx [post]
+func (h *Handler) StageOFX(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	f, filename, ok := h.openUpload(c, "OFX", []string{"application/x-ofx", "application/ofx"}, ".ofx")
+	if !ok {
+		return
+	}
+	defer f.Close()
+
+	importOpts, err := importOptionsFromForm(c, filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	staged, err := h.integrationService.StageOFX(c.GetString("user_id"), f, importOpts)
+	if err != nil {
+		respondImportError(c, "OFX", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, staged)
+}
+
+// StageCAMT053 godoc
+// @Summary Upload CAMT.053 para pré-visualização
+// @Description Faz upload de um extrato ISO 20022 CAMT.053 (XML), categoriza os lançamentos e guarda o resultado como importação pendente, sem salvar
+// @Tags parser
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CAMT.053 XML file"
+// @Param strict formData bool false "Rejeita o arquivo inteiro se algum lançamento for inválido"
+// @Param statement_kind formData string false "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos"
+// @Param sign_convention formData string false "Convenção de sinal (auto, debit_positive, debit_negative)"
+// @Success 201 {object} parser.StagedImport
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /parser/staged/camt053 [post]
+func (h *Handler) StageCAMT053(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	f, filename, ok := h.openUpload(c, "CAMT.053 (XML)", []string{"application/xml", "text/xml"}, ".xml")
+	if !ok {
+		return
+	}
+	defer f.Close()
+
+	importOpts, err := importOptionsFromForm(c, filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	staged, err := h.integrationService.StageCAMT053(c.GetString("user_id"), f, importOpts)
+	if err != nil {
+		respondImportError(c, "CAMT.053", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, staged)
+}
+
+// StagePDF godoc
+// @Summary Upload de fatura PDF para pré-visualização
+// @Description Faz upload da fatura do cartão em PDF (layout Nubank), categoriza as transações e guarda o resultado como importação pendente, sem salvar
+// @Tags parser
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "PDF file"
+// @Param strict formData bool false "Rejeita o arquivo inteiro se alguma linha for inválida"
+// @Param statement_kind formData string false "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos"
+// @Param sign_convention formData string false "Convenção de sinal (auto, debit_positive, debit_negative)"
+// @Success 201 {object} parser.StagedImport
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /parser/staged/pdf [post]
+func (h *Handler) StagePDF(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	f, filename, ok := h.openUpload(c, "PDF", []string{"application/pdf"}, ".pdf")
+	if !ok {
+		return
+	}
+	defer f.Close()
+
+	importOpts, err := importOptionsFromForm(c, filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	staged, err := h.integrationService.StagePDF(c.GetString("user_id"), f, importOpts)
+	if err != nil {
+		respondImportError(c, "PDF", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, staged)
+}
+
+// StageXLSX godoc
+// @Summary Upload de planilha XLSX para pré-visualização
+// @Description Faz upload de uma planilha Excel (.xlsx), categoriza as transações e guarda o resultado como importação pendente, sem salvar
+// @Tags parser
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "XLSX file"
+// @Param sheet formData string false "Nome ou número (a partir de 1) da planilha; padrão é a primeira"
+// @Param header_row formData int false "Número da linha do cabeçalho; detectado automaticamente se vazio"
+// @Param profile formData string false "Perfil do banco (ex.: nubank_conta, inter_conta); detectado automaticamente se vazio"
+// @Param mapping_id formData string false "ID de um mapeamento de colunas salvo (substitui a detecção automática)"
+// @Param locale formData string false "Formato dos valores em células de texto (auto, pt-BR, en-US)"
+// @Param strict formData bool false "Rejeita o arquivo inteiro se alguma linha for inválida"
+// @Param statement_kind formData string false "Tipo de extrato (auto, credit_card, checking, savings); define como os sinais dos valores são lidos"
+// @Param sign_convention formData string false "Convenção de sinal (auto, debit_positive, debit_negative)"
+// @Success 201 {object} parser.StagedImport
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /parser/staged/xlsx [post]
+func (h *Handler) StageXLSX(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	f, filename, ok := h.openUpload(c, "XLSX", []string{xlsxContentType}, ".xlsx")
+	if !ok {
+		return
+	}
+	defer f.Close()
+
+	opts, err := h.xlsxOptionsFromForm(c)
+	if err != nil {
+		respondOptionsError(c, err)
+		return
+	}
+
+	importOpts, err := importOptionsFromForm(c, filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	staged, err := h.integrationService.StageXLSX(c.GetString("user_id"), f, opts, importOpts)
+	if err != nil {
+		respondImportError(c, "XLSX", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, staged)
+}
+
+// GetStagedImport godoc
+// @Summary Busca uma importação pendente
+// @Description Retorna as linhas parseadas e categorizadas de uma importação ainda não confirmada
+// @Tags parser
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da importação pendente"
+// @Success 200 {object} parser.StagedImport
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /parser/staged/{id} [get]
+func (h *Handler) GetStagedImport(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	staged, err := h.integrationService.GetStagedImport(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		respondStagingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, staged)
+}
+
+// UpdateStagedRow godoc
+// @Summary Edita uma linha da importação pendente
+// @Description Altera a categoria ou a descrição de uma linha, ou a exclui da importação
+// @Tags parser
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da importação pendente"
+// @Param index path int true "Índice da linha"
+// @Param request body UpdateStagedRowRequest true "Alterações da linha"
+// @Success 200 {object} parser.StagedImport
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /parser/staged/{id}/rows/{index} [patch]
+func (h *Handler) UpdateStagedRow(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Índice de linha inválido",
+		})
+		return
+	}
+
+	var req UpdateStagedRowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Formato de requisição inválido: " + err.Error(),
+		})
+		return
+	}
+
+	staged, err := h.integrationService.UpdateStagedRow(c.GetString("user_id"), c.Param("id"), index, req)
+	if err != nil {
+		respondStagingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, staged)
+}
+
+// CommitStagedImport godoc
+// @Summary Confirma uma importação pendente
+// @Description Salva as linhas não excluídas da importação pendente como despesas
+// @Tags parser
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da importação pendente"
+// @Success 200 {object} parser.ImportAndSaveResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /parser/staged/{id}/commit [post]
+func (h *Handler) CommitStagedImport(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	result, err := h.integrationService.CommitStagedImport(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, ErrStagedImportNotFound) {
+			respondStagingError(c, err)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erro ao salvar importação: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DiscardStagedImport godoc
+// @Summary Descarta uma importação pendente
+// @Description Remove a importação pendente sem salvar nenhuma transação
+// @Tags parser
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da importação pendente"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /parser/staged/{id} [delete]
+func (h *Handler) DiscardStagedImport(c *gin.Context) {
+	if !h.requireIntegration(c) {
+		return
+	}
+
+	if err := h.integrationService.DiscardStagedImport(c.GetString("user_id"), c.Param("id")); err != nil {
+		respondStagingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Importação pendente descartada",
+	})
+}
+
+func respondStagingError(c *gin.Context, err error) {
+	if errors.Is(err, ErrStagedImportNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrStagedImportNotFound = errors.New("importação pendente não encontrada ou expirada")
+
+type StagingRepository interface {
+	Create(staged *StagedImport) error
+	FindByID(id, userID string) (*StagedImport, error)
+	Update(staged *StagedImport) error
+	Delete(id, userID string) error
+	DeleteExpired(now time.Time) int
+}
+
+type memoryStagingRepository struct {
+	imports map[string]*StagedImport
+	mu      sync.RWMutex
+}
+
+func NewStagingRepository() StagingRepository {
+	return &memoryStagingRepository{
+		imports: make(map[string]*StagedImport),
+	}
+}
+
+func (r *memoryStagingRepository) Create(staged *StagedImport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.imports[staged.ID] = staged
+	return nil
+}
+
+func (r *memoryStagingRepository) FindByID(id, userID string) (*StagedImport, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	staged, exists := r.imports[id]
+	if !exists || staged.UserID != userID || time.Now().After(staged.ExpiresAt) {
+		return nil, ErrStagedImportNotFound
+	}
+
+	copied := *staged
+	copied.Rows = append([]StagedRow(nil), staged.Rows...)
+	return &copied, nil
+}
+
+func (r *memoryStagingRepository) Update(staged *StagedImport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.imports[staged.ID]
+	if !exists || existing.UserID != staged.UserID {
+		return ErrStagedImportNotFound
+	}
+
+	r.imports[staged.ID] = staged
+	return nil
+}
+
+func (r *memoryStagingRepository) Delete(id, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	staged, exists := r.imports[id]
+	if !exists || staged.UserID != userID {
+		return ErrStagedImportNotFound
+	}
+
+	delete(r.imports, id)
+	return nil
+}
+
+func (r *memoryStagingRepository) DeleteExpired(now time.Time) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for id, staged := range r.imports {
+		if now.After(staged.ExpiresAt) {
+			delete(r.imports, id)
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStageCSVStrict(t *testing.T) {
+	csv := csvWithRows(2, 1)
+
+	tests := []struct {
+		name       string
+		strict     bool
+		wantStaged bool
+	}{
+		{"strict", true, false},
+		{"lenient", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestIntegrationService()
+
+			staged, err := s.StageCSV("user", strings.NewReader(csv), CSVOptions{}, ImportOptions{Filename: "extrato.csv", Strict: tt.strict})
+			if !tt.wantStaged {
+				var rejectedErr *RejectedRowsError
+				if !errors.As(err, &rejectedErr) || len(rejectedErr.Rows) != 1 {
+					t.Fatalf("StageCSV error = %v, want the rejected row", err)
+				}
+				if staged != nil {
+					t.Errorf("staged = %+v, want nothing staged", staged)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("StageCSV: %v", err)
+			}
+			if len(staged.Rows) != 2 || len(staged.RejectedRows) != 1 {
+				t.Errorf("staged %d rows and %d rejected, want 2 and 1", len(staged.Rows), len(staged.RejectedRows))
+			}
+		})
+	}
+}
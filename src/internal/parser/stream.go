@@ -0,0 +1,173 @@
+package parser
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// csvRowReader splits a CSV file into rows one at a time, keeping the line
+// number and raw text of each row for rejection reports.
+type csvRowReader struct {
+	reader    *csv.Reader
+	lines     *lineRecorder
+	delimiter rune
+}
+
+func newCSVRowReader(file io.Reader, opts CSVOptions) (*csvRowReader, error) {
+	text, err := newTextReader(file, opts.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	delimiter := opts.Delimiter
+	if delimiter == 0 {
+		sample, _ := text.Peek(sniffSize)
+		delimiter = sniffDelimiter(sample)
+	}
+
+	lines := newLineRecorder(text)
+	reader := csv.NewReader(lines)
+	reader.Comma = delimiter
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	return &csvRowReader{reader: reader, lines: lines, delimiter: delimiter}, nil
+}
+
+// next returns the next row, or the rejected row when the line is
+// malformed. It returns io.EOF at the end of the file and any other read
+// error as is, since the reader cannot go past it.
+func (r *csvRowReader) next() (*TableRow, *RejectedRow, error) {
+	record, err := r.reader.Read()
+	if err == io.EOF {
+		return nil, nil, io.EOF
+	}
+	if err != nil {
+		var parseErr *csv.ParseError
+		if !errors.As(err, &parseErr) {
+			return nil, nil, fmt.Errorf("erro ao ler CSV: %w", err)
+		}
+		rejected := &RejectedRow{
+			Line:   parseErr.StartLine,
+			Raw:    r.lines.text(parseErr.StartLine, parseErr.Line),
+			Reason: "linha malformada: " + parseErr.Err.Error(),
+		}
+		r.lines.forget(parseErr.Line)
+		return nil, rejected, nil
+	}
+
+	line, _ := r.reader.FieldPos(0)
+	lastLine, _ := r.reader.FieldPos(len(record) - 1)
+	row := &TableRow{Line: line, Raw: r.lines.text(line, lastLine), Record: record}
+	r.lines.forget(lastLine)
+
+	return row, nil, nil
+}
+
+// StreamCSV parses a CSV file like ParseCSV, but hands the result to fn in
+// chunks of up to chunkSize rows as the file is read, so large files are
+// never held in memory. The importer, the amount format and the sign
+// convention are picked from the first rows and kept for the rest of the
+// file. An error returned by fn stops the parsing and is returned as is.
+func (s *service) StreamCSV(file io.Reader, opts CSVOptions, chunkSize int, fn func(chunk *ParseResult) error) error {
+	reader, err := newCSVRowReader(file, opts)
+	if err != nil {
+		return err
+	}
+
+	window := headerSearchRows + detectSampleRows
+	if opts.Mapping != nil {
+		window = max(window, opts.Mapping.SkipRows+1+detectSampleRows)
+	}
+
+	var rows []TableRow
+	var malformed []RejectedRow
+	eof := false
+
+	for len(rows) < window {
+		row, rejected, err := reader.next()
+		if err == io.EOF {
+			eof = true
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if rejected != nil {
+			malformed = append(malformed, *rejected)
+			continue
+		}
+		rows = append(rows, *row)
+	}
+
+	if len(rows) == 0 {
+		return fmt.Errorf("erro ao ler header: %w", io.EOF)
+	}
+
+	importer, headerIdx, err := s.selectImporter(rows, headerSearchRows, 0, opts)
+	if err != nil {
+		return err
+	}
+
+	header := rows[headerIdx]
+	profile := importer.Profile()
+
+	pending := rows[headerIdx+1:]
+	var pendingRejected []RejectedRow
+	for _, rejected := range malformed {
+		if rejected.Line == 0 || rejected.Line > header.Line {
+			pendingRejected = append(pendingRejected, rejected)
+		}
+	}
+
+	flush := func() error {
+		result := importer.Parse(&Table{
+			Header:    header.Record,
+			Rows:      pending,
+			Delimiter: reader.delimiter,
+		}, opts)
+		result.Rejected = append(result.Rejected, pendingRejected...)
+		sortRejected(result.Rejected)
+
+		// The first chunk settles the sign convention for the whole file.
+		resolveSign(&profile, result.Transactions)
+		chunkProfile := profile
+		result.Profile = &chunkProfile
+
+		if opts.Locale == LocaleAuto && result.Locale != LocaleAuto {
+			opts.Locale = result.Locale
+		}
+
+		pending, pendingRejected = nil, nil
+		return fn(result)
+	}
+
+	for !eof {
+		if len(pending) >= chunkSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+
+		row, rejected, err := reader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if rejected != nil {
+			pendingRejected = append(pendingRejected, *rejected)
+			continue
+		}
+		pending = append(pending, *row)
+	}
+
+	if len(pending) > 0 || len(pendingRejected) > 0 {
+		return flush()
+	}
+
+	return nil
+}
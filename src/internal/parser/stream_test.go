@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+const streamCSV = "Data,Descrição,Valor\n" +
+	"01/01/2026,Padaria,\"10,00\"\n" +
+	"02/01/2026,\"Mercado,25,00\n"
+
+func TestStreamCSVReadError(t *testing.T) {
+	boom := errors.New("boom")
+	file := io.MultiReader(strings.NewReader("Data,Descrição,Valor\n01/01/2026,Padaria,\"10,00\"\n"), iotest.ErrReader(boom))
+
+	chunks := 0
+	err := NewService().StreamCSV(file, CSVOptions{}, 100, func(chunk *ParseResult) error {
+		chunks++
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("StreamCSV error = %v, want %v", err, boom)
+	}
+	if chunks != 0 {
+		t.Errorf("got %d chunks before the read error, want 0", chunks)
+	}
+}
+
+func TestParseCSVReadError(t *testing.T) {
+	boom := errors.New("boom")
+	file := io.MultiReader(strings.NewReader("Data,Descrição,Valor\n"), iotest.ErrReader(boom))
+
+	if _, err := NewService().ParseCSV(file, CSVOptions{}); !errors.Is(err, boom) {
+		t.Fatalf("ParseCSV error = %v, want %v", err, boom)
+	}
+}
+
+func TestStreamCSVMalformedRow(t *testing.T) {
+	var rejected []RejectedRow
+	err := NewService().StreamCSV(strings.NewReader(streamCSV), CSVOptions{}, 100, func(chunk *ParseResult) error {
+		rejected = append(rejected, chunk.Rejected...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamCSV: %v", err)
+	}
+	if len(rejected) != 1 {
+		t.Fatalf("got %d rejected rows, want 1: %+v", len(rejected), rejected)
+	}
+	if rejected[0].Line != 3 || !strings.Contains(rejected[0].Raw, "Mercado") {
+		t.Errorf("rejected = %+v, want line 3 with its raw text", rejected[0])
+	}
+}
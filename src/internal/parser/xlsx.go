@@ -0,0 +1,465 @@
+package parser
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxXLSXSize     = 50 << 20
+	maxXLSXPartSize = 200 << 20
+)
+
+// ParseXLSX reads one sheet of an Excel workbook and runs its rows through
+// the same profile detection and row parsing used for CSV files.
+func (s *service) ParseXLSX(file io.Reader, opts XLSXOptions) (*ParseResult, error) {
+	data, err := io.ReadAll(io.LimitReader(file, maxXLSXSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler XLSX: %w", err)
+	}
+
+	if len(data) > maxXLSXSize {
+		return nil, fmt.Errorf("arquivo XLSX excede o limite de %d MB", maxXLSXSize>>20)
+	}
+
+	workbook, err := openWorkbook(data)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet, err := workbook.findSheet(opts.Sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := workbook.readSheet(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler planilha %q: %w", sheet.name, err)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("planilha %q está vazia", sheet.name)
+	}
+
+	return s.parseTable(rows, nil, 0, opts.HeaderRow, opts.CSVOptions)
+}
+
+type xlsxWorkbook struct {
+	files     map[string]*zip.File
+	sheets    []xlsxSheet
+	strings   []string
+	dateStyle []bool
+	date1904  bool
+}
+
+type xlsxSheet struct {
+	name string
+	path string
+}
+
+func openWorkbook(data []byte) (*xlsxWorkbook, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("arquivo não é uma planilha XLSX válida")
+	}
+
+	wb := &xlsxWorkbook{files: make(map[string]*zip.File)}
+	for _, f := range archive.File {
+		wb.files[strings.TrimPrefix(f.Name, "/")] = f
+	}
+
+	if _, ok := wb.files["xl/workbook.xml"]; !ok {
+		return nil, fmt.Errorf("arquivo não é uma planilha XLSX válida (xl/workbook.xml ausente)")
+	}
+
+	if err := wb.readWorkbook(); err != nil {
+		return nil, err
+	}
+
+	if err := wb.readSharedStrings(); err != nil {
+		return nil, err
+	}
+
+	if err := wb.readStyles(); err != nil {
+		return nil, err
+	}
+
+	return wb, nil
+}
+
+func (wb *xlsxWorkbook) open(name string) (io.ReadCloser, error) {
+	f, ok := wb.files[name]
+	if !ok {
+		return nil, nil
+	}
+
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(r, maxXLSXPartSize), r}, nil
+}
+
+func (wb *xlsxWorkbook) decode(name string, v interface{}) (bool, error) {
+	r, err := wb.open(name)
+	if err != nil || r == nil {
+		return false, err
+	}
+	defer r.Close()
+
+	if err := xml.NewDecoder(r).Decode(v); err != nil {
+		return false, fmt.Errorf("erro ao ler %s: %w", name, err)
+	}
+	return true, nil
+}
+
+func (wb *xlsxWorkbook) readWorkbook() error {
+	var workbook struct {
+		Properties struct {
+			Date1904 string `xml:"date1904,attr"`
+		} `xml:"workbookPr"`
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if _, err := wb.decode("xl/workbook.xml", &workbook); err != nil {
+		return err
+	}
+
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if _, err := wb.decode("xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return err
+	}
+
+	targets := make(map[string]string)
+	for _, rel := range rels.Relationships {
+		target := rel.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join("xl", target)
+		}
+		targets[rel.ID] = target
+	}
+
+	wb.date1904 = workbook.Properties.Date1904 == "1" || workbook.Properties.Date1904 == "true"
+
+	for i, sheet := range workbook.Sheets {
+		target := targets[sheet.ID]
+		if target == "" {
+			target = fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1)
+		}
+		wb.sheets = append(wb.sheets, xlsxSheet{name: sheet.Name, path: target})
+	}
+
+	if len(wb.sheets) == 0 {
+		return fmt.Errorf("nenhuma planilha encontrada no arquivo")
+	}
+
+	return nil
+}
+
+func (wb *xlsxWorkbook) readSharedStrings() error {
+	r, err := wb.open("xl/sharedStrings.xml")
+	if err != nil || r == nil {
+		return err
+	}
+	defer r.Close()
+
+	decoder := xml.NewDecoder(r)
+	var current strings.Builder
+	inString, inText, inPhonetic := false, false, false
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("erro ao ler textos da planilha: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "si":
+				inString = true
+				current.Reset()
+			case "rPh":
+				inPhonetic = true
+			case "t":
+				inText = inString && !inPhonetic
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "si":
+				wb.strings = append(wb.strings, current.String())
+				inString = false
+			case "rPh":
+				inPhonetic = false
+			case "t":
+				inText = false
+			}
+		case xml.CharData:
+			if inText {
+				current.Write(t)
+			}
+		}
+	}
+}
+
+var builtinDateFormats = map[int]bool{
+	14: true, 15: true, 16: true, 17: true, 18: true, 19: true, 20: true, 21: true, 22: true,
+	27: true, 28: true, 29: true, 30: true, 31: true, 32: true, 33: true, 34: true, 35: true, 36: true,
+	45: true, 46: true, 47: true, 50: true, 51: true, 52: true, 53: true, 54: true, 55: true, 56: true, 57: true, 58: true,
+}
+
+var formatLiterals = regexp.MustCompile(`"[^"]*"|\[[^\]]*\]|\\.`)
+
+func (wb *xlsxWorkbook) readStyles() error {
+	var styles struct {
+		NumFmts []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		CellXfs []struct {
+			NumFmtID int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if _, err := wb.decode("xl/styles.xml", &styles); err != nil {
+		return err
+	}
+
+	custom := make(map[int]bool)
+	for _, f := range styles.NumFmts {
+		code := strings.ToLower(formatLiterals.ReplaceAllString(f.Code, ""))
+		custom[f.ID] = strings.ContainsAny(code, "dy") || (strings.Contains(code, "m") && !strings.Contains(code, "h"))
+	}
+
+	wb.dateStyle = make([]bool, len(styles.CellXfs))
+	for i, xf := range styles.CellXfs {
+		if isDate, ok := custom[xf.NumFmtID]; ok {
+			wb.dateStyle[i] = isDate
+		} else {
+			wb.dateStyle[i] = builtinDateFormats[xf.NumFmtID]
+		}
+	}
+
+	return nil
+}
+
+func (wb *xlsxWorkbook) findSheet(selector string) (xlsxSheet, error) {
+	selector = strings.TrimSpace(selector)
+	if selector == "" {
+		return wb.sheets[0], nil
+	}
+
+	if index, err := strconv.Atoi(selector); err == nil {
+		if index >= 1 && index <= len(wb.sheets) {
+			return wb.sheets[index-1], nil
+		}
+	}
+
+	for _, sheet := range wb.sheets {
+		if strings.EqualFold(sheet.name, selector) {
+			return sheet, nil
+		}
+	}
+
+	names := make([]string, len(wb.sheets))
+	for i, sheet := range wb.sheets {
+		names[i] = sheet.name
+	}
+	return xlsxSheet{}, fmt.Errorf("planilha %q não encontrada; disponíveis: %s", selector, strings.Join(names, ", "))
+}
+
+// readSheet returns the non-empty rows of a worksheet. Line is the row
+// number shown in Excel, and Raw joins the cell texts with ";".
+func (wb *xlsxWorkbook) readSheet(sheet xlsxSheet) ([]TableRow, error) {
+	r, err := wb.open(sheet.path)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, fmt.Errorf("arquivo %s ausente", sheet.path)
+	}
+	defer r.Close()
+
+	decoder := xml.NewDecoder(r)
+
+	var rows []TableRow
+	var record []string
+	rowNumber, column := 0, 0
+
+	var cellType, cellStyle, value string
+	var inValue, inInline bool
+	var text strings.Builder
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "row":
+				rowNumber++
+				if n, err := strconv.Atoi(attr(t, "r")); err == nil {
+					rowNumber = n
+				}
+				record = record[:0]
+				column = 0
+			case "c":
+				if ref := attr(t, "r"); ref != "" {
+					if col := columnIndex(ref); col >= 0 {
+						column = col
+					}
+				}
+				cellType, cellStyle = attr(t, "t"), attr(t, "s")
+				value = ""
+				text.Reset()
+			case "v":
+				inValue = true
+			case "is":
+				inInline = true
+			}
+		case xml.CharData:
+			if inValue {
+				value += string(t)
+			} else if inInline {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "v":
+				inValue = false
+			case "is":
+				inInline = false
+			case "c":
+				for len(record) <= column {
+					record = append(record, "")
+				}
+				record[column] = wb.cellText(cellType, cellStyle, value, text.String())
+				column++
+			case "row":
+				if !emptyRecord(record) {
+					rows = append(rows, TableRow{
+						Line:   rowNumber,
+						Raw:    strings.Join(record, ";"),
+						Record: append([]string(nil), record...),
+					})
+				}
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+func (wb *xlsxWorkbook) cellText(cellType, style, value, inline string) string {
+	switch cellType {
+	case "s":
+		index, err := strconv.Atoi(strings.TrimSpace(value))
+		if err == nil && index >= 0 && index < len(wb.strings) {
+			return strings.TrimSpace(wb.strings[index])
+		}
+		return ""
+	case "inlineStr":
+		return strings.TrimSpace(inline)
+	case "b":
+		if value == "1" {
+			return "TRUE"
+		}
+		return "FALSE"
+	case "str", "e":
+		return strings.TrimSpace(value)
+	}
+
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return strings.TrimSpace(value)
+	}
+
+	if index, err := strconv.Atoi(style); err == nil && index >= 0 && index < len(wb.dateStyle) && wb.dateStyle[index] {
+		return excelDate(number, wb.date1904).Format("2006-01-02")
+	}
+
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+// excelDate converts an Excel serial date. The 1900 system counts from
+// 1899-12-30 because of Excel's fictitious 1900-02-29.
+func excelDate(serial float64, date1904 bool) time.Time {
+	base := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	if date1904 {
+		base = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	days := math.Floor(serial)
+	seconds := math.Round((serial - days) * 86400)
+	return base.AddDate(0, 0, int(days)).Add(time.Duration(seconds) * time.Second)
+}
+
+// columnIndex converts a cell reference such as "AB12" to a zero-based
+// column index.
+func columnIndex(ref string) int {
+	index := 0
+	letters := 0
+	for _, r := range ref {
+		if r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A'+1)
+		letters++
+	}
+
+	if letters == 0 || letters > 3 {
+		return -1
+	}
+	return index - 1
+}
+
+func attr(element xml.StartElement, name string) string {
+	for _, a := range element.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func emptyRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,177 @@
+package parser
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// buildXLSX zips the given parts into a workbook. Only the parts the
+// parser reads are needed; content types and document properties are not.
+func buildXLSX(t testing.TB, parts map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range parts {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+var xlsxParts = map[string]string{
+	"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Extrato" sheetId="1" r:id="rId1"/><sheet name="Resumo" sheetId="2" r:id="rId2"/></sheets></workbook>`,
+	"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Target="/xl/worksheets/resumo.xml"/></Relationships>`,
+	"xl/sharedStrings.xml": `<sst><si><t>Data</t></si><si><t>Descrição</t></si><si><t>Valor</t></si>` +
+		`<si><r><t>Padaria </t></r><r><t>Central</t></r><rPh><t>ignorado</t></rPh></si></sst>`,
+	"xl/styles.xml": `<styleSheet><numFmts><numFmt numFmtId="164" formatCode="&quot;R$&quot; #,##0.00"/></numFmts>` +
+		`<cellXfs><xf numFmtId="0"/><xf numFmtId="14"/><xf numFmtId="164"/></cellXfs></styleSheet>`,
+	"xl/worksheets/sheet1.xml": `<worksheet><sheetData>` +
+		`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c></row>` +
+		`<row r="2"><c r="A2" s="1"><v>46023</v></c><c r="B2" t="s"><v>3</v></c><c r="C2" s="2"><v>-10.5</v></c></row>` +
+		`<row r="3"><c r="A3" t="inlineStr"><is><t> </t></is></c></row>` +
+		`<row r="4"><c r="A4" t="inlineStr"><is><t>02/01/2026</t></is></c><c r="C4"><v>25</v></c><c r="B4" t="inlineStr"><is><t>Mercado</t></is></c></row>` +
+		`</sheetData></worksheet>`,
+	"xl/worksheets/resumo.xml": `<worksheet><sheetData><row><c t="str"><v>Total</v></c><c t="b"><v>1</v></c></row></sheetData></worksheet>`,
+}
+
+func TestParseXLSX(t *testing.T) {
+	result, err := NewService().ParseXLSX(bytes.NewReader(buildXLSX(t, xlsxParts)), XLSXOptions{})
+	if err != nil {
+		t.Fatalf("ParseXLSX: %v", err)
+	}
+
+	if len(result.Transactions) != 2 {
+		t.Fatalf("got %d transactions %+v, want 2 (rejected %+v)", len(result.Transactions), result.Transactions, result.Rejected)
+	}
+
+	tests := []struct {
+		date        string
+		description string
+		amount      float64
+	}{
+		{"2026-01-01", "Padaria Central", -10.5},
+		{"2026-01-02", "Mercado", 25},
+	}
+	for i, tt := range tests {
+		got := result.Transactions[i]
+		if got.Date.Format("2006-01-02") != tt.date || got.Description != tt.description || got.Amount != tt.amount {
+			t.Errorf("transaction %d = %+v, want %+v", i, got, tt)
+		}
+	}
+}
+
+func TestXLSXReadSheet(t *testing.T) {
+	wb, err := openWorkbook(buildXLSX(t, xlsxParts))
+	if err != nil {
+		t.Fatalf("openWorkbook: %v", err)
+	}
+
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{"", []string{"Data;Descrição;Valor", "2026-01-01;Padaria Central;-10.5", "02/01/2026;Mercado;25"}},
+		{"1", []string{"Data;Descrição;Valor", "2026-01-01;Padaria Central;-10.5", "02/01/2026;Mercado;25"}},
+		{"resumo", []string{"Total;TRUE"}},
+		{"2", []string{"Total;TRUE"}},
+	}
+
+	for _, tt := range tests {
+		sheet, err := wb.findSheet(tt.selector)
+		if err != nil {
+			t.Errorf("findSheet(%q): %v", tt.selector, err)
+			continue
+		}
+		rows, err := wb.readSheet(sheet)
+		if err != nil {
+			t.Errorf("readSheet(%q): %v", tt.selector, err)
+			continue
+		}
+		var got []string
+		for _, row := range rows {
+			got = append(got, row.Raw)
+		}
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("sheet %q = %q, want %q", tt.selector, got, tt.want)
+		}
+	}
+
+	if _, err := wb.findSheet("3"); err == nil || !strings.Contains(err.Error(), "Extrato, Resumo") {
+		t.Errorf("findSheet(3) error = %v, want the available sheets listed", err)
+	}
+}
+
+func TestParseXLSXInvalid(t *testing.T) {
+	tests := map[string][]byte{
+		"not a zip":        []byte("Data;Descrição;Valor\n"),
+		"without workbook": buildXLSX(t, map[string]string{"xl/worksheets/sheet1.xml": "<worksheet/>"}),
+		"without sheets":   buildXLSX(t, map[string]string{"xl/workbook.xml": "<workbook><sheets/></workbook>"}),
+		"missing sheet":    buildXLSX(t, map[string]string{"xl/workbook.xml": `<workbook><sheets><sheet name="A"/></sheets></workbook>`}),
+		"empty sheet": buildXLSX(t, map[string]string{
+			"xl/workbook.xml":          `<workbook><sheets><sheet name="A"/></sheets></workbook>`,
+			"xl/worksheets/sheet1.xml": "<worksheet><sheetData><row/></sheetData></worksheet>",
+		}),
+		"broken xml": buildXLSX(t, map[string]string{"xl/workbook.xml": "<workbook><sheets>"}),
+	}
+
+	for name, data := range tests {
+		if _, err := NewService().ParseXLSX(bytes.NewReader(data), XLSXOptions{}); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	tests := map[string]int{"A1": 0, "c7": 2, "Z9": 25, "AA10": 26, "AB12": 27, "XFD1": 16383, "12": -1, "ABCD1": -1}
+	for ref, want := range tests {
+		if got := columnIndex(ref); got != want {
+			t.Errorf("columnIndex(%q) = %d, want %d", ref, got, want)
+		}
+	}
+}
+
+func TestExcelDate(t *testing.T) {
+	tests := []struct {
+		serial   float64
+		date1904 bool
+		want     string
+	}{
+		{1, false, "1899-12-31 00:00"},
+		{61, false, "1900-03-01 00:00"},
+		{46023, false, "2026-01-01 00:00"},
+		{46023.75, false, "2026-01-01 18:00"},
+		{0, true, "1904-01-01 00:00"},
+		{44561, true, "2026-01-01 00:00"},
+	}
+	for _, tt := range tests {
+		if got := excelDate(tt.serial, tt.date1904).Format("2006-01-02 15:04"); got != tt.want {
+			t.Errorf("excelDate(%v, %v) = %s, want %s", tt.serial, tt.date1904, got, tt.want)
+		}
+	}
+}
+
+func FuzzParseXLSX(f *testing.F) {
+	f.Add(buildXLSX(f, xlsxParts))
+	f.Add(buildXLSX(f, map[string]string{
+		"xl/workbook.xml":          `<workbook><sheets><sheet name="A"/></sheets></workbook>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="999999999"><c r="XFD1" s="99" t="s"><v>-1</v></c></row></sheetData></worksheet>`,
+	}))
+	f.Add([]byte("PK\x03\x04"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		NewService().ParseXLSX(bytes.NewReader(data), XLSXOptions{})
+	})
+}
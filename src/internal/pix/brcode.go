@@ -0,0 +1,257 @@
+package pix
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var ErrInvalidPayload = errors.New("código PIX inválido")
+
+// amountPattern is the transaction amount of field 54: digits with an
+// optional decimal point and up to two decimals, as in "10" or "25.90".
+var amountPattern = regexp.MustCompile(`^\d+(\.\d{1,2})?$`)
+
+// pixGUI identifies the PIX merchant account template among the other
+// payment arrangements an EMV payload may carry.
+const pixGUI = "br.gov.bcb.pix"
+
+// Fields of the BR Code, as in the Manual do BR Code of the Banco Central.
+const (
+	fieldPayloadFormat    = "00"
+	fieldInitiationMethod = "01"
+	fieldMerchantCategory = "52"
+	fieldCurrency         = "53"
+	fieldAmount           = "54"
+	fieldCountry          = "58"
+	fieldMerchantName     = "59"
+	fieldMerchantCity     = "60"
+	fieldPostalCode       = "61"
+	fieldAdditionalData   = "62"
+	fieldCRC              = "63"
+
+	accountGUI         = "00"
+	accountKey         = "01"
+	accountInfo        = "02"
+	accountURL         = "25"
+	additionalTxID     = "05"
+	initiationDynamic  = "12"
+	currencyReal       = "986"
+	merchantAccountMin = 26
+	merchantAccountMax = 51
+)
+
+// Payment is what a PIX "copia e cola" code tells. Static codes carry the
+// key; dynamic ones carry the URL of the charge instead, and Key is empty.
+// Amount is zero when the payer chooses it.
+type Payment struct {
+	Key          string  `json:"key,omitempty"`
+	KeyType      string  `json:"key_type,omitempty"`
+	URL          string  `json:"url,omitempty"`
+	Dynamic      bool    `json:"dynamic"`
+	MerchantName string  `json:"merchant_name"`
+	MerchantCity string  `json:"merchant_city"`
+	PostalCode   string  `json:"postal_code,omitempty"`
+	Amount       float64 `json:"amount"`
+	TxID         string  `json:"txid,omitempty"`
+	Message      string  `json:"message,omitempty"`
+}
+
+// ParsePayload reads a BR Code EMV payload and validates its CRC16.
+func ParsePayload(payload string) (*Payment, error) {
+	payload = strings.TrimSpace(payload)
+
+	fields, err := parseFields(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkCRC(payload, fields); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(fields))
+	for _, f := range fields {
+		values[f.id] = f.value
+	}
+
+	if values[fieldPayloadFormat] != "01" {
+		return nil, fmt.Errorf("%w: formato do payload não suportado", ErrInvalidPayload)
+	}
+	if currency, ok := values[fieldCurrency]; ok && currency != currencyReal {
+		return nil, fmt.Errorf("%w: moeda %s não é real", ErrInvalidPayload, currency)
+	}
+
+	payment := &Payment{
+		Dynamic:      values[fieldInitiationMethod] == initiationDynamic,
+		MerchantName: strings.TrimSpace(values[fieldMerchantName]),
+		MerchantCity: strings.TrimSpace(values[fieldMerchantCity]),
+		PostalCode:   values[fieldPostalCode],
+	}
+
+	if !readAccount(payment, fields) {
+		return nil, fmt.Errorf("%w: o código não tem os dados de uma conta PIX", ErrInvalidPayload)
+	}
+
+	if amount, ok := values[fieldAmount]; ok {
+		if !amountPattern.MatchString(amount) {
+			return nil, fmt.Errorf("%w: valor %q", ErrInvalidPayload, amount)
+		}
+		payment.Amount, err = strconv.ParseFloat(amount, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: valor %q", ErrInvalidPayload, amount)
+		}
+	}
+
+	if additional, ok := values[fieldAdditionalData]; ok {
+		subfields, err := parseFields(additional)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range subfields {
+			// "***" is the txid of static codes that do not set one.
+			if f.id == additionalTxID && f.value != "***" {
+				payment.TxID = f.value
+			}
+		}
+	}
+
+	return payment, nil
+}
+
+// readAccount finds the PIX template among the merchant account fields (26
+// to 51) and reads its key, message and charge URL.
+func readAccount(payment *Payment, fields []field) bool {
+	for _, f := range fields {
+		id, _ := strconv.Atoi(f.id)
+		if id < merchantAccountMin || id > merchantAccountMax {
+			continue
+		}
+
+		subfields, err := parseFields(f.value)
+		if err != nil {
+			continue
+		}
+
+		values := make(map[string]string, len(subfields))
+		for _, sf := range subfields {
+			values[sf.id] = sf.value
+		}
+		if !strings.EqualFold(values[accountGUI], pixGUI) {
+			continue
+		}
+
+		payment.Key = values[accountKey]
+		payment.KeyType = keyType(payment.Key)
+		payment.Message = values[accountInfo]
+		payment.URL = values[accountURL]
+		return payment.Key != "" || payment.URL != ""
+	}
+
+	return false
+}
+
+type field struct {
+	id    string
+	value string
+	// end is the byte offset right after the field's length, where the value
+	// starts; the CRC covers the payload up to it for the CRC field.
+	end int
+}
+
+// parseFields splits an EMV payload into its ID, length and value fields.
+// Lengths count characters, so names with accents are read correctly.
+func parseFields(payload string) ([]field, error) {
+	fields := []field{}
+
+	for pos := 0; pos < len(payload); {
+		if pos+4 > len(payload) {
+			return nil, fmt.Errorf("%w: campo incompleto na posição %d", ErrInvalidPayload, pos)
+		}
+
+		id := payload[pos : pos+2]
+		length, err := strconv.Atoi(payload[pos+2 : pos+4])
+		if err != nil || !isDigits(id) {
+			return nil, fmt.Errorf("%w: campo malformado na posição %d", ErrInvalidPayload, pos)
+		}
+
+		start := pos + 4
+		end := start
+		for i := 0; i < length; i++ {
+			if end >= len(payload) {
+				return nil, fmt.Errorf("%w: campo %s mais curto que o tamanho informado", ErrInvalidPayload, id)
+			}
+			_, size := utf8.DecodeRuneInString(payload[end:])
+			end += size
+		}
+
+		fields = append(fields, field{id: id, value: payload[start:end], end: start})
+		pos = end
+	}
+
+	return fields, nil
+}
+
+// checkCRC validates the CRC16 in the last field, computed over the whole
+// payload up to and including "6304".
+func checkCRC(payload string, fields []field) error {
+	if len(fields) == 0 || fields[len(fields)-1].id != fieldCRC || len(fields[len(fields)-1].value) != 4 {
+		return fmt.Errorf("%w: CRC ausente", ErrInvalidPayload)
+	}
+
+	last := fields[len(fields)-1]
+	expected := fmt.Sprintf("%04X", crc16(payload[:last.end]))
+	if !strings.EqualFold(last.value, expected) {
+		return fmt.Errorf("%w: CRC não confere", ErrInvalidPayload)
+	}
+
+	return nil
+}
+
+// crc16 is CRC-16/CCITT-FALSE: polynomial 0x1021, initial value 0xFFFF.
+func crc16(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// keyType tells the kind of a PIX key: cpf, cnpj, phone, email or the random
+// key (evp).
+func keyType(key string) string {
+	switch {
+	case key == "":
+		return ""
+	case strings.Contains(key, "@"):
+		return "email"
+	case strings.HasPrefix(key, "+"):
+		return "phone"
+	case isDigits(key) && len(key) == 11:
+		return "cpf"
+	case isDigits(key) && len(key) == 14:
+		return "cnpj"
+	case len(key) == 36 && strings.Count(key, "-") == 4:
+		return "evp"
+	}
+	return ""
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
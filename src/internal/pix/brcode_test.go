@@ -0,0 +1,139 @@
+package pix
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"unicode/utf8"
+)
+
+// emv encodes one field: ID, length in characters and value.
+func emv(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, utf8.RuneCountInString(value), value)
+}
+
+// withCRC appends the CRC field to a payload.
+func withCRC(payload string) string {
+	payload += "6304"
+	return payload + fmt.Sprintf("%04X", crc16(payload))
+}
+
+func TestCRC16(t *testing.T) {
+	if got := crc16("123456789"); got != 0x29B1 {
+		t.Errorf("crc16(123456789) = %04X, want 29B1", got)
+	}
+}
+
+func TestParsePayload(t *testing.T) {
+	static := withCRC(emv("00", "01") +
+		emv("26", emv("00", "br.gov.bcb.pix")+emv("01", "fulano@example.com")+emv("02", "Almoço")) +
+		emv("52", "0000") + emv("53", "986") + emv("54", "25.90") + emv("58", "BR") +
+		emv("59", "Padaria São João") + emv("60", "São Paulo") +
+		emv("62", emv("05", "PEDIDO42")))
+
+	dynamic := withCRC(emv("00", "01") + emv("01", "12") +
+		emv("26", emv("00", "BR.GOV.BCB.PIX")+emv("25", "pix.example.com/qr/v2/abc")) +
+		emv("53", "986") + emv("58", "BR") + emv("59", "Loja") + emv("60", "Recife") +
+		emv("62", emv("05", "***")))
+
+	tests := []struct {
+		name    string
+		payload string
+		want    Payment
+	}{
+		{
+			name:    "manual example",
+			payload: "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D",
+			want: Payment{
+				Key:          "123e4567-e12b-12d1-a456-426655440000",
+				KeyType:      "evp",
+				MerchantName: "Fulano de Tal",
+				MerchantCity: "BRASILIA",
+			},
+		},
+		{
+			name:    "static with amount and accents",
+			payload: static,
+			want: Payment{
+				Key:          "fulano@example.com",
+				KeyType:      "email",
+				MerchantName: "Padaria São João",
+				MerchantCity: "São Paulo",
+				Amount:       25.90,
+				TxID:         "PEDIDO42",
+				Message:      "Almoço",
+			},
+		},
+		{
+			name:    "dynamic",
+			payload: dynamic,
+			want: Payment{
+				URL:          "pix.example.com/qr/v2/abc",
+				Dynamic:      true,
+				MerchantName: "Loja",
+				MerchantCity: "Recife",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePayload(tt.payload)
+			if err != nil {
+				t.Fatalf("ParsePayload: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("got %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePayloadInvalid(t *testing.T) {
+	valid := "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{"wrong crc", valid[:len(valid)-4] + "0000"},
+		{"no crc", valid[:len(valid)-8]},
+		{"truncated field", valid[:20]},
+		{"not a pix account", withCRC(emv("00", "01") + emv("26", emv("00", "br.com.outro")+emv("01", "x")) + emv("53", "986"))},
+		{"other currency", withCRC(emv("00", "01") + emv("26", emv("00", "br.gov.bcb.pix")+emv("01", "x")) + emv("53", "840"))},
+		{"negative amount", withCRC(emv("00", "01") + emv("26", emv("00", "br.gov.bcb.pix")+emv("01", "x")) + emv("54", "-1.00"))},
+		{"amount NaN", withCRC(emv("00", "01") + emv("26", emv("00", "br.gov.bcb.pix")+emv("01", "x")) + emv("54", "NaN"))},
+		{"amount Inf", withCRC(emv("00", "01") + emv("26", emv("00", "br.gov.bcb.pix")+emv("01", "x")) + emv("54", "Inf"))},
+		{"amount 0x1p4", withCRC(emv("00", "01") + emv("26", emv("00", "br.gov.bcb.pix")+emv("01", "x")) + emv("54", "0x1p4"))},
+		{"amount 1e3", withCRC(emv("00", "01") + emv("26", emv("00", "br.gov.bcb.pix")+emv("01", "x")) + emv("54", "1e3"))},
+		{"amount 1.234", withCRC(emv("00", "01") + emv("26", emv("00", "br.gov.bcb.pix")+emv("01", "x")) + emv("54", "1.234"))},
+		{"amount .50", withCRC(emv("00", "01") + emv("26", emv("00", "br.gov.bcb.pix")+emv("01", "x")) + emv("54", ".50"))},
+		{"empty", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParsePayload(tt.payload); !errors.Is(err, ErrInvalidPayload) {
+				t.Errorf("error = %v, want ErrInvalidPayload", err)
+			}
+		})
+	}
+}
+
+func TestKeyType(t *testing.T) {
+	tests := map[string]string{
+		"fulano@example.com":                   "email",
+		"+5511999999999":                       "phone",
+		"12345678901":                          "cpf",
+		"12345678000199":                       "cnpj",
+		"123e4567-e12b-12d1-a456-426655440000": "evp",
+		"":                                     "",
+		"abc":                                  "",
+	}
+
+	for key, want := range tests {
+		if got := keyType(key); got != want {
+			t.Errorf("keyType(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
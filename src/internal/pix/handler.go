@@ -0,0 +1,84 @@
+package pix
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// Parse godoc
+// @Summary Lê um código PIX copia e cola
+// @Description Valida o CRC16 do BR Code (payload EMV do PIX copia e cola) e retorna chave, nome e cidade do recebedor, valor e txid, sem salvar nada. Códigos dinâmicos trazem a URL da cobrança no lugar da chave
+// @Tags pix
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ParseRequest true "Payload do PIX copia e cola"
+// @Success 200 {object} pix.Payment
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /pix/parse [post]
+func (h *Handler) Parse(c *gin.Context) {
+	var req ParseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payment, err := h.service.Parse(req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, payment)
+}
+
+// CreateExpense godoc
+// @Summary Cria uma despesa a partir de um PIX copia e cola
+// @Description Lê o BR Code e salva o pagamento como despesa, com o nome do recebedor como descrição e a categoria sugerida pelas mesmas regras da importação de extratos. Os campos enviados substituem os do código; amount é obrigatório quando o código não traz o valor
+// @Tags pix
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateExpenseRequest true "Payload do PIX e dados da despesa"
+// @Success 201 {object} pix.CreateExpenseResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /pix/expenses [post]
+func (h *Handler) CreateExpense(c *gin.Context) {
+	var req CreateExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.CreateExpense(c.GetString("user_id"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, ErrInvalidPayload) || errors.Is(err, ErrAmountRequired) {
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
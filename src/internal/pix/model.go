@@ -0,0 +1,27 @@
+package pix
+
+import (
+	"time"
+
+	"gastei-quanto/src/internal/expense"
+)
+
+type ParseRequest struct {
+	Payload string `json:"payload" binding:"required"`
+}
+
+// CreateExpenseRequest saves the payment of a PIX code as an expense. The
+// fields sent override the ones read from the code; Amount is required when
+// the code leaves it to the payer, and Date defaults to now.
+type CreateExpenseRequest struct {
+	Payload     string     `json:"payload" binding:"required"`
+	Date        *time.Time `json:"date"`
+	Description string     `json:"description"`
+	Category    string     `json:"category"`
+	Amount      float64    `json:"amount" binding:"gte=0"`
+}
+
+type CreateExpenseResponse struct {
+	Payment *Payment         `json:"payment"`
+	Expense *expense.Expense `json:"expense"`
+}
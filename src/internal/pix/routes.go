@@ -0,0 +1,11 @@
+package pix
+
+import "github.com/gin-gonic/gin"
+
+func RegisterRoutes(rg *gin.RouterGroup, handler *Handler) {
+	pix := rg.Group("/pix")
+	{
+		pix.POST("/parse", handler.Parse)
+		pix.POST("/expenses", handler.CreateExpense)
+	}
+}
@@ -0,0 +1,97 @@
+package pix
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"gastei-quanto/src/internal/categorizer"
+	"gastei-quanto/src/internal/expense"
+	"gastei-quanto/src/internal/rule"
+)
+
+var ErrAmountRequired = errors.New("o código PIX não traz o valor; informe amount")
+
+type Service interface {
+	Parse(req ParseRequest) (*Payment, error)
+	CreateExpense(userID string, req CreateExpenseRequest) (*CreateExpenseResponse, error)
+}
+
+type service struct {
+	expenseService expense.Service
+	categorizer    categorizer.Pipeline
+}
+
+func NewService(expenseService expense.Service, categorizer categorizer.Pipeline) Service {
+	return &service{
+		expenseService: expenseService,
+		categorizer:    categorizer,
+	}
+}
+
+func (s *service) Parse(req ParseRequest) (*Payment, error) {
+	return ParsePayload(req.Payload)
+}
+
+// CreateExpense saves the payment as an expense described by the merchant
+// name and categorized by the same pipeline as imported rows.
+func (s *service) CreateExpense(userID string, req CreateExpenseRequest) (*CreateExpenseResponse, error) {
+	payment, err := ParsePayload(req.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := payment.Amount
+	if req.Amount > 0 {
+		amount = math.Round(req.Amount*100) / 100
+	}
+	if amount == 0 {
+		return nil, ErrAmountRequired
+	}
+
+	date := time.Now()
+	if req.Date != nil {
+		date = *req.Date
+	}
+
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		description = payment.MerchantName
+	}
+	if description == "" {
+		description = "PIX"
+	}
+
+	category := strings.TrimSpace(req.Category)
+	categorySource := expense.CategorySourceManual
+	if category == "" {
+		pipeline, err := s.categorizer.ForUser(userID)
+		if err != nil {
+			return nil, err
+		}
+
+		categorized, err := pipeline.Categorize(rule.Candidate{Description: description, Amount: amount, Date: date, Type: "expense"})
+		if err != nil {
+			return nil, err
+		}
+		category, categorySource = categorized.Category, categorized.Source
+	}
+
+	created, err := s.expenseService.Create(userID, expense.CreateExpenseRequest{
+		Date:           date,
+		Description:    description,
+		Category:       category,
+		Amount:         amount,
+		Type:           "expense",
+		CategorySource: categorySource,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateExpenseResponse{
+		Payment: payment,
+		Expense: created,
+	}, nil
+}
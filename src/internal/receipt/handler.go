@@ -0,0 +1,248 @@
+package receipt
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// ImportXML godoc
+// @Summary Importa o XML de uma NFC-e/NF-e
+// @Description Lê o XML da nota fiscal (NFC-e ou NF-e, com ou sem o protocolo de autorização), salva os itens (produto, quantidade, preço unitário e total) e tenta vincular a nota a uma despesa já salva pela data, valor e CNPJ ou nome do estabelecimento
+// @Tags receipts
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "XML da nota fiscal"
+// @Success 201 {object} receipt.ImportResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /receipts/xml [post]
+func (h *Handler) ImportXML(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Arquivo não encontrado. Use o campo 'file' no form-data"})
+		return
+	}
+
+	if !strings.HasSuffix(strings.ToLower(file.Filename), ".xml") &&
+		file.Header.Get("Content-Type") != "application/xml" && file.Header.Get("Content-Type") != "text/xml" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Apenas arquivos XML são aceitos"})
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao abrir arquivo"})
+		return
+	}
+	defer f.Close()
+
+	result, err := h.service.ImportXML(c.GetString("user_id"), f)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// ImportQRCode godoc
+// @Summary Importa uma NFC-e pelo QR code
+// @Description Lê a URL do QR code da NFC-e (ou só os parâmetros dela) e salva a nota. O QR code online traz só a chave de acesso, então a data (issued_at) e o valor (total_amount ou itens) devem ser enviados junto. O XML da mesma nota, enviado depois, completa os itens
+// @Tags receipts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body QRCodeRequest true "URL do QR code e dados da nota"
+// @Success 201 {object} receipt.ImportResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /receipts/qrcode [post]
+func (h *Handler) ImportQRCode(c *gin.Context) {
+	var req QRCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.ImportQRCode(c.GetString("user_id"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// List godoc
+// @Summary Lista as notas fiscais
+// @Description Retorna as notas fiscais importadas pelo usuário autenticado, com os itens
+// @Tags receipts
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Data inicial (YYYY-MM-DD)"
+// @Param end_date query string false "Data final (YYYY-MM-DD)"
+// @Param expense_id query string false "Filtrar pela despesa vinculada"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /receipts [get]
+func (h *Handler) List(c *gin.Context) {
+	var query ListReceiptsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	receipts, err := h.service.List(c.GetString("user_id"), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"receipts": receipts,
+		"count":    len(receipts),
+	})
+}
+
+// Products godoc
+// @Summary Gastos por produto
+// @Description Soma os itens das notas fiscais por produto (código de barras ou descrição): quantidade, total gasto, preço médio e último preço pago
+// @Tags receipts
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Data inicial (YYYY-MM-DD)"
+// @Param end_date query string false "Data final (YYYY-MM-DD)"
+// @Param description query string false "Filtrar produtos pela descrição"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /receipts/products [get]
+func (h *Handler) Products(c *gin.Context) {
+	var query ProductQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	products, err := h.service.Products(c.GetString("user_id"), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"products": products,
+		"count":    len(products),
+	})
+}
+
+// GetByID godoc
+// @Summary Busca uma nota fiscal por ID
+// @Description Retorna a nota fiscal com os itens
+// @Tags receipts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da nota fiscal"
+// @Success 200 {object} receipt.Receipt
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /receipts/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	receipt, err := h.service.GetByID(c.Param("id"), c.GetString("user_id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, receipt)
+}
+
+// LinkExpense godoc
+// @Summary Vincula a nota fiscal a uma despesa
+// @Description Vincula a nota a uma despesa escolhida pelo usuário, quando a busca automática não encontrou ou errou. Com expense_id vazio, desfaz o vínculo
+// @Tags receipts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da nota fiscal"
+// @Param request body LinkExpenseRequest true "Despesa a vincular"
+// @Success 200 {object} receipt.Receipt
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /receipts/{id}/expense [put]
+func (h *Handler) LinkExpense(c *gin.Context) {
+	var req LinkExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	receipt, err := h.service.LinkExpense(c.Param("id"), c.GetString("user_id"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, receipt)
+}
+
+// Delete godoc
+// @Summary Remove uma nota fiscal
+// @Description Remove a nota fiscal e os itens; a despesa vinculada não é alterada
+// @Tags receipts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da nota fiscal"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /receipts/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Param("id"), c.GetString("user_id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Nota fiscal removida",
+	})
+}
+
+func respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrReceiptNotFound), err.Error() == "expense not found":
+		status = http.StatusNotFound
+	case errors.Is(err, ErrReceiptAlreadyImported):
+		status = http.StatusConflict
+	case errors.Is(err, ErrInvalidXML), errors.Is(err, ErrInvalidQRCode), errors.Is(err, ErrInvalidAccessKey):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,111 @@
+package receipt
+
+import (
+	"strings"
+	"time"
+
+	"gastei-quanto/src/internal/expense"
+)
+
+// matchWindow is how many days a card statement may date a purchase apart
+// from the nota: some issuers use the day it was settled.
+const matchWindow = 2
+
+// amountTolerance absorbs rounding between the nota and the statement.
+const amountTolerance = 0.01
+
+// merchantStopWords are parts of company names that say nothing about the
+// merchant.
+var merchantStopWords = map[string]bool{
+	"ltda": true, "me": true, "epp": true, "eireli": true, "sa": true, "s": true, "a": true,
+	"comercio": true, "com": true, "de": true, "do": true, "da": true, "dos": true, "das": true,
+	"e": true, "industria": true, "servicos": true, "alimentos": true, "produtos": true,
+}
+
+// matchExpense looks for the expense that paid for the nota: same amount,
+// dated up to matchWindow days apart and not linked to another nota. The
+// merchant's CNPJ or a word of its name in the description decides between
+// candidates; without it, only a single candidate is accepted.
+func (s *service) matchExpense(userID string, receipt *Receipt) (*expense.Expense, error) {
+	y, m, d := receipt.IssuedAt.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	start := day.AddDate(0, 0, -matchWindow)
+	end := day.AddDate(0, 0, matchWindow+1).Add(-time.Nanosecond)
+	minAmount := receipt.TotalAmount - amountTolerance
+	maxAmount := receipt.TotalAmount + amountTolerance
+
+	candidates, err := s.expenseService.List(userID, expense.ListExpensesQuery{
+		StartDate: &start,
+		EndDate:   &end,
+		Type:      "expense",
+		MinAmount: &minAmount,
+		MaxAmount: &maxAmount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	linked, err := s.repo.FindLinkedExpenseIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	words := merchantWords(receipt.IssuerName)
+
+	var best *expense.Expense
+	bestScore, tied := -1, false
+	for _, candidate := range candidates {
+		if linked[candidate.ID] {
+			continue
+		}
+
+		cy, cm, cd := candidate.Date.Date()
+		distance := int(day.Sub(time.Date(cy, cm, cd, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+		if distance < 0 {
+			distance = -distance
+		}
+
+		score := matchWindow - distance
+		if mentionsMerchant(candidate.Description, receipt.IssuerCNPJ, words) {
+			score += 10
+		}
+
+		switch {
+		case score > bestScore:
+			best, bestScore, tied = candidate, score, false
+		case score == bestScore:
+			tied = true
+		}
+	}
+
+	if best == nil || (tied && bestScore < 10) {
+		return nil, nil
+	}
+
+	return best, nil
+}
+
+func merchantWords(name string) []string {
+	var words []string
+	for _, word := range strings.Fields(expense.NormalizeDescription(name)) {
+		if len(word) >= 3 && !merchantStopWords[word] {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+func mentionsMerchant(description, cnpj string, words []string) bool {
+	if cnpj != "" && strings.Contains(nonDigits.ReplaceAllString(description, ""), cnpj) {
+		return true
+	}
+
+	for _, word := range strings.Fields(expense.NormalizeDescription(description)) {
+		for _, merchant := range words {
+			if word == merchant {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package receipt
+
+import (
+	"time"
+
+	"gastei-quanto/src/internal/expense"
+)
+
+const (
+	ModelNFe  = "55"
+	ModelNFCe = "65"
+
+	SourceXML    = "xml"
+	SourceQRCode = "qrcode"
+)
+
+// Receipt is a nota fiscal (NFC-e or NF-e) with its line items, linked to
+// the expense that paid for it when one is found. Receipts read from a
+// QR code have no items until the XML of the same nota is uploaded.
+type Receipt struct {
+	ID             string    `json:"id"`
+	UserID         string    `json:"user_id"`
+	ExpenseID      string    `json:"expense_id,omitempty"`
+	AccessKey      string    `json:"access_key"`
+	Model          string    `json:"model"`
+	Number         string    `json:"number"`
+	IssuerCNPJ     string    `json:"issuer_cnpj"`
+	IssuerName     string    `json:"issuer_name,omitempty"`
+	IssuedAt       time.Time `json:"issued_at"`
+	TotalAmount    float64   `json:"total_amount"`
+	DiscountAmount float64   `json:"discount_amount,omitempty"`
+	Source         string    `json:"source"`
+	Items          []Item    `json:"items"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Item is a line of the nota. TotalAmount is what was paid for the line,
+// after its discount.
+type Item struct {
+	Number      int     `json:"number"`
+	Code        string  `json:"code,omitempty"`
+	EAN         string  `json:"ean,omitempty"`
+	Description string  `json:"description" binding:"required"`
+	NCM         string  `json:"ncm,omitempty"`
+	Quantity    float64 `json:"quantity" binding:"gt=0"`
+	Unit        string  `json:"unit,omitempty"`
+	UnitPrice   float64 `json:"unit_price" binding:"gte=0"`
+	Discount    float64 `json:"discount,omitempty" binding:"gte=0"`
+	TotalAmount float64 `json:"total_amount" binding:"gte=0"`
+}
+
+// QRCodeRequest carries the URL of the QR code printed on an NFC-e, or just
+// its query string or "p" parameter. The online QR code (the usual one)
+// only carries the access key, so the date and total must be sent along
+// with it; items are optional.
+type QRCodeRequest struct {
+	URL         string     `json:"url" binding:"required"`
+	IssuedAt    *time.Time `json:"issued_at"`
+	TotalAmount float64    `json:"total_amount" binding:"gte=0"`
+	IssuerName  string     `json:"issuer_name"`
+	Items       []Item     `json:"items" binding:"dive"`
+}
+
+type LinkExpenseRequest struct {
+	ExpenseID string `json:"expense_id"`
+}
+
+type ListReceiptsQuery struct {
+	StartDate *time.Time `form:"start_date"`
+	EndDate   *time.Time `form:"end_date"`
+	ExpenseID string     `form:"expense_id"`
+}
+
+// ImportResponse is the imported receipt and, when the nota was matched to
+// an expense already saved, that expense.
+type ImportResponse struct {
+	Receipt *Receipt         `json:"receipt"`
+	Expense *expense.Expense `json:"expense,omitempty"`
+}
+
+type ProductQuery struct {
+	StartDate   *time.Time `form:"start_date"`
+	EndDate     *time.Time `form:"end_date"`
+	Description string     `form:"description"`
+}
+
+// ProductSummary adds up the purchases of one product, identified by its
+// barcode or, without one, by its normalized description.
+type ProductSummary struct {
+	Product       string    `json:"product"`
+	EAN           string    `json:"ean,omitempty"`
+	Unit          string    `json:"unit,omitempty"`
+	Purchases     int       `json:"purchases"`
+	Quantity      float64   `json:"quantity"`
+	TotalAmount   float64   `json:"total_amount"`
+	AveragePrice  float64   `json:"average_price"`
+	LastPrice     float64   `json:"last_price"`
+	LastPurchased time.Time `json:"last_purchased"`
+}
@@ -0,0 +1,352 @@
+package receipt
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidAccessKey = errors.New("chave de acesso inválida")
+	ErrInvalidXML       = errors.New("XML de nota fiscal inválido")
+	ErrInvalidQRCode    = errors.New("QR code de NFC-e inválido")
+)
+
+var nonDigits = regexp.MustCompile(`\D`)
+
+// decimalPattern is how the nota writes amounts and quantities: digits with
+// an optional dot and decimals.
+var decimalPattern = regexp.MustCompile(`^\d+(\.\d+)?$`)
+
+// AccessKey is the 44 digit chave de acesso of a nota: state, year and
+// month of issue, issuer CNPJ, model, series, number, emission type, a
+// random code and a mod 11 check digit.
+type AccessKey struct {
+	Key        string
+	State      string
+	IssuedIn   time.Time
+	IssuerCNPJ string
+	Model      string
+	Series     string
+	Number     string
+}
+
+func ParseAccessKey(value string) (*AccessKey, error) {
+	key := nonDigits.ReplaceAllString(value, "")
+	if len(key) != 44 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAccessKey, value)
+	}
+
+	if accessKeyDigit(key[:43]) != key[43] {
+		return nil, fmt.Errorf("%w: dígito verificador não confere", ErrInvalidAccessKey)
+	}
+
+	year, _ := strconv.Atoi(key[2:4])
+	month, _ := strconv.Atoi(key[4:6])
+	if month < 1 || month > 12 {
+		return nil, fmt.Errorf("%w: mês de emissão inválido", ErrInvalidAccessKey)
+	}
+
+	return &AccessKey{
+		Key:        key,
+		State:      key[0:2],
+		IssuedIn:   time.Date(2000+year, time.Month(month), 1, 0, 0, 0, 0, time.UTC),
+		IssuerCNPJ: key[6:20],
+		Model:      key[20:22],
+		Series:     strings.TrimLeft(key[22:25], "0"),
+		Number:     strings.TrimLeft(key[25:34], "0"),
+	}, nil
+}
+
+// accessKeyDigit is the mod 11 check digit, with weights 2 to 9 from the
+// rightmost digit; remainders 0 and 1 give 0.
+func accessKeyDigit(digits string) byte {
+	sum, weight := 0, 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += int(digits[i]-'0') * weight
+		if weight++; weight > 9 {
+			weight = 2
+		}
+	}
+
+	remainder := sum % 11
+	if remainder < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - remainder)
+}
+
+type nfeInfo struct {
+	ID  string `xml:"Id,attr"`
+	Ide struct {
+		Mod   string `xml:"mod"`
+		NNF   string `xml:"nNF"`
+		DhEmi string `xml:"dhEmi"`
+		DEmi  string `xml:"dEmi"`
+	} `xml:"ide"`
+	Emit struct {
+		CNPJ  string `xml:"CNPJ"`
+		XNome string `xml:"xNome"`
+		XFant string `xml:"xFant"`
+	} `xml:"emit"`
+	Det []struct {
+		NItem string `xml:"nItem,attr"`
+		Prod  struct {
+			CProd  string `xml:"cProd"`
+			CEAN   string `xml:"cEAN"`
+			XProd  string `xml:"xProd"`
+			NCM    string `xml:"NCM"`
+			UCom   string `xml:"uCom"`
+			QCom   string `xml:"qCom"`
+			VUnCom string `xml:"vUnCom"`
+			VProd  string `xml:"vProd"`
+			VDesc  string `xml:"vDesc"`
+		} `xml:"prod"`
+	} `xml:"det"`
+	Total struct {
+		VNF   string `xml:"ICMSTot>vNF"`
+		VDesc string `xml:"ICMSTot>vDesc"`
+	} `xml:"total"`
+}
+
+// ParseXML reads the XML of an NFC-e or NF-e, either the signed nota alone
+// (<NFe>) or as distributed with its authorization (<nfeProc>).
+func ParseXML(r io.Reader) (*Receipt, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	var info *nfeInfo
+	var protocolKey string
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidXML, err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "infNFe":
+			info = &nfeInfo{}
+			if err := decoder.DecodeElement(info, &start); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidXML, err)
+			}
+		case "chNFe":
+			if err := decoder.DecodeElement(&protocolKey, &start); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidXML, err)
+			}
+		}
+	}
+
+	if info == nil {
+		return nil, fmt.Errorf("%w: elemento infNFe não encontrado", ErrInvalidXML)
+	}
+
+	key, err := ParseAccessKey(firstNonEmpty(strings.TrimPrefix(info.ID, "NFe"), protocolKey))
+	if err != nil {
+		return nil, err
+	}
+
+	issuedAt, err := parseIssueDate(firstNonEmpty(info.Ide.DhEmi, info.Ide.DEmi))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidXML, err)
+	}
+
+	total, err := parseDecimal(info.Total.VNF)
+	if err != nil {
+		return nil, fmt.Errorf("%w: valor total (vNF) inválido", ErrInvalidXML)
+	}
+	discount, _ := parseDecimal(info.Total.VDesc)
+
+	receipt := &Receipt{
+		AccessKey:      key.Key,
+		Model:          firstNonEmpty(info.Ide.Mod, key.Model),
+		Number:         firstNonEmpty(info.Ide.NNF, key.Number),
+		IssuerCNPJ:     firstNonEmpty(nonDigits.ReplaceAllString(info.Emit.CNPJ, ""), key.IssuerCNPJ),
+		IssuerName:     strings.TrimSpace(firstNonEmpty(info.Emit.XFant, info.Emit.XNome)),
+		IssuedAt:       issuedAt,
+		TotalAmount:    total,
+		DiscountAmount: discount,
+		Source:         SourceXML,
+		Items:          make([]Item, 0, len(info.Det)),
+	}
+
+	for i, det := range info.Det {
+		number, err := strconv.Atoi(det.NItem)
+		if err != nil {
+			number = i + 1
+		}
+
+		quantity, err := parseDecimal(det.Prod.QCom)
+		if err != nil {
+			return nil, fmt.Errorf("%w: quantidade do item %d inválida", ErrInvalidXML, number)
+		}
+		unitPrice, err := parseDecimal(det.Prod.VUnCom)
+		if err != nil {
+			return nil, fmt.Errorf("%w: valor unitário do item %d inválido", ErrInvalidXML, number)
+		}
+		gross, err := parseDecimal(det.Prod.VProd)
+		if err != nil {
+			return nil, fmt.Errorf("%w: valor do item %d inválido", ErrInvalidXML, number)
+		}
+		itemDiscount, _ := parseDecimal(det.Prod.VDesc)
+
+		receipt.Items = append(receipt.Items, Item{
+			Number:      number,
+			Code:        strings.TrimSpace(det.Prod.CProd),
+			EAN:         normalizeEAN(det.Prod.CEAN),
+			Description: strings.Join(strings.Fields(det.Prod.XProd), " "),
+			NCM:         strings.TrimSpace(det.Prod.NCM),
+			Quantity:    quantity,
+			Unit:        strings.TrimSpace(det.Prod.UCom),
+			UnitPrice:   unitPrice,
+			Discount:    itemDiscount,
+			TotalAmount: round2(gross - itemDiscount),
+		})
+	}
+
+	if receipt.TotalAmount == 0 {
+		receipt.TotalAmount = itemsTotal(receipt.Items)
+	}
+
+	return receipt, nil
+}
+
+// QRCode is what the QR code of an NFC-e tells about the nota. The online
+// QR code (version 2 "p=chave|2|amb|csc|hash", version 3 "p=chave|3|amb")
+// carries only the access key; the offline one and the version 1 URL also
+// carry the day of issue and the total.
+type QRCode struct {
+	AccessKey   *AccessKey
+	IssuedAt    *time.Time
+	TotalAmount float64
+}
+
+func ParseQRCode(value string) (*QRCode, error) {
+	value = strings.TrimSpace(value)
+	if i := strings.Index(value, "?"); i >= 0 {
+		value = value[i+1:]
+	}
+
+	params, err := url.ParseQuery(value)
+	if err != nil || (!params.Has("p") && !params.Has("chNFe")) {
+		params = url.Values{"p": {value}}
+	}
+
+	if key := params.Get("chNFe"); key != "" {
+		return parseQRCodeV1(params)
+	}
+
+	parts := strings.Split(params.Get("p"), "|")
+	key, err := ParseAccessKey(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	qr := &QRCode{AccessKey: key}
+
+	// Offline: chave|versão|ambiente|dia|valor|...
+	if len(parts) >= 6 && len(parts[3]) <= 2 {
+		day, dayErr := strconv.Atoi(parts[3])
+		total, totalErr := parseDecimal(parts[4])
+		if dayErr != nil || totalErr != nil || day < 1 || day > 31 {
+			return nil, fmt.Errorf("%w: dia ou valor da emissão offline inválido", ErrInvalidQRCode)
+		}
+		issuedAt := key.IssuedIn.AddDate(0, 0, day-1)
+		qr.IssuedAt = &issuedAt
+		qr.TotalAmount = total
+	}
+
+	return qr, nil
+}
+
+// parseQRCodeV1 reads the first QR code layout, whose parameters are named
+// and whose date is the dhEmi text written in hexadecimal.
+func parseQRCodeV1(params url.Values) (*QRCode, error) {
+	key, err := ParseAccessKey(params.Get("chNFe"))
+	if err != nil {
+		return nil, err
+	}
+
+	qr := &QRCode{AccessKey: key}
+
+	if total := params.Get("vNF"); total != "" {
+		if qr.TotalAmount, err = parseDecimal(total); err != nil {
+			return nil, fmt.Errorf("%w: valor (vNF) inválido", ErrInvalidQRCode)
+		}
+	}
+
+	if encoded := params.Get("dhEmi"); encoded != "" {
+		decoded := make([]byte, 0, len(encoded)/2)
+		for i := 0; i+1 < len(encoded); i += 2 {
+			b, err := strconv.ParseUint(encoded[i:i+2], 16, 8)
+			if err != nil {
+				return nil, fmt.Errorf("%w: data (dhEmi) inválida", ErrInvalidQRCode)
+			}
+			decoded = append(decoded, byte(b))
+		}
+		issuedAt, err := parseIssueDate(string(decoded))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQRCode, err)
+		}
+		qr.IssuedAt = &issuedAt
+	}
+
+	return qr, nil
+}
+
+func parseIssueDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("data de emissão inválida: %s", value)
+}
+
+// parseDecimal reads the amounts of the nota, always written with a dot.
+func parseDecimal(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	if !decimalPattern.MatchString(value) {
+		return 0, fmt.Errorf("valor inválido: %s", value)
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// normalizeEAN drops the "SEM GTIN" placeholder and anything that is not a
+// barcode.
+func normalizeEAN(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) < 8 || nonDigits.MatchString(value) {
+		return ""
+	}
+	return value
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
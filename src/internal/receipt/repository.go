@@ -0,0 +1,150 @@
+package receipt
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrReceiptNotFound        = errors.New("nota fiscal não encontrada")
+	ErrReceiptAlreadyImported = errors.New("nota fiscal já importada")
+)
+
+type Repository interface {
+	Create(receipt *Receipt) error
+	Update(receipt *Receipt) error
+	FindByID(id, userID string) (*Receipt, error)
+	FindByAccessKey(userID, accessKey string) (*Receipt, error)
+	FindByUserID(userID string, query ListReceiptsQuery) ([]*Receipt, error)
+	FindLinkedExpenseIDs(userID string) (map[string]bool, error)
+	Delete(id, userID string) error
+}
+
+type memoryRepository struct {
+	receipts map[string]*Receipt
+	mu       sync.RWMutex
+}
+
+func NewRepository() Repository {
+	return &memoryRepository{
+		receipts: make(map[string]*Receipt),
+	}
+}
+
+func (r *memoryRepository) Create(receipt *Receipt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.receipts {
+		if existing.UserID == receipt.UserID && existing.AccessKey == receipt.AccessKey {
+			return ErrReceiptAlreadyImported
+		}
+	}
+
+	stored := *receipt
+	stored.Items = append([]Item(nil), receipt.Items...)
+	r.receipts[receipt.ID] = &stored
+	return nil
+}
+
+func (r *memoryRepository) Update(receipt *Receipt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.receipts[receipt.ID]
+	if !ok || existing.UserID != receipt.UserID {
+		return ErrReceiptNotFound
+	}
+
+	stored := *receipt
+	stored.Items = append([]Item(nil), receipt.Items...)
+	r.receipts[receipt.ID] = &stored
+	return nil
+}
+
+func (r *memoryRepository) FindByID(id, userID string) (*Receipt, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	receipt, ok := r.receipts[id]
+	if !ok || receipt.UserID != userID {
+		return nil, ErrReceiptNotFound
+	}
+
+	return copyReceipt(receipt), nil
+}
+
+func (r *memoryRepository) FindByAccessKey(userID, accessKey string) (*Receipt, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, receipt := range r.receipts {
+		if receipt.UserID == userID && receipt.AccessKey == accessKey {
+			return copyReceipt(receipt), nil
+		}
+	}
+
+	return nil, ErrReceiptNotFound
+}
+
+func (r *memoryRepository) FindByUserID(userID string, query ListReceiptsQuery) ([]*Receipt, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	receipts := []*Receipt{}
+	for _, receipt := range r.receipts {
+		if receipt.UserID != userID {
+			continue
+		}
+		if query.StartDate != nil && receipt.IssuedAt.Before(*query.StartDate) {
+			continue
+		}
+		if query.EndDate != nil && receipt.IssuedAt.After(*query.EndDate) {
+			continue
+		}
+		if query.ExpenseID != "" && receipt.ExpenseID != query.ExpenseID {
+			continue
+		}
+		receipts = append(receipts, copyReceipt(receipt))
+	}
+
+	sort.Slice(receipts, func(i, j int) bool {
+		return receipts[i].IssuedAt.After(receipts[j].IssuedAt)
+	})
+
+	return receipts, nil
+}
+
+func (r *memoryRepository) FindLinkedExpenseIDs(userID string) (map[string]bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	linked := make(map[string]bool)
+	for _, receipt := range r.receipts {
+		if receipt.UserID == userID && receipt.ExpenseID != "" {
+			linked[receipt.ExpenseID] = true
+		}
+	}
+
+	return linked, nil
+}
+
+func (r *memoryRepository) Delete(id, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	receipt, ok := r.receipts[id]
+	if !ok || receipt.UserID != userID {
+		return ErrReceiptNotFound
+	}
+
+	delete(r.receipts, id)
+	return nil
+}
+
+func copyReceipt(receipt *Receipt) *Receipt {
+	copied := *receipt
+	copied.Items = append([]Item{}, receipt.Items...)
+	return &copied
+}
@@ -0,0 +1,345 @@
+package receipt
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+type sqlRepository struct {
+	db *sql.DB
+}
+
+func NewSQLRepository(db *sql.DB) Repository {
+	return &sqlRepository{
+		db: db,
+	}
+}
+
+const receiptColumns = `id, user_id, expense_id, access_key, model, number, issuer_cnpj, issuer_name, issued_at, 
+	total_amount, discount_amount, source, created_at, updated_at`
+
+const itemColumns = `receipt_id, number, code, ean, description, ncm, quantity, unit, unit_price, discount, total_amount`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func scanReceipt(row rowScanner) (*Receipt, error) {
+	receipt := &Receipt{Items: []Item{}}
+	var expenseID, issuerName sql.NullString
+
+	err := row.Scan(
+		&receipt.ID,
+		&receipt.UserID,
+		&expenseID,
+		&receipt.AccessKey,
+		&receipt.Model,
+		&receipt.Number,
+		&receipt.IssuerCNPJ,
+		&issuerName,
+		&receipt.IssuedAt,
+		&receipt.TotalAmount,
+		&receipt.DiscountAmount,
+		&receipt.Source,
+		&receipt.CreatedAt,
+		&receipt.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt.ExpenseID = expenseID.String
+	receipt.IssuerName = issuerName.String
+	return receipt, nil
+}
+
+func (r *sqlRepository) Create(receipt *Receipt) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		`INSERT INTO receipts (`+receiptColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		receipt.ID,
+		receipt.UserID,
+		nullString(receipt.ExpenseID),
+		receipt.AccessKey,
+		receipt.Model,
+		receipt.Number,
+		receipt.IssuerCNPJ,
+		nullString(receipt.IssuerName),
+		receipt.IssuedAt,
+		receipt.TotalAmount,
+		receipt.DiscountAmount,
+		receipt.Source,
+		receipt.CreatedAt,
+		receipt.UpdatedAt,
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return ErrReceiptAlreadyImported
+		}
+		return err
+	}
+
+	if err := insertItems(tx, receipt.ID, receipt.Items); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *sqlRepository) Update(receipt *Receipt) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		`UPDATE receipts SET expense_id = ?, model = ?, number = ?, issuer_cnpj = ?, issuer_name = ?, issued_at = ?, 
+			total_amount = ?, discount_amount = ?, source = ?, updated_at = ? 
+		WHERE id = ? AND user_id = ?`,
+		nullString(receipt.ExpenseID),
+		receipt.Model,
+		receipt.Number,
+		receipt.IssuerCNPJ,
+		nullString(receipt.IssuerName),
+		receipt.IssuedAt,
+		receipt.TotalAmount,
+		receipt.DiscountAmount,
+		receipt.Source,
+		receipt.UpdatedAt,
+		receipt.ID,
+		receipt.UserID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrReceiptNotFound
+	}
+
+	if _, err := tx.Exec(`DELETE FROM receipt_items WHERE receipt_id = ?`, receipt.ID); err != nil {
+		return err
+	}
+
+	if err := insertItems(tx, receipt.ID, receipt.Items); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// itemInsertChunk keeps each multi-row INSERT well below SQLite's limit of
+// 999 bound parameters.
+const itemInsertChunk = 50
+
+func insertItems(db execer, receiptID string, items []Item) error {
+	for start := 0; start < len(items); start += itemInsertChunk {
+		end := min(start+itemInsertChunk, len(items))
+		chunk := items[start:end]
+
+		placeholders := strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?), ", len(chunk)), ", ")
+		args := make([]interface{}, 0, len(chunk)*11)
+		for _, item := range chunk {
+			args = append(args,
+				receiptID,
+				item.Number,
+				nullString(item.Code),
+				nullString(item.EAN),
+				item.Description,
+				nullString(item.NCM),
+				item.Quantity,
+				nullString(item.Unit),
+				item.UnitPrice,
+				item.Discount,
+				item.TotalAmount,
+			)
+		}
+
+		if _, err := db.Exec(`INSERT INTO receipt_items (`+itemColumns+`) VALUES `+placeholders, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *sqlRepository) FindByID(id, userID string) (*Receipt, error) {
+	return r.findOne(`SELECT `+receiptColumns+` FROM receipts WHERE id = ? AND user_id = ?`, id, userID)
+}
+
+func (r *sqlRepository) FindByAccessKey(userID, accessKey string) (*Receipt, error) {
+	return r.findOne(`SELECT `+receiptColumns+` FROM receipts WHERE user_id = ? AND access_key = ?`, userID, accessKey)
+}
+
+func (r *sqlRepository) findOne(query string, args ...interface{}) (*Receipt, error) {
+	receipt, err := scanReceipt(r.db.QueryRow(query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrReceiptNotFound
+		}
+		return nil, err
+	}
+
+	if err := r.loadItems([]*Receipt{receipt}); err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+func (r *sqlRepository) FindByUserID(userID string, query ListReceiptsQuery) ([]*Receipt, error) {
+	queryStr := `SELECT ` + receiptColumns + ` FROM receipts WHERE user_id = ?`
+	args := []interface{}{userID}
+
+	if query.StartDate != nil {
+		queryStr += " AND issued_at >= ?"
+		args = append(args, query.StartDate)
+	}
+
+	if query.EndDate != nil {
+		queryStr += " AND issued_at <= ?"
+		args = append(args, query.EndDate)
+	}
+
+	if query.ExpenseID != "" {
+		queryStr += " AND expense_id = ?"
+		args = append(args, query.ExpenseID)
+	}
+
+	queryStr += " ORDER BY issued_at DESC"
+
+	rows, err := r.db.Query(queryStr, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	receipts := []*Receipt{}
+	for rows.Next() {
+		receipt, err := scanReceipt(rows)
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadItems(receipts); err != nil {
+		return nil, err
+	}
+
+	return receipts, nil
+}
+
+func (r *sqlRepository) loadItems(receipts []*Receipt) error {
+	if len(receipts) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*Receipt, len(receipts))
+	args := make([]interface{}, 0, len(receipts))
+	for _, receipt := range receipts {
+		byID[receipt.ID] = receipt
+		args = append(args, receipt.ID)
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+	rows, err := r.db.Query(`SELECT `+itemColumns+` FROM receipt_items 
+		WHERE receipt_id IN (`+placeholders+`) ORDER BY receipt_id, number`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item Item
+		var receiptID string
+		var code, ean, ncm, unit sql.NullString
+
+		if err := rows.Scan(
+			&receiptID,
+			&item.Number,
+			&code,
+			&ean,
+			&item.Description,
+			&ncm,
+			&item.Quantity,
+			&unit,
+			&item.UnitPrice,
+			&item.Discount,
+			&item.TotalAmount,
+		); err != nil {
+			return err
+		}
+
+		item.Code = code.String
+		item.EAN = ean.String
+		item.NCM = ncm.String
+		item.Unit = unit.String
+		byID[receiptID].Items = append(byID[receiptID].Items, item)
+	}
+
+	return rows.Err()
+}
+
+func (r *sqlRepository) FindLinkedExpenseIDs(userID string) (map[string]bool, error) {
+	rows, err := r.db.Query(`SELECT expense_id FROM receipts WHERE user_id = ? AND expense_id IS NOT NULL`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	linked := make(map[string]bool)
+	for rows.Next() {
+		var expenseID string
+		if err := rows.Scan(&expenseID); err != nil {
+			return nil, err
+		}
+		linked[expenseID] = true
+	}
+
+	return linked, rows.Err()
+}
+
+func (r *sqlRepository) Delete(id, userID string) error {
+	result, err := r.db.Exec(`DELETE FROM receipts WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrReceiptNotFound
+	}
+
+	return nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
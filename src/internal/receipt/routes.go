@@ -0,0 +1,16 @@
+package receipt
+
+import "github.com/gin-gonic/gin"
+
+func RegisterRoutes(rg *gin.RouterGroup, handler *Handler) {
+	receipts := rg.Group("/receipts")
+	{
+		receipts.GET("", handler.List)
+		receipts.POST("/xml", handler.ImportXML)
+		receipts.POST("/qrcode", handler.ImportQRCode)
+		receipts.GET("/products", handler.Products)
+		receipts.GET("/:id", handler.GetByID)
+		receipts.PUT("/:id/expense", handler.LinkExpense)
+		receipts.DELETE("/:id", handler.Delete)
+	}
+}
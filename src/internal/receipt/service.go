@@ -0,0 +1,271 @@
+package receipt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"gastei-quanto/src/internal/expense"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	ImportXML(userID string, file io.Reader) (*ImportResponse, error)
+	ImportQRCode(userID string, req QRCodeRequest) (*ImportResponse, error)
+	GetByID(id, userID string) (*Receipt, error)
+	List(userID string, query ListReceiptsQuery) ([]*Receipt, error)
+	LinkExpense(id, userID string, req LinkExpenseRequest) (*Receipt, error)
+	Delete(id, userID string) error
+	Products(userID string, query ProductQuery) ([]ProductSummary, error)
+}
+
+type service struct {
+	repo           Repository
+	expenseService expense.Service
+}
+
+func NewService(repo Repository, expenseService expense.Service) Service {
+	return &service{
+		repo:           repo,
+		expenseService: expenseService,
+	}
+}
+
+// ImportXML saves the nota and its items. The XML of a nota first read from
+// its QR code completes it; importing the same nota twice is an error.
+func (s *service) ImportXML(userID string, file io.Reader) (*ImportResponse, error) {
+	parsed, err := ParseXML(file)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindByAccessKey(userID, parsed.AccessKey)
+	if err != nil && !errors.Is(err, ErrReceiptNotFound) {
+		return nil, err
+	}
+
+	if existing != nil {
+		if existing.Source == SourceXML {
+			return nil, ErrReceiptAlreadyImported
+		}
+		parsed.ID = existing.ID
+		parsed.ExpenseID = existing.ExpenseID
+		parsed.CreatedAt = existing.CreatedAt
+	}
+
+	return s.save(userID, parsed, existing != nil)
+}
+
+func (s *service) ImportQRCode(userID string, req QRCodeRequest) (*ImportResponse, error) {
+	qr, err := ParseQRCode(req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	items := req.Items
+	for i := range items {
+		if items[i].Number == 0 {
+			items[i].Number = i + 1
+		}
+		if items[i].TotalAmount == 0 {
+			items[i].TotalAmount = round2(items[i].Quantity*items[i].UnitPrice - items[i].Discount)
+		}
+	}
+
+	total := req.TotalAmount
+	if total == 0 {
+		total = qr.TotalAmount
+	}
+	if total == 0 {
+		total = itemsTotal(items)
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("%w: o QR code online não traz o valor da nota; informe total_amount ou os itens", ErrInvalidQRCode)
+	}
+
+	issuedAt := qr.IssuedAt
+	if req.IssuedAt != nil {
+		issuedAt = req.IssuedAt
+	}
+	if issuedAt == nil {
+		return nil, fmt.Errorf("%w: o QR code online não traz a data da nota; informe issued_at", ErrInvalidQRCode)
+	}
+
+	if _, err := s.repo.FindByAccessKey(userID, qr.AccessKey.Key); err == nil {
+		return nil, ErrReceiptAlreadyImported
+	} else if !errors.Is(err, ErrReceiptNotFound) {
+		return nil, err
+	}
+
+	if items == nil {
+		items = []Item{}
+	}
+
+	return s.save(userID, &Receipt{
+		AccessKey:   qr.AccessKey.Key,
+		Model:       qr.AccessKey.Model,
+		Number:      qr.AccessKey.Number,
+		IssuerCNPJ:  qr.AccessKey.IssuerCNPJ,
+		IssuerName:  strings.TrimSpace(req.IssuerName),
+		IssuedAt:    *issuedAt,
+		TotalAmount: total,
+		Source:      SourceQRCode,
+		Items:       items,
+	}, false)
+}
+
+func (s *service) save(userID string, receipt *Receipt, update bool) (*ImportResponse, error) {
+	now := time.Now()
+	receipt.UserID = userID
+	receipt.UpdatedAt = now
+	if receipt.ID == "" {
+		receipt.ID = uuid.New().String()
+	}
+	if receipt.CreatedAt.IsZero() {
+		receipt.CreatedAt = now
+	}
+
+	response := &ImportResponse{Receipt: receipt}
+
+	if receipt.ExpenseID == "" {
+		matched, err := s.matchExpense(userID, receipt)
+		if err != nil {
+			return nil, err
+		}
+		if matched != nil {
+			receipt.ExpenseID = matched.ID
+			response.Expense = matched
+		}
+	}
+
+	if update {
+		if err := s.repo.Update(receipt); err != nil {
+			return nil, err
+		}
+	} else if err := s.repo.Create(receipt); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *service) GetByID(id, userID string) (*Receipt, error) {
+	return s.repo.FindByID(id, userID)
+}
+
+func (s *service) List(userID string, query ListReceiptsQuery) ([]*Receipt, error) {
+	return s.repo.FindByUserID(userID, query)
+}
+
+// LinkExpense links the nota to an expense chosen by the user, or unlinks it
+// when no expense is given.
+func (s *service) LinkExpense(id, userID string, req LinkExpenseRequest) (*Receipt, error) {
+	receipt, err := s.repo.FindByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ExpenseID != "" {
+		if _, err := s.expenseService.GetByID(req.ExpenseID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	receipt.ExpenseID = req.ExpenseID
+	receipt.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(receipt); err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+func (s *service) Delete(id, userID string) error {
+	return s.repo.Delete(id, userID)
+}
+
+// Products adds up the items bought in the period, most spent first.
+func (s *service) Products(userID string, query ProductQuery) ([]ProductSummary, error) {
+	receipts, err := s.repo.FindByUserID(userID, ListReceiptsQuery{
+		StartDate: query.StartDate,
+		EndDate:   query.EndDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	filter := expense.NormalizeDescription(query.Description)
+	byProduct := make(map[string]*ProductSummary)
+
+	// Oldest first, so the last price is the most recent one.
+	sort.Slice(receipts, func(i, j int) bool {
+		return receipts[i].IssuedAt.Before(receipts[j].IssuedAt)
+	})
+
+	for _, receipt := range receipts {
+		for _, item := range receipt.Items {
+			normalized := expense.NormalizeDescription(item.Description)
+			if filter != "" && !strings.Contains(normalized, filter) {
+				continue
+			}
+
+			key := item.EAN
+			if key == "" {
+				key = normalized
+			}
+
+			summary, ok := byProduct[key]
+			if !ok {
+				summary = &ProductSummary{EAN: item.EAN}
+				byProduct[key] = summary
+			}
+
+			summary.Product = item.Description
+			summary.Unit = item.Unit
+			summary.Purchases++
+			summary.Quantity += item.Quantity
+			summary.TotalAmount += item.TotalAmount
+			if item.Quantity > 0 {
+				summary.LastPrice = round2(item.TotalAmount / item.Quantity)
+			}
+			summary.LastPurchased = receipt.IssuedAt
+		}
+	}
+
+	products := make([]ProductSummary, 0, len(byProduct))
+	for _, summary := range byProduct {
+		summary.TotalAmount = round2(summary.TotalAmount)
+		summary.Quantity = math.Round(summary.Quantity*1000) / 1000
+		if summary.Quantity > 0 {
+			summary.AveragePrice = round2(summary.TotalAmount / summary.Quantity)
+		}
+		products = append(products, *summary)
+	}
+
+	sort.Slice(products, func(i, j int) bool {
+		if products[i].TotalAmount != products[j].TotalAmount {
+			return products[i].TotalAmount > products[j].TotalAmount
+		}
+		return products[i].Product < products[j].Product
+	})
+
+	return products, nil
+}
+
+func itemsTotal(items []Item) float64 {
+	total := 0.0
+	for _, item := range items {
+		total += item.TotalAmount
+	}
+	return round2(total)
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
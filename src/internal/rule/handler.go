@@ -0,0 +1,187 @@
+package rule
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// Create godoc
+// @Summary Cria uma regra de categorização
+// @Description Cria uma regra que define a categoria das transações importadas sem categoria. match_type: contains (contém), prefix (começa com), merchant (descrição exata) ou regex. Condições opcionais: faixa de valor (min_amount/max_amount, em valor absoluto), dias da semana (weekdays, 0 = domingo) e tipo (expense ou income). As regras do usuário rodam antes das categorias padrão, da maior prioridade para a menor
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body RuleRequest true "Dados da regra"
+// @Success 201 {object} rule.Rule
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /rules [post]
+func (h *Handler) Create(c *gin.Context) {
+	var req RuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.service.Create(c.GetString("user_id"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// List godoc
+// @Summary Lista as regras de categorização
+// @Description Retorna as regras do usuário na ordem em que são aplicadas
+// @Tags rules
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /rules [get]
+func (h *Handler) List(c *gin.Context) {
+	rules, err := h.service.List(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rules": rules,
+		"count": len(rules),
+	})
+}
+
+// GetByID godoc
+// @Summary Busca uma regra de categorização por ID
+// @Description Retorna a regra de categorização
+// @Tags rules
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da regra"
+// @Success 200 {object} rule.Rule
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /rules/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	rule, err := h.service.GetByID(c.Param("id"), c.GetString("user_id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// Update godoc
+// @Summary Atualiza uma regra de categorização
+// @Description Substitui os dados da regra. Sem enabled, a regra mantém o estado atual
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da regra"
+// @Param request body RuleRequest true "Dados da regra"
+// @Success 200 {object} rule.Rule
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /rules/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	var req RuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.service.Update(c.Param("id"), c.GetString("user_id"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// Delete godoc
+// @Summary Remove uma regra de categorização
+// @Description Remove a regra; as categorias já aplicadas não são alteradas
+// @Tags rules
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da regra"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /rules/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Param("id"), c.GetString("user_id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Regra removida",
+	})
+}
+
+// Test godoc
+// @Summary Testa as regras de categorização
+// @Description Informa qual regra categorizaria a transação, sem salvar nada. Sem date, usa hoje; sem type, expense
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body Candidate true "Transação de exemplo"
+// @Success 200 {object} rule.TestRuleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /rules/test [post]
+func (h *Handler) Test(c *gin.Context) {
+	var candidate Candidate
+	if err := c.ShouldBindJSON(&candidate); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.Test(c.GetString("user_id"), candidate)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrRuleNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrInvalidRule):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
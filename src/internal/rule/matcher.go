@@ -0,0 +1,134 @@
+package rule
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gastei-quanto/src/internal/expense"
+)
+
+// Matcher holds a user's enabled rules, compiled and in the order they run.
+type Matcher struct {
+	rules []*compiledRule
+}
+
+type compiledRule struct {
+	*Rule
+	pattern string
+	regex   *regexp.Regexp
+}
+
+func NewMatcher(rules []*Rule) (*Matcher, error) {
+	matcher := &Matcher{}
+
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+
+		compiled, err := compile(rule)
+		if err != nil {
+			return nil, err
+		}
+		matcher.rules = append(matcher.rules, compiled)
+	}
+
+	sort.SliceStable(matcher.rules, func(i, j int) bool {
+		if matcher.rules[i].Priority != matcher.rules[j].Priority {
+			return matcher.rules[i].Priority > matcher.rules[j].Priority
+		}
+		return matcher.rules[i].CreatedAt.Before(matcher.rules[j].CreatedAt)
+	})
+
+	return matcher, nil
+}
+
+func compile(rule *Rule) (*compiledRule, error) {
+	compiled := &compiledRule{Rule: rule}
+
+	if rule.MatchType == MatchRegex {
+		regex, err := regexp.Compile("(?i)" + rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: expressão regular inválida: %v", ErrInvalidRule, err)
+		}
+		compiled.regex = regex
+		return compiled, nil
+	}
+
+	compiled.pattern = expense.NormalizeDescription(rule.Pattern)
+	if compiled.pattern == "" {
+		return nil, fmt.Errorf("%w: o padrão precisa ter letras ou números", ErrInvalidRule)
+	}
+
+	return compiled, nil
+}
+
+// Match returns the first rule the transaction matches, or nil.
+func (m *Matcher) Match(candidate Candidate) *Rule {
+	if m == nil || len(m.rules) == 0 {
+		return nil
+	}
+
+	normalized := expense.NormalizeDescription(candidate.Description)
+	for _, rule := range m.rules {
+		if rule.matches(candidate, normalized) {
+			return rule.Rule
+		}
+	}
+
+	return nil
+}
+
+func (r *compiledRule) matches(candidate Candidate, normalized string) bool {
+	switch r.MatchType {
+	case MatchContains:
+		if !strings.Contains(normalized, r.pattern) {
+			return false
+		}
+	case MatchPrefix:
+		if !strings.HasPrefix(normalized, r.pattern) {
+			return false
+		}
+	case MatchMerchant:
+		if normalized != r.pattern {
+			return false
+		}
+	case MatchRegex:
+		if !r.regex.MatchString(candidate.Description) {
+			return false
+		}
+	default:
+		return false
+	}
+
+	amount := math.Abs(candidate.Amount)
+	if r.MinAmount != nil && amount < *r.MinAmount {
+		return false
+	}
+	if r.MaxAmount != nil && amount > *r.MaxAmount {
+		return false
+	}
+
+	if r.Type != "" && candidate.Type != r.Type {
+		return false
+	}
+
+	if len(r.Weekdays) > 0 {
+		weekday := int(candidate.Date.Weekday())
+		found := false
+		for _, day := range r.Weekdays {
+			if day == weekday {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
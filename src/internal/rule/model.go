@@ -0,0 +1,61 @@
+package rule
+
+import "time"
+
+const (
+	MatchContains = "contains"
+	MatchPrefix   = "prefix"
+	MatchRegex    = "regex"
+	MatchMerchant = "merchant"
+)
+
+// Rule sets the category of imported transactions whose description matches
+// Pattern. Contains, prefix and merchant (the whole description) compare
+// descriptions ignoring case, accents and punctuation; regex is matched
+// against the description as is, ignoring case. The other conditions are
+// optional: the amount range (in absolute value, inclusive), the weekdays
+// (0 is Sunday) and the type (expense or income). Rules with a higher
+// priority run first; on a tie the oldest rule wins.
+type Rule struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	MatchType string    `json:"match_type"`
+	Pattern   string    `json:"pattern"`
+	MinAmount *float64  `json:"min_amount,omitempty"`
+	MaxAmount *float64  `json:"max_amount,omitempty"`
+	Weekdays  []int     `json:"weekdays,omitempty"`
+	Type      string    `json:"type,omitempty"`
+	Priority  int       `json:"priority"`
+	Category  string    `json:"category"`
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type RuleRequest struct {
+	Name      string   `json:"name"`
+	MatchType string   `json:"match_type" binding:"required,oneof=contains prefix regex merchant"`
+	Pattern   string   `json:"pattern" binding:"required"`
+	MinAmount *float64 `json:"min_amount" binding:"omitempty,gte=0"`
+	MaxAmount *float64 `json:"max_amount" binding:"omitempty,gte=0"`
+	Weekdays  []int    `json:"weekdays" binding:"omitempty,dive,min=0,max=6"`
+	Type      string   `json:"type" binding:"omitempty,oneof=income expense"`
+	Priority  int      `json:"priority"`
+	Category  string   `json:"category" binding:"required"`
+	Enabled   *bool    `json:"enabled"`
+}
+
+// Candidate is the transaction a rule is checked against. Type is expense
+// or income.
+type Candidate struct {
+	Description string    `json:"description" binding:"required"`
+	Amount      float64   `json:"amount"`
+	Date        time.Time `json:"date"`
+	Type        string    `json:"type" binding:"omitempty,oneof=income expense"`
+}
+
+type TestRuleResponse struct {
+	Rule     *Rule  `json:"rule"`
+	Category string `json:"category,omitempty"`
+}
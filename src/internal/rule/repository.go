@@ -0,0 +1,103 @@
+package rule
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrRuleNotFound = errors.New("regra não encontrada")
+	ErrInvalidRule  = errors.New("regra inválida")
+)
+
+type Repository interface {
+	Create(rule *Rule) error
+	Update(rule *Rule) error
+	FindByID(id, userID string) (*Rule, error)
+	FindByUserID(userID string) ([]*Rule, error)
+	Delete(id, userID string) error
+}
+
+type memoryRepository struct {
+	rules map[string]*Rule
+	mu    sync.RWMutex
+}
+
+func NewRepository() Repository {
+	return &memoryRepository{
+		rules: make(map[string]*Rule),
+	}
+}
+
+func (r *memoryRepository) Create(rule *Rule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *rule
+	r.rules[rule.ID] = &stored
+	return nil
+}
+
+func (r *memoryRepository) Update(rule *Rule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.rules[rule.ID]
+	if !ok || existing.UserID != rule.UserID {
+		return ErrRuleNotFound
+	}
+
+	stored := *rule
+	r.rules[rule.ID] = &stored
+	return nil
+}
+
+func (r *memoryRepository) FindByID(id, userID string) (*Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rule, ok := r.rules[id]
+	if !ok || rule.UserID != userID {
+		return nil, ErrRuleNotFound
+	}
+
+	copied := *rule
+	return &copied, nil
+}
+
+// FindByUserID returns the user's rules in the order they run.
+func (r *memoryRepository) FindByUserID(userID string) ([]*Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := []*Rule{}
+	for _, rule := range r.rules {
+		if rule.UserID == userID {
+			copied := *rule
+			rules = append(rules, &copied)
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority > rules[j].Priority
+		}
+		return rules[i].CreatedAt.Before(rules[j].CreatedAt)
+	})
+
+	return rules, nil
+}
+
+func (r *memoryRepository) Delete(id, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rule, ok := r.rules[id]
+	if !ok || rule.UserID != userID {
+		return ErrRuleNotFound
+	}
+
+	delete(r.rules, id)
+	return nil
+}
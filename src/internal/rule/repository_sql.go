@@ -0,0 +1,213 @@
+package rule
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+)
+
+type sqlRepository struct {
+	db *sql.DB
+}
+
+func NewSQLRepository(db *sql.DB) Repository {
+	return &sqlRepository{
+		db: db,
+	}
+}
+
+const ruleColumns = `id, user_id, name, match_type, pattern, min_amount, max_amount, weekdays, type, priority, category, 
+	enabled, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRule(row rowScanner) (*Rule, error) {
+	rule := &Rule{}
+	var minAmount, maxAmount sql.NullFloat64
+	var weekdays, ruleType sql.NullString
+
+	err := row.Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.Name,
+		&rule.MatchType,
+		&rule.Pattern,
+		&minAmount,
+		&maxAmount,
+		&weekdays,
+		&ruleType,
+		&rule.Priority,
+		&rule.Category,
+		&rule.Enabled,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if minAmount.Valid {
+		rule.MinAmount = &minAmount.Float64
+	}
+	if maxAmount.Valid {
+		rule.MaxAmount = &maxAmount.Float64
+	}
+	rule.Weekdays = splitWeekdays(weekdays.String)
+	rule.Type = ruleType.String
+
+	return rule, nil
+}
+
+func (r *sqlRepository) Create(rule *Rule) error {
+	query := `INSERT INTO category_rules (` + ruleColumns + `) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.Exec(
+		query,
+		rule.ID,
+		rule.UserID,
+		rule.Name,
+		rule.MatchType,
+		rule.Pattern,
+		nullFloat(rule.MinAmount),
+		nullFloat(rule.MaxAmount),
+		nullString(joinWeekdays(rule.Weekdays)),
+		nullString(rule.Type),
+		rule.Priority,
+		rule.Category,
+		rule.Enabled,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	)
+	return err
+}
+
+func (r *sqlRepository) Update(rule *Rule) error {
+	query := `UPDATE category_rules SET name = ?, match_type = ?, pattern = ?, min_amount = ?, max_amount = ?, weekdays = ?, 
+		type = ?, priority = ?, category = ?, enabled = ?, updated_at = ? 
+		WHERE id = ? AND user_id = ?`
+
+	result, err := r.db.Exec(
+		query,
+		rule.Name,
+		rule.MatchType,
+		rule.Pattern,
+		nullFloat(rule.MinAmount),
+		nullFloat(rule.MaxAmount),
+		nullString(joinWeekdays(rule.Weekdays)),
+		nullString(rule.Type),
+		rule.Priority,
+		rule.Category,
+		rule.Enabled,
+		rule.UpdatedAt,
+		rule.ID,
+		rule.UserID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *sqlRepository) FindByID(id, userID string) (*Rule, error) {
+	query := `SELECT ` + ruleColumns + ` 
+		FROM category_rules WHERE id = ? AND user_id = ?`
+
+	rule, err := scanRule(r.db.QueryRow(query, id, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrRuleNotFound
+		}
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// FindByUserID returns the user's rules in the order they run.
+func (r *sqlRepository) FindByUserID(userID string) ([]*Rule, error) {
+	query := `SELECT ` + ruleColumns + ` 
+		FROM category_rules WHERE user_id = ? 
+		ORDER BY priority DESC, created_at`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []*Rule{}
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+func (r *sqlRepository) Delete(id, userID string) error {
+	result, err := r.db.Exec(`DELETE FROM category_rules WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrRuleNotFound
+	}
+
+	return nil
+}
+
+// Weekdays are stored comma separated ("1,2,3").
+func joinWeekdays(weekdays []int) string {
+	parts := make([]string, len(weekdays))
+	for i, day := range weekdays {
+		parts[i] = strconv.Itoa(day)
+	}
+	return strings.Join(parts, ",")
+}
+
+func splitWeekdays(value string) []int {
+	if value == "" {
+		return nil
+	}
+
+	var weekdays []int
+	for _, part := range strings.Split(value, ",") {
+		if day, err := strconv.Atoi(part); err == nil {
+			weekdays = append(weekdays, day)
+		}
+	}
+	return weekdays
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func nullFloat(value *float64) sql.NullFloat64 {
+	if value == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *value, Valid: true}
+}
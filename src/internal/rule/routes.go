@@ -0,0 +1,15 @@
+package rule
+
+import "github.com/gin-gonic/gin"
+
+func RegisterRoutes(rg *gin.RouterGroup, handler *Handler) {
+	rules := rg.Group("/rules")
+	{
+		rules.GET("", handler.List)
+		rules.POST("", handler.Create)
+		rules.POST("/test", handler.Test)
+		rules.GET("/:id", handler.GetByID)
+		rules.PUT("/:id", handler.Update)
+		rules.DELETE("/:id", handler.Delete)
+	}
+}
@@ -0,0 +1,157 @@
+package rule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Create(userID string, req RuleRequest) (*Rule, error)
+	GetByID(id, userID string) (*Rule, error)
+	List(userID string) ([]*Rule, error)
+	Update(id, userID string, req RuleRequest) (*Rule, error)
+	Delete(id, userID string) error
+	Test(userID string, candidate Candidate) (*TestRuleResponse, error)
+	Matcher(userID string) (*Matcher, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+func (s *service) Create(userID string, req RuleRequest) (*Rule, error) {
+	now := time.Now()
+	rule := &Rule{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Enabled:   true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := applyRequest(rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *service) GetByID(id, userID string) (*Rule, error) {
+	return s.repo.FindByID(id, userID)
+}
+
+// List returns the user's rules in the order they run.
+func (s *service) List(userID string) ([]*Rule, error) {
+	return s.repo.FindByUserID(userID)
+}
+
+func (s *service) Update(id, userID string, req RuleRequest) (*Rule, error) {
+	rule, err := s.repo.FindByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyRequest(rule, req); err != nil {
+		return nil, err
+	}
+	rule.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *service) Delete(id, userID string) error {
+	return s.repo.Delete(id, userID)
+}
+
+// Test tells which rule, if any, would categorize the transaction.
+func (s *service) Test(userID string, candidate Candidate) (*TestRuleResponse, error) {
+	if candidate.Date.IsZero() {
+		candidate.Date = time.Now()
+	}
+	if candidate.Type == "" {
+		candidate.Type = "expense"
+	}
+
+	matcher, err := s.Matcher(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TestRuleResponse{Rule: matcher.Match(candidate)}
+	if response.Rule != nil {
+		response.Category = response.Rule.Category
+	}
+
+	return response, nil
+}
+
+// Matcher loads the user's enabled rules for a categorization run.
+func (s *service) Matcher(userID string) (*Matcher, error) {
+	rules, err := s.repo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMatcher(rules)
+}
+
+func applyRequest(rule *Rule, req RuleRequest) error {
+	if req.MinAmount != nil && req.MaxAmount != nil && *req.MinAmount > *req.MaxAmount {
+		return fmt.Errorf("%w: min_amount maior que max_amount", ErrInvalidRule)
+	}
+
+	category := strings.TrimSpace(req.Category)
+	if category == "" {
+		return fmt.Errorf("%w: informe a categoria", ErrInvalidRule)
+	}
+
+	rule.Name = strings.TrimSpace(req.Name)
+	if rule.Name == "" {
+		rule.Name = strings.TrimSpace(req.Pattern)
+	}
+	rule.MatchType = req.MatchType
+	rule.Pattern = strings.TrimSpace(req.Pattern)
+	rule.MinAmount = req.MinAmount
+	rule.MaxAmount = req.MaxAmount
+	rule.Weekdays = uniqueWeekdays(req.Weekdays)
+	rule.Type = req.Type
+	rule.Priority = req.Priority
+	rule.Category = category
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+
+	_, err := compile(rule)
+	return err
+}
+
+func uniqueWeekdays(weekdays []int) []int {
+	seen := make(map[int]bool)
+	var unique []int
+	for _, day := range weekdays {
+		if !seen[day] {
+			seen[day] = true
+			unique = append(unique, day)
+		}
+	}
+	sort.Ints(unique)
+	return unique
+}
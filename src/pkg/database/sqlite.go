@@ -2,6 +2,7 @@ package database
 
 import (
 	"database/sql"
+	"fmt"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -65,6 +66,149 @@ func (s *SQLiteDatabase) Migrate() error {
 		`CREATE INDEX IF NOT EXISTS idx_expenses_date ON expenses(date)`,
 		`CREATE INDEX IF NOT EXISTS idx_expenses_category ON expenses(category)`,
 		`CREATE INDEX IF NOT EXISTS idx_expenses_type ON expenses(type)`,
+		`CREATE TABLE IF NOT EXISTS import_batches (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			filename TEXT,
+			source TEXT NOT NULL,
+			total_rows INTEGER NOT NULL DEFAULT 0,
+			saved_rows INTEGER NOT NULL DEFAULT 0,
+			rejected_rows INTEGER NOT NULL DEFAULT 0,
+			status TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			rolled_back_at DATETIME,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_import_batches_user_id ON import_batches(user_id)`,
+		`CREATE TABLE IF NOT EXISTS column_mappings (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			name TEXT NOT NULL,
+			date_column TEXT NOT NULL,
+			date_format TEXT NOT NULL DEFAULT '',
+			amount_column TEXT NOT NULL DEFAULT '',
+			debit_column TEXT NOT NULL DEFAULT '',
+			credit_column TEXT NOT NULL DEFAULT '',
+			description_column TEXT NOT NULL DEFAULT '',
+			category_column TEXT NOT NULL DEFAULT '',
+			skip_rows INTEGER NOT NULL DEFAULT 0,
+			sign_convention TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_column_mappings_user_id ON column_mappings(user_id)`,
+		`CREATE TABLE IF NOT EXISTS installment_plans (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			description TEXT NOT NULL,
+			category TEXT NOT NULL,
+			installment_amount REAL NOT NULL,
+			total_installments INTEGER NOT NULL,
+			first_installment_date DATETIME NOT NULL,
+			plan_key TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_installment_plans_user_key ON installment_plans(user_id, plan_key)`,
+		`CREATE TABLE IF NOT EXISTS receipts (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			expense_id TEXT,
+			access_key TEXT NOT NULL,
+			model TEXT NOT NULL,
+			number TEXT NOT NULL,
+			issuer_cnpj TEXT NOT NULL,
+			issuer_name TEXT,
+			issued_at DATETIME NOT NULL,
+			total_amount REAL NOT NULL,
+			discount_amount REAL NOT NULL DEFAULT 0,
+			source TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (expense_id) REFERENCES expenses(id) ON DELETE SET NULL,
+			UNIQUE (user_id, access_key)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_receipts_expense_id ON receipts(expense_id)`,
+		`CREATE TABLE IF NOT EXISTS receipt_items (
+			receipt_id TEXT NOT NULL,
+			number INTEGER NOT NULL,
+			code TEXT,
+			ean TEXT,
+			description TEXT NOT NULL,
+			ncm TEXT,
+			quantity REAL NOT NULL,
+			unit TEXT,
+			unit_price REAL NOT NULL,
+			discount REAL NOT NULL DEFAULT 0,
+			total_amount REAL NOT NULL,
+			PRIMARY KEY (receipt_id, number),
+			FOREIGN KEY (receipt_id) REFERENCES receipts(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS bills (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			barcode TEXT NOT NULL,
+			digitable_line TEXT NOT NULL,
+			bank_code TEXT,
+			bank_name TEXT,
+			segment TEXT,
+			description TEXT NOT NULL,
+			category TEXT NOT NULL,
+			amount REAL NOT NULL,
+			due_date DATETIME,
+			status TEXT NOT NULL,
+			expense_id TEXT,
+			paid_at DATETIME,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (expense_id) REFERENCES expenses(id) ON DELETE SET NULL,
+			UNIQUE (user_id, barcode)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_bills_user_due_date ON bills(user_id, due_date)`,
+		`CREATE TABLE IF NOT EXISTS category_rules (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			name TEXT NOT NULL,
+			match_type TEXT NOT NULL,
+			pattern TEXT NOT NULL,
+			min_amount REAL,
+			max_amount REAL,
+			weekdays TEXT,
+			type TEXT,
+			priority INTEGER NOT NULL DEFAULT 0,
+			category TEXT NOT NULL,
+			enabled BOOLEAN NOT NULL DEFAULT 1,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_category_rules_user_id ON category_rules(user_id)`,
+		`CREATE TABLE IF NOT EXISTS categories (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			name TEXT NOT NULL,
+			name_key TEXT NOT NULL,
+			parent_id TEXT,
+			color TEXT,
+			icon TEXT,
+			archived BOOLEAN NOT NULL DEFAULT 0,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (parent_id) REFERENCES categories(id) ON DELETE SET NULL,
+			UNIQUE(user_id, name_key)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories(parent_id)`,
+		`CREATE TABLE IF NOT EXISTS inbox_addresses (
+			user_id TEXT PRIMARY KEY,
+			token TEXT UNIQUE NOT NULL,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
 	}
 
 	for _, query := range queries {
@@ -73,5 +217,136 @@ func (s *SQLiteDatabase) Migrate() error {
 		}
 	}
 
+	columns := []struct {
+		table      string
+		column     string
+		definition string
+	}{
+		{"expenses", "batch_id", "TEXT REFERENCES import_batches(id) ON DELETE SET NULL"},
+		{"expenses", "fingerprint", "TEXT"},
+		{"import_batches", "skipped_duplicates", "INTEGER NOT NULL DEFAULT 0"},
+		{"expenses", "installment_plan_id", "TEXT REFERENCES installment_plans(id) ON DELETE SET NULL"},
+		{"expenses", "installment_number", "INTEGER"},
+		{"expenses", "original_currency", "TEXT"},
+		{"expenses", "original_amount", "REAL"},
+		{"expenses", "exchange_rate", "REAL"},
+		{"expenses", "taxed_expense_id", "TEXT REFERENCES expenses(id) ON DELETE SET NULL"},
+		{"expenses", "account", "TEXT"},
+		{"expenses", "tags", "TEXT"},
+		{"expenses", "category_source", "TEXT"},
+	}
+
+	for _, c := range columns {
+		if err := addColumnIfMissing(tx, c.table, c.column, c.definition); err != nil {
+			return err
+		}
+	}
+
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_expenses_batch_id ON expenses(batch_id)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_expenses_user_fingerprint ON expenses(user_id, fingerprint) WHERE fingerprint IS NOT NULL`,
+		`CREATE INDEX IF NOT EXISTS idx_expenses_installment_plan_id ON expenses(installment_plan_id)`,
+	}
+
+	for _, query := range indexes {
+		if _, err := tx.Exec(query); err != nil {
+			return err
+		}
+	}
+
+	// Labels the importer saved before import and analysis shared one
+	// category vocabulary.
+	categoryRenames := []struct {
+		from string
+		to   string
+		key  string
+	}{
+		{"Alimentacao", "Alimentação", "alimentacao"},
+		{"Credito", "Créditos", "creditos"},
+	}
+
+	for _, rename := range categoryRenames {
+		for _, table := range []string{"expenses", "installment_plans", "bills", "category_rules"} {
+			query := fmt.Sprintf("UPDATE %s SET category = ? WHERE category = ?", table)
+			if _, err := tx.Exec(query, rename.to, rename.from); err != nil {
+				return err
+			}
+		}
+
+		// A catalog that already has the new name keeps both rows; the
+		// user can merge them.
+		_, err := tx.Exec(
+			`UPDATE categories SET name = ?, name_key = ? 
+			WHERE name = ? AND NOT EXISTS (
+				SELECT 1 FROM categories other WHERE other.user_id = categories.user_id AND other.name_key = ? AND other.id != categories.id)`,
+			rename.to,
+			rename.key,
+			rename.from,
+			rename.key,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Import jobs live in memory, so a batch still running at startup was cut
+	// short by a restart; marking it failed lets the user roll it back.
+	if _, err := tx.Exec(`UPDATE import_batches SET status = 'failed' WHERE status = 'running'`); err != nil {
+		return err
+	}
+
+	// Expenses saved before category_source was recorded: the ones migrated
+	// from another finance app carry the user's categories. The rest stay
+	// unknown, since imports made before import_batches existed have no
+	// batch and cannot be told apart from expenses entered by hand.
+	backfills := []string{
+		`UPDATE expenses SET category_source = 'imported'
+			WHERE category_source IS NULL AND COALESCE(category, '') != '' AND batch_id IN (
+				SELECT id FROM import_batches WHERE source IN ('qif', 'mobills', 'organizze', 'gnucash', 'money lover'))`,
+	}
+
+	for _, query := range backfills {
+		if _, err := tx.Exec(query); err != nil {
+			return err
+		}
+	}
+
 	return tx.Commit()
 }
+
+func addColumnIfMissing(tx *sql.Tx, table, column, definition string) error {
+	rows, err := tx.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	exists := false
+	for rows.Next() {
+		var (
+			cid        int
+			name       string
+			columnType string
+			notNull    int
+			defaultVal sql.NullString
+			primaryKey int
+		)
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultVal, &primaryKey); err != nil {
+			return err
+		}
+		if name == column {
+			exists = true
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if exists {
+		return nil
+	}
+
+	_, err = tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}